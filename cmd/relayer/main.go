@@ -0,0 +1,225 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/key"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	SourceEndpointKey      = "source-endpoint"
+	SourceURIKey           = "source-uri"
+	SourceBlockchainIDKey  = "source-blockchain-id"
+	DestinationEndpointKey = "destination-endpoint"
+	KeyFileKey             = "key-file"
+	WarpAddressKey         = "warp-address"
+	QuorumNumeratorKey     = "quorum-numerator"
+	SigningSubnetIDKey     = "signing-subnet-id"
+	GasLimitKey            = "gas-limit"
+	MaxFeeCapKey           = "max-fee-cap"
+	MaxTipCapKey           = "max-tip-cap"
+	SignedMessageBufferKey = "signed-message-buffer"
+	VerifyOnlyKey          = "verify-only"
+	VerifyFileKey          = "verify-file"
+	PChainHeightKey        = "p-chain-height"
+	MetricsPortKey         = "metrics-port"
+	LogLevelKey            = "log-level"
+
+	MetricsEndpoint = "/metrics" // Endpoint for the Prometheus Metrics Server
+)
+
+var errMissingFlag = errors.New("missing required flag")
+
+type relayerConfig struct {
+	SourceEndpoint      string
+	SourceURI           string
+	SourceBlockchainID  string
+	DestinationEndpoint string
+	Key                 *ecdsa.PrivateKey
+	WarpAddress         common.Address
+	QuorumNumerator     uint64
+	SigningSubnetID     string
+	GasLimit            uint64
+	GasFeeCap           *big.Int
+	GasTipCap           *big.Int
+
+	SignedMessageBufferSize int
+
+	VerifyOnly   bool
+	VerifyFile   string
+	PChainHeight uint64
+}
+
+func buildFlagSet() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("relayer", pflag.ContinueOnError)
+	fs.String(SourceEndpointKey, "", "Specify the RPC Websocket Endpoint of the source chain to watch for warp messages")
+	fs.String(SourceURIKey, "http://127.0.0.1:9650", "Specify the base URI of the source node to fetch aggregate signatures from")
+	fs.String(SourceBlockchainIDKey, "", "Specify the blockchain ID of the source chain")
+	fs.String(DestinationEndpointKey, "", "Specify the RPC Endpoint of the destination chain to deliver warp messages to")
+	fs.String(KeyFileKey, "", "Specify the file containing the hex encoded private key used to sign delivery txs (INSECURE: only use for testing)")
+	fs.String(WarpAddressKey, warp.ContractAddress.Hex(), "Specify the address of the warp precompile")
+	fs.Uint64(QuorumNumeratorKey, warp.WarpDefaultQuorumNumerator, "Specify the quorum numerator of the aggregate signature")
+	fs.String(SigningSubnetIDKey, "", "Specify the subnet whose validators should sign messages sent from the Primary Network (empty uses the source subnet)")
+	fs.Uint64(GasLimitKey, 5_000_000, "Specify the gas limit of delivery txs")
+	fs.Int64(MaxFeeCapKey, 50, "Specify the maximum fee cap of delivery txs denominated in GWei")
+	fs.Int64(MaxTipCapKey, 1, "Specify the max tip cap of delivery txs denominated in GWei")
+	fs.Int(SignedMessageBufferKey, 100, "Specify the number of signed messages that may wait to be delivered before signing blocks (0 delivers each message before signing the next)")
+	fs.Bool(VerifyOnlyKey, false, "Only verify signed warp messages against the P-Chain validator set and print a report of each message, without delivering them")
+	fs.String(VerifyFileKey, "", "Specify a file of hex encoded signed warp messages, one per line, to verify in verify-only mode instead of watching the source chain")
+	fs.Uint64(PChainHeightKey, 0, "Specify the P-Chain height to verify messages at in verify-only mode (0 uses the current height)")
+	fs.Uint64(MetricsPortKey, 8083, "Specify the port to use for the metrics server (0 disables the metrics server)")
+	fs.String(LogLevelKey, "info", "Specify the log level to use in the relayer")
+	return fs
+}
+
+func buildConfig(v *viper.Viper) (relayerConfig, error) {
+	c := relayerConfig{
+		SourceEndpoint:      v.GetString(SourceEndpointKey),
+		SourceURI:           v.GetString(SourceURIKey),
+		SourceBlockchainID:  v.GetString(SourceBlockchainIDKey),
+		DestinationEndpoint: v.GetString(DestinationEndpointKey),
+		QuorumNumerator:     v.GetUint64(QuorumNumeratorKey),
+		SigningSubnetID:     v.GetString(SigningSubnetIDKey),
+		GasLimit:            v.GetUint64(GasLimitKey),
+
+		SignedMessageBufferSize: v.GetInt(SignedMessageBufferKey),
+
+		VerifyOnly:   v.GetBool(VerifyOnlyKey),
+		VerifyFile:   v.GetString(VerifyFileKey),
+		PChainHeight: v.GetUint64(PChainHeightKey),
+	}
+	required := []string{SourceEndpointKey, SourceBlockchainIDKey, DestinationEndpointKey, KeyFileKey}
+	switch {
+	case c.VerifyOnly && c.VerifyFile != "":
+		required = []string{SourceURIKey}
+	case c.VerifyOnly:
+		required = []string{SourceEndpointKey, SourceBlockchainIDKey, SourceURIKey}
+	case c.VerifyFile != "":
+		return c, fmt.Errorf("%s requires %s", VerifyFileKey, VerifyOnlyKey)
+	}
+	for _, required := range required {
+		if v.GetString(required) == "" {
+			return c, fmt.Errorf("%w: %s", errMissingFlag, required)
+		}
+	}
+	if !common.IsHexAddress(v.GetString(WarpAddressKey)) {
+		return c, fmt.Errorf("invalid warp address %q", v.GetString(WarpAddressKey))
+	}
+	c.WarpAddress = common.HexToAddress(v.GetString(WarpAddressKey))
+	if c.QuorumNumerator < warp.WarpQuorumNumeratorMinimum || c.QuorumNumerator > warp.WarpQuorumDenominator {
+		return c, fmt.Errorf("invalid quorum numerator %d, must be in [%d, %d]", c.QuorumNumerator, warp.WarpQuorumNumeratorMinimum, warp.WarpQuorumDenominator)
+	}
+	if v.GetInt64(MaxFeeCapKey) < 0 || v.GetInt64(MaxTipCapKey) < 0 {
+		return c, fmt.Errorf("invalid fee cap %d or tip cap %d < 0", v.GetInt64(MaxFeeCapKey), v.GetInt64(MaxTipCapKey))
+	}
+	if c.SignedMessageBufferSize < 0 {
+		return c, fmt.Errorf("invalid signed message buffer size %d < 0", c.SignedMessageBufferSize)
+	}
+	bigGwei := big.NewInt(params.GWei)
+	c.GasFeeCap = new(big.Int).Mul(bigGwei, big.NewInt(v.GetInt64(MaxFeeCapKey)))
+	c.GasTipCap = new(big.Int).Mul(bigGwei, big.NewInt(v.GetInt64(MaxTipCapKey)))
+
+	if c.VerifyOnly {
+		// Verification does not issue any tx, so it does not need a key.
+		return c, nil
+	}
+	k, err := key.Load(v.GetString(KeyFileKey))
+	if err != nil {
+		return c, err
+	}
+	c.Key = k.PrivKey
+	return c, nil
+}
+
+func main() {
+	fs := buildFlagSet()
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		os.Exit(1)
+	}
+	v := viper.New()
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	v.SetEnvPrefix("evm_relayer")
+	if err := v.BindPFlags(fs); err != nil {
+		fmt.Printf("couldn't bind flags: %s\n", err)
+		os.Exit(1)
+	}
+
+	logLevel, err := log.LvlFromString(v.GetString(LogLevelKey))
+	if err != nil {
+		fmt.Printf("couldn't parse log level: %s\n", err)
+		os.Exit(1)
+	}
+	log.Root().SetHandler(log.LvlFilterHandler(logLevel, log.StreamHandler(os.Stderr, log.TerminalFormat(true))))
+
+	c, err := buildConfig(v)
+	if err != nil {
+		fmt.Printf("%s\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	reg := prometheus.NewRegistry()
+	metrics := newRelayerMetrics(reg)
+	if port := v.GetUint64(MetricsPortKey); port != 0 {
+		mux := http.NewServeMux()
+		mux.Handle(MetricsEndpoint, promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+		server := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux}
+		go func() {
+			log.Info(fmt.Sprintf("Metrics Server: localhost:%d%s", port, MetricsEndpoint))
+			if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				log.Error("Metrics server error", "err", err)
+			}
+		}()
+		defer server.Close()
+	}
+
+	if c.VerifyOnly && c.VerifyFile != "" {
+		verifier, err := newVerifier(ctx, c)
+		if err != nil {
+			fmt.Printf("failed to create verifier: %s\n", err)
+			os.Exit(1)
+		}
+		if err := verifier.verifyFile(ctx, c.VerifyFile); err != nil {
+			fmt.Printf("%s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	r, err := newRelayer(ctx, c, metrics)
+	if err != nil {
+		fmt.Printf("failed to create relayer: %s\n", err)
+		os.Exit(1)
+	}
+	if err := r.Run(ctx); err != nil {
+		fmt.Printf("relayer failed: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,349 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/load"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/predicate"
+	warpBackend "github.com/ava-labs/subnet-evm/warp"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// stallWarnPeriod is how long the signed message channel must stay full, or empty while
+	// messages are waiting to be signed, before a warning is logged.
+	stallWarnPeriod = 30 * time.Second
+	// stallCheckFrequency is how often the depth of the signed message channel is checked.
+	stallCheckFrequency = time.Second
+)
+
+type relayerMetrics struct {
+	// Number of warp messages delivered to the destination chain
+	MessagesRelayed prometheus.Counter
+	// Number of warp messages that failed to be delivered to the destination chain
+	RelayFailures prometheus.Counter
+	// Summary of the quantiles of the time from observing a warp message to its delivery being accepted
+	RelayTimes prometheus.Summary
+	// Number of signed warp messages waiting to be delivered
+	SignedMessagesPending prometheus.Gauge
+}
+
+func newRelayerMetrics(reg *prometheus.Registry) *relayerMetrics {
+	m := &relayerMetrics{
+		MessagesRelayed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "relayer_messages_relayed",
+			Help: "Number of Warp Messages Delivered to the Destination Chain",
+		}),
+		RelayFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "relayer_relay_failures",
+			Help: "Number of Warp Messages that Failed to be Delivered to the Destination Chain",
+		}),
+		RelayTimes: prometheus.NewSummary(prometheus.SummaryOpts{
+			Name:       "relayer_relay_time",
+			Help:       "Individual Times from Observing a Warp Message to its Delivery being Accepted",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}),
+		SignedMessagesPending: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "relayer_signed_messages_pending",
+			Help: "Number of Signed Warp Messages Waiting to be Delivered to the Destination Chain",
+		}),
+	}
+	reg.MustRegister(m.MessagesRelayed)
+	reg.MustRegister(m.RelayFailures)
+	reg.MustRegister(m.RelayTimes)
+	reg.MustRegister(m.SignedMessagesPending)
+	return m
+}
+
+// signedMessage is a warp message with an aggregate signature, waiting to be delivered.
+type signedMessage struct {
+	message  *avalancheWarp.Message
+	observed time.Time
+}
+
+// relayer delivers every warp message sent on a source chain to a destination chain.
+//
+// For each SendWarpMessage log emitted on the source chain, the relayer fetches an aggregate
+// signature of the message from the source node and issues a tx on the destination chain that
+// carries the signed message in its predicate and reads it from the warp precompile.
+// Signing and delivery run concurrently, connected by a channel of signed messages.
+type relayer struct {
+	sourceClient ethclient.Client
+	warpClient   warpBackend.Client
+	destWorker   txs.Worker[*types.Transaction]
+
+	warpAddress     common.Address
+	quorumNum       uint64
+	signingSubnetID string
+
+	key       *ecdsa.PrivateKey
+	signer    types.Signer
+	chainID   *big.Int
+	nonce     uint64
+	gasLimit  uint64
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+
+	signedMessageBufferSize int
+
+	// verifier, if non-nil, verifies each signed message instead of delivering it.
+	verifier *verifier
+
+	metrics *relayerMetrics
+}
+
+func newRelayer(ctx context.Context, c relayerConfig, metrics *relayerMetrics) (*relayer, error) {
+	sourceClient, err := ethclient.Dial(c.SourceEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial source endpoint %s: %w", c.SourceEndpoint, err)
+	}
+	warpClient, err := warpBackend.NewClient(c.SourceURI, c.SourceBlockchainID)
+	if err != nil {
+		return nil, err
+	}
+	if c.VerifyOnly {
+		verifier, err := newVerifier(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		return &relayer{
+			sourceClient:    sourceClient,
+			warpClient:      warpClient,
+			warpAddress:     c.WarpAddress,
+			quorumNum:       c.QuorumNumerator,
+			signingSubnetID: c.SigningSubnetID,
+
+			signedMessageBufferSize: c.SignedMessageBufferSize,
+
+			verifier: verifier,
+			metrics:  metrics,
+		}, nil
+	}
+	destClient, err := ethclient.Dial(c.DestinationEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial destination endpoint %s: %w", c.DestinationEndpoint, err)
+	}
+	chainID, err := destClient.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch destination chainID: %w", err)
+	}
+	address := ethcrypto.PubkeyToAddress(c.Key.PublicKey)
+	nonce, err := destClient.NonceAt(ctx, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nonce of %s: %w", address, err)
+	}
+	return &relayer{
+		sourceClient:    sourceClient,
+		warpClient:      warpClient,
+		destWorker:      load.NewTxReceiptWorker(ctx, destClient),
+		warpAddress:     c.WarpAddress,
+		quorumNum:       c.QuorumNumerator,
+		signingSubnetID: c.SigningSubnetID,
+		key:             c.Key,
+		signer:          types.LatestSignerForChainID(chainID),
+		chainID:         chainID,
+		nonce:           nonce,
+		gasLimit:        c.GasLimit,
+		gasFeeCap:       c.GasFeeCap,
+		gasTipCap:       c.GasTipCap,
+
+		signedMessageBufferSize: c.SignedMessageBufferSize,
+
+		metrics: metrics,
+	}, nil
+}
+
+// Run relays warp messages until [ctx] is cancelled or the log subscription fails.
+// A message that fails to be signed or delivered is logged and counted in the metrics, and is not retried.
+func (r *relayer) Run(ctx context.Context) error {
+	logs := make(chan types.Log, 100)
+	sub, err := r.sourceClient.SubscribeFilterLogs(ctx, interfaces.FilterQuery{
+		Addresses: []common.Address{r.warpAddress},
+		Topics:    [][]common.Hash{{warp.WarpABI.Events["SendWarpMessage"].ID}},
+	}, logs)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to warp messages: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	if r.verifier != nil {
+		log.Info("Verifying warp messages", "warpAddress", r.warpAddress, "signedMessageBufferSize", r.signedMessageBufferSize)
+	} else {
+		log.Info("Relaying warp messages", "warpAddress", r.warpAddress, "destinationChainID", r.chainID, "signedMessageBufferSize", r.signedMessageBufferSize)
+	}
+	signedMessages := make(chan signedMessage, r.signedMessageBufferSize)
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		defer close(signedMessages)
+		return r.sign(egCtx, sub, logs, signedMessages)
+	})
+	eg.Go(func() error {
+		r.deliver(egCtx, signedMessages)
+		return nil
+	})
+	eg.Go(func() error {
+		r.monitor(egCtx, logs, signedMessages)
+		return nil
+	})
+	return eg.Wait()
+}
+
+// sign fetches an aggregate signature of the warp message emitted in each log received on [logs]
+// and sends the signed message to [signedMessages].
+func (r *relayer) sign(ctx context.Context, sub interfaces.Subscription, logs <-chan types.Log, signedMessages chan<- signedMessage) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return fmt.Errorf("warp message subscription failed: %w", err)
+		case warpLog := <-logs:
+			observed := time.Now()
+			message, err := r.aggregateSignature(ctx, warpLog)
+			if err != nil {
+				log.Warn("Failed to sign warp message", "txHash", warpLog.TxHash, "logIndex", warpLog.Index, "err", err)
+				r.metrics.RelayFailures.Inc()
+				continue
+			}
+			select {
+			case signedMessages <- signedMessage{message: message, observed: observed}:
+				r.metrics.SignedMessagesPending.Set(float64(len(signedMessages)))
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// deliver delivers each message received on [signedMessages] to the destination chain, or only
+// verifies it in verify-only mode, until [signedMessages] is closed.
+func (r *relayer) deliver(ctx context.Context, signedMessages <-chan signedMessage) {
+	for signed := range signedMessages {
+		r.metrics.SignedMessagesPending.Set(float64(len(signedMessages)))
+		if ctx.Err() != nil {
+			return
+		}
+		if r.verifier != nil {
+			// Print the report rather than logging it, so that it is not filtered by the log level.
+			fmt.Println(r.verifier.verify(ctx, signed.message))
+			continue
+		}
+		if err := r.relay(ctx, signed.message); err != nil {
+			log.Warn("Failed to relay warp message", "messageID", signed.message.ID(), "err", err)
+			r.metrics.RelayFailures.Inc()
+			continue
+		}
+		r.metrics.MessagesRelayed.Inc()
+		r.metrics.RelayTimes.Observe(time.Since(signed.observed).Seconds())
+	}
+}
+
+// monitor logs a warning when [signedMessages] stays full for stallWarnPeriod, meaning that delivery
+// cannot keep up and backpressures signing, or stays empty for stallWarnPeriod while messages are
+// waiting to be signed, meaning that signing cannot keep up and starves delivery.
+func (r *relayer) monitor(ctx context.Context, logs <-chan types.Log, signedMessages <-chan signedMessage) {
+	ticker := time.NewTicker(stallCheckFrequency)
+	defer ticker.Stop()
+
+	var fullSince, starvedSince time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			depth := len(signedMessages)
+			full := cap(signedMessages) > 0 && depth == cap(signedMessages)
+			starved := depth == 0 && len(logs) > 0
+			fullSince = r.warnIfStalled(now, full, fullSince, "Signed message channel has been full, delivery is backpressuring signing", depth)
+			starvedSince = r.warnIfStalled(now, starved, starvedSince, "Signed message channel has been empty, signing is starving delivery", depth)
+		}
+	}
+}
+
+// warnIfStalled logs [msg] if [stalled] has held since [since] for stallWarnPeriod, and returns
+// the time since which [stalled] has held, restarting the period after each warning.
+func (r *relayer) warnIfStalled(now time.Time, stalled bool, since time.Time, msg string, depth int) time.Time {
+	switch {
+	case !stalled:
+		return time.Time{}
+	case since.IsZero():
+		return now
+	case now.Sub(since) >= stallWarnPeriod:
+		log.Warn(msg, "duration", now.Sub(since), "depth", depth, "bufferSize", r.signedMessageBufferSize)
+		return now
+	default:
+		return since
+	}
+}
+
+// aggregateSignature fetches an aggregate signature of the warp message emitted in [warpLog].
+func (r *relayer) aggregateSignature(ctx context.Context, warpLog types.Log) (*avalancheWarp.Message, error) {
+	unsignedMessage, err := warp.UnpackSendWarpEventDataToMessage(warpLog.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse warp message: %w", err)
+	}
+	messageID := unsignedMessage.ID()
+	log.Info("Signing warp message", "messageID", messageID, "txHash", warpLog.TxHash)
+
+	signedMessageBytes, err := r.warpClient.GetMessageAggregateSignature(ctx, messageID, r.quorumNum, r.signingSubnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate signatures of message %s: %w", messageID, err)
+	}
+	message, err := avalancheWarp.ParseMessage(signedMessageBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signed message %s: %w", messageID, err)
+	}
+	return message, nil
+}
+
+// relay delivers [message] to the destination chain and waits for the delivery tx to be accepted.
+func (r *relayer) relay(ctx context.Context, message *avalancheWarp.Message) error {
+	messageID := message.ID()
+	input, err := warp.PackGetVerifiedWarpMessage(0)
+	if err != nil {
+		return err
+	}
+	tx, err := types.SignTx(predicate.NewPredicateTx(
+		r.chainID,
+		r.nonce,
+		&r.warpAddress,
+		r.gasLimit,
+		r.gasFeeCap,
+		r.gasTipCap,
+		common.Big0,
+		input,
+		types.AccessList{},
+		r.warpAddress,
+		message.Bytes(),
+	), r.signer, r.key)
+	if err != nil {
+		return err
+	}
+	if err := r.destWorker.IssueTx(ctx, tx); err != nil {
+		return fmt.Errorf("failed to issue delivery of message %s: %w", messageID, err)
+	}
+	// The nonce is consumed once the tx is issued, even if it fails to confirm.
+	r.nonce++
+	if err := r.destWorker.ConfirmTx(ctx, tx); err != nil {
+		return fmt.Errorf("failed to confirm delivery of message %s: %w", messageID, err)
+	}
+	log.Info("Relayed warp message", "messageID", messageID, "deliveryTxHash", tx.Hash())
+	return nil
+}
@@ -0,0 +1,221 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+)
+
+var (
+	_ validators.State = (*pChainState)(nil)
+
+	errVerificationFailed = errors.New("warp message verification failed")
+)
+
+// pChainState is a validators.State that reads the P-Chain over the platform API of a node.
+//
+// Like the validator state of the warp precompile, it returns the validator set of the signing subnet,
+// if any, in place of the Primary Network validator set, since messages sent from the Primary Network
+// are signed by the validators of the receiving subnet.
+type pChainState struct {
+	client          platformvm.Client
+	signingSubnetID ids.ID
+}
+
+func (s *pChainState) GetMinimumHeight(ctx context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (s *pChainState) GetCurrentHeight(ctx context.Context) (uint64, error) {
+	return s.client.GetHeight(ctx)
+}
+
+func (s *pChainState) GetSubnetID(ctx context.Context, chainID ids.ID) (ids.ID, error) {
+	return s.client.ValidatedBy(ctx, chainID)
+}
+
+func (s *pChainState) GetValidatorSet(ctx context.Context, height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	if subnetID == constants.PrimaryNetworkID && s.signingSubnetID != ids.Empty {
+		subnetID = s.signingSubnetID
+	}
+	return s.client.GetValidatorsAt(ctx, subnetID, height)
+}
+
+// verifier runs the off-chain verification of signed warp messages performed by the warp precompile,
+// without delivering them.
+type verifier struct {
+	state        *pChainState
+	networkID    uint32
+	quorumNum    uint64
+	pChainHeight uint64
+}
+
+func newVerifier(ctx context.Context, c relayerConfig) (*verifier, error) {
+	networkID, err := info.NewClient(c.SourceURI).GetNetworkID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch network ID from %s: %w", c.SourceURI, err)
+	}
+	var signingSubnetID ids.ID
+	if c.SigningSubnetID != "" {
+		signingSubnetID, err = ids.FromString(c.SigningSubnetID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing subnet ID %q: %w", c.SigningSubnetID, err)
+		}
+	}
+	return &verifier{
+		state: &pChainState{
+			client:          platformvm.NewClient(c.SourceURI),
+			signingSubnetID: signingSubnetID,
+		},
+		networkID:    networkID,
+		quorumNum:    c.QuorumNumerator,
+		pChainHeight: c.PChainHeight,
+	}, nil
+}
+
+// verificationReport is the outcome of verifying a single signed warp message.
+type verificationReport struct {
+	messageID     ids.ID
+	sourceChainID ids.ID
+	pChainHeight  uint64
+	signers       []ids.NodeID
+	signedWeight  uint64
+	totalWeight   uint64
+	err           error
+}
+
+func (r *verificationReport) String() string {
+	result := "PASS"
+	if r.err != nil {
+		result = "FAIL"
+	}
+	s := fmt.Sprintf("%s messageID=%s sourceChainID=%s pChainHeight=%d signedWeight=%d totalWeight=%d signers=%v",
+		result, r.messageID, r.sourceChainID, r.pChainHeight, r.signedWeight, r.totalWeight, r.signers)
+	if r.err != nil {
+		s += fmt.Sprintf(" err=%q", r.err)
+	}
+	return s
+}
+
+// verify verifies [message] against the validator set at the configured P-Chain height, or the current
+// height if none is configured, and reports the signers of [message].
+func (v *verifier) verify(ctx context.Context, message *avalancheWarp.Message) *verificationReport {
+	report := &verificationReport{
+		messageID:     message.ID(),
+		sourceChainID: message.SourceChainID,
+		pChainHeight:  v.pChainHeight,
+	}
+	if report.pChainHeight == 0 {
+		height, err := v.state.GetCurrentHeight(ctx)
+		if err != nil {
+			report.err = fmt.Errorf("failed to fetch P-Chain height: %w", err)
+			return report
+		}
+		report.pChainHeight = height
+	}
+	// Report the signers even if verification fails, since they are most useful to debug a failure.
+	if err := v.reportSigners(ctx, message, report); err != nil {
+		report.err = err
+		return report
+	}
+	report.err = warp.VerifyWarpMessage(ctx, message, v.networkID, v.state, report.pChainHeight, v.quorumNum)
+	return report
+}
+
+// reportSigners records the validators that signed [message] and their weight in [report].
+func (v *verifier) reportSigners(ctx context.Context, message *avalancheWarp.Message, report *verificationReport) error {
+	subnetID, err := v.state.GetSubnetID(ctx, message.SourceChainID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch subnet of source chain %s: %w", message.SourceChainID, err)
+	}
+	vdrs, totalWeight, err := avalancheWarp.GetCanonicalValidatorSet(ctx, v.state, report.pChainHeight, subnetID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch validator set of subnet %s: %w", subnetID, err)
+	}
+	report.totalWeight = totalWeight
+	signature, ok := message.Signature.(*avalancheWarp.BitSetSignature)
+	if !ok {
+		return fmt.Errorf("unexpected signature type %T", message.Signature)
+	}
+	signers, err := avalancheWarp.FilterValidators(set.BitsFromBytes(signature.Signers), vdrs)
+	if err != nil {
+		return err
+	}
+	// Since [signers] is a subset of [vdrs], this cannot overflow.
+	report.signedWeight, _ = avalancheWarp.SumWeight(signers)
+	for _, signer := range signers {
+		report.signers = append(report.signers, signer.NodeIDs...)
+	}
+	return nil
+}
+
+// verifyFile verifies each signed warp message in the file at [path], which holds one hex encoded
+// message per line, and prints a report of each message. Returns an error if any message fails
+// verification.
+func (v *verifier) verifyFile(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var numMessages, numFailures int
+	scanner := bufio.NewScanner(f)
+	// Signed messages may exceed the default maximum line length.
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		encoded := strings.TrimSpace(scanner.Text())
+		if encoded == "" {
+			continue
+		}
+		numMessages++
+		message, err := parseSignedMessage(encoded)
+		if err != nil {
+			fmt.Printf("FAIL line=%d err=%q\n", line, err)
+			numFailures++
+			continue
+		}
+		report := v.verify(ctx, message)
+		fmt.Println(report)
+		if report.err != nil {
+			numFailures++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	fmt.Printf("Verified %d messages: %d passed, %d failed\n", numMessages, numMessages-numFailures, numFailures)
+	if numFailures > 0 {
+		return fmt.Errorf("%w: %d of %d messages", errVerificationFailed, numFailures, numMessages)
+	}
+	return nil
+}
+
+// parseSignedMessage parses a hex encoded signed warp message.
+func parseSignedMessage(encoded string) (*avalancheWarp.Message, error) {
+	messageBytes, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signed message: %w", err)
+	}
+	message, err := avalancheWarp.ParseMessage(messageBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signed message: %w", err)
+	}
+	return message, nil
+}
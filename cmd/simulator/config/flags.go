@@ -6,9 +6,12 @@ package config
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -16,52 +19,309 @@ import (
 const Version = "v0.1.1"
 
 const (
-	ConfigFilePathKey = "config-file"
-	LogLevelKey       = "log-level"
-	EndpointsKey      = "endpoints"
-	MaxFeeCapKey      = "max-fee-cap"
-	MaxTipCapKey      = "max-tip-cap"
-	WorkersKey        = "workers"
-	TxsPerWorkerKey   = "txs-per-worker"
-	KeyDirKey         = "key-dir"
-	VersionKey        = "version"
-	TimeoutKey        = "timeout"
-	BatchSizeKey      = "batch-size"
-	MetricsPortKey    = "metrics-port"
-	MetricsOutputKey  = "metrics-output"
+	ConfigFilePathKey     = "config-file"
+	LogLevelKey           = "log-level"
+	LogFormatKey          = "log-format"
+	EndpointsKey          = "endpoints"
+	ConfirmEndpointsKey   = "confirm-endpoints"
+	MaxFeeCapKey          = "max-fee-cap"
+	MaxTipCapKey          = "max-tip-cap"
+	MinFeeCapKey          = "min-fee-cap"
+	MinTipCapKey          = "min-tip-cap"
+	FeeTiersKey           = "fee-tiers"
+	OnErrorKey            = "on-error"
+	ConfirmByReceiptKey   = "confirm-by-receipt"
+	ConfirmByLogKey       = "confirm-by-log"
+	ConfirmationsKey      = "confirmations"
+	FeeBumpRetriesKey     = "fee-bump-retries"
+	FeeBumpPercentKey     = "fee-bump-percent"
+	TxCostMetricsKey      = "tx-cost-metrics"
+	BlockStatsKey         = "block-stats"
+	WorkersKey            = "workers"
+	KeysPerWorkerKey      = "keys-per-worker"
+	TxsPerWorkerKey       = "txs-per-worker"
+	TotalTxsKey           = "total-txs"
+	DurationKey           = "duration"
+	WarmupTxsKey          = "warmup-txs"
+	PresignKey            = "presign"
+	PresignBufferKey      = "presign-buffer"
+	KeyDirKey             = "key-dir"
+	KeyPassphraseKey      = "key-passphrase"
+	VersionKey            = "version"
+	TimeoutKey            = "timeout"
+	BatchSizeKey          = "batch-size"
+	MetricsPortKey        = "metrics-port"
+	MetricsOutputKey      = "metrics-output"
+	PushgatewayURLKey     = "pushgateway-url"
+	PushIntervalKey       = "push-interval"
+	ReportIntervalKey     = "report-interval"
+	WorkerPoolKey         = "worker-pool"
+	ConcurrencyKey        = "concurrency"
+	SynchronizedStartKey  = "synchronized-start"
+	ConfirmConcurrencyKey = "confirm-concurrency"
+	EndpointLimitKey      = "endpoint-limit"
+	ConfirmSampleRateKey  = "confirm-sample-rate"
+	MaxInflightKey        = "max-inflight"
+	MaxConfirmWaitKey     = "max-confirm-wait"
+	BatchLatencyTargetKey = "batch-latency-target"
+	MinBatchSizeKey       = "min-batch-size"
+	MaxBatchSizeKey       = "max-batch-size"
+	VerboseWorkersKey     = "verbose-workers"
+	TxRecordFileKey       = "tx-record-file"
+	ReplayFileKey         = "replay-file"
+	SignerKey             = "signer"
+	RemoteSignerKey       = "remote-signer-endpoint"
+	NumKeysKey            = "num-keys"
+	FundingAmountKey      = "funding-amount"
+	SkipFundingKey        = "skip-funding"
+	FundingRetriesKey     = "funding-retries"
+	FundingBackoffKey     = "funding-backoff"
+	FundingFanoutKey      = "funding-fanout"
+	ReclaimFundsKey       = "reclaim-funds"
+	MinPayloadSizeKey     = "min-payload-size"
+	MaxPayloadSizeKey     = "max-payload-size"
+	GasLimitKey           = "gas-limit"
+	MetricsEnabledKey     = "metrics-enabled"
+	ReadinessTimeoutKey   = "readiness-timeout"
+	HealthEndpointsKey    = "health-endpoints"
+	TxTypeKey             = "tx-type"
+	TxMixKey              = "tx-mix"
+	SeedKey               = "seed"
+	BlobsPerTxKey         = "blobs-per-tx"
+	MaxBlobFeeCapKey      = "max-blob-fee-cap"
+	EntryPointKey         = "entry-point"
+	AccountFactoryKey     = "account-factory"
+	BundlerEndpointKey    = "bundler-endpoint"
+	TopologyFileKey       = "topology-file"
+	InjectLatencyKey      = "inject-latency"
+	CheckpointFileKey     = "checkpoint-file"
+	CheckpointIntervalKey = "checkpoint-interval"
+	ResumeKey             = "resume"
+	WorkerWeightsKey      = "worker-weights"
 )
 
+// FundKeysCommand is the subcommand that generates and funds keys in [KeyDir] without running a load test.
+const FundKeysCommand = "fund-keys"
+
+const (
+	LocalSigner  = "local"
+	RemoteSigner = "remote"
+)
+
+const (
+	AbortOnError    = "abort"
+	ContinueOnError = "continue"
+)
+
+const (
+	AllVerboseWorkers  = "all"
+	NoVerboseWorkers   = "none"
+	FirstVerboseWorker = "first"
+)
+
+const (
+	TextLogFormat = "text"
+	JSONLogFormat = "json"
+)
+
+const (
+	TransferTxType = "transfer"
+	BlobTxType     = "blob"
+	UserOpTxType   = "user-op"
+)
+
+// MaxBlobsPerTx is the maximum number of blobs a single tx can carry, since a tx may not
+// use more blob gas than a block.
+const MaxBlobsPerTx = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+
 var (
 	ErrNoEndpoints = errors.New("must specify at least one endpoint")
 	ErrNoWorkers   = errors.New("must specify non-zero number of workers")
 	ErrNoTxs       = errors.New("must specify non-zero number of txs-per-worker")
+
+	ErrTotalTxsAndTxsPerWorker = errors.New("cannot specify both total-txs and txs-per-worker")
+	ErrDurationAndTotalTxs     = errors.New("cannot specify both duration and total-txs")
+	ErrDurationFundingAmount   = errors.New("must specify non-zero funding-amount to fund keys for a duration")
+	ErrDurationReplay          = errors.New("cannot specify both duration and replay-file")
+	ErrDurationUserOps         = errors.New("cannot specify duration when submitting user operations")
+
+	ErrNoRemoteSignerEndpoint  = errors.New("must specify remote-signer-endpoint when using the remote signer")
+	ErrNoKeys                  = errors.New("must specify non-zero number of num-keys")
+	ErrNoFundingAmount         = errors.New("must specify non-zero funding-amount")
+	ErrReclaimWithoutFunding   = errors.New("cannot specify both reclaim-funds and skip-funding")
+	ErrNoBundlerEndpoint       = errors.New("must specify bundler-endpoint when submitting user operations")
+	ErrUserOpsRemoteSigner     = errors.New("cannot sign user operations with the remote signer")
+	ErrUserOpsGasLimit         = errors.New("cannot specify gas-limit when submitting user operations")
+	ErrUserOpsConfirmEndpoint  = errors.New("cannot specify confirm-endpoints when submitting user operations, which are confirmed by the bundler")
+	ErrTxMixAndTxType          = errors.New("cannot specify both tx-mix and tx-type")
+	ErrTxMixPercentages        = errors.New("tx-mix percentages must sum to 100")
+	ErrTopologyOptions         = errors.New("cannot specify duration, warmup-txs, replay-file, tx-type, tx-mix, confirm-endpoints, or block-stats with topology-file")
+	ErrTopologyOnError         = errors.New("cannot continue on error with topology-file, since every sent warp message must be delivered")
+	ErrResumeWithoutCheckpoint = errors.New("must specify checkpoint-file to resume from")
+	ErrWorkerWeightsOptions    = errors.New("cannot specify duration, replay-file, or topology-file with worker-weights")
+	ErrCheckpointOptions       = errors.New("cannot specify duration, replay-file, topology-file, or user-op txs with checkpoint-file")
+	ErrPresignOptions          = errors.New("cannot specify replay-file, topology-file, or user-op txs with presign")
+	ErrConfirmByLogWorkload    = errors.New("cannot specify confirm-by-log without topology-file, since only warp messages emit an expected log")
+	ErrEndpointLimitOptions    = errors.New("cannot specify replay-file, topology-file, or user-op txs with endpoint-limit")
+	ErrConfirmSampleOptions    = errors.New("cannot specify replay-file, topology-file, user-op txs, fee-bump-retries, or checkpoint-file with a confirm-sample-rate below 1")
+	ErrKeysPerWorkerOptions    = errors.New("cannot specify replay-file, topology-file, user-op txs, fee-bump-retries, or checkpoint-file with more than one key per worker")
 )
 
 type Config struct {
-	Endpoints     []string      `json:"endpoints"`
-	MaxFeeCap     int64         `json:"max-fee-cap"`
-	MaxTipCap     int64         `json:"max-tip-cap"`
-	Workers       int           `json:"workers"`
-	TxsPerWorker  uint64        `json:"txs-per-worker"`
-	KeyDir        string        `json:"key-dir"`
-	Timeout       time.Duration `json:"timeout"`
-	BatchSize     uint64        `json:"batch-size"`
-	MetricsPort   uint64        `json:"metrics-port"`
-	MetricsOutput string        `json:"metrics-output"`
+	Endpoints          []string      `json:"endpoints"`
+	ConfirmEndpoints   []string      `json:"confirm-endpoints"`
+	MaxFeeCap          int64         `json:"max-fee-cap"`
+	MaxTipCap          int64         `json:"max-tip-cap"`
+	MinFeeCap          int64         `json:"min-fee-cap"`
+	MinTipCap          int64         `json:"min-tip-cap"`
+	FeeTiers           int           `json:"fee-tiers"`
+	OnError            string        `json:"on-error"`
+	ConfirmByReceipt   bool          `json:"confirm-by-receipt"`
+	ConfirmByLog       bool          `json:"confirm-by-log"`
+	Confirmations      uint64        `json:"confirmations"`
+	FeeBumpRetries     int           `json:"fee-bump-retries"`
+	FeeBumpPercent     uint64        `json:"fee-bump-percent"`
+	TxCostMetrics      bool          `json:"tx-cost-metrics"`
+	BlockStats         bool          `json:"block-stats"`
+	Workers            int           `json:"workers"`
+	KeysPerWorker      int           `json:"keys-per-worker"`
+	TxsPerWorker       uint64        `json:"txs-per-worker"`
+	TotalTxs           uint64        `json:"total-txs"`
+	Duration           time.Duration `json:"duration"`
+	WarmupTxs          uint64        `json:"warmup-txs"`
+	Presign            bool          `json:"presign"`
+	PresignBuffer      uint64        `json:"presign-buffer"`
+	KeyDir             string        `json:"key-dir"`
+	KeyPassphrase      string        `json:"-"`
+	Timeout            time.Duration `json:"timeout"`
+	BatchSize          uint64        `json:"batch-size"`
+	MetricsPort        uint64        `json:"metrics-port"`
+	MetricsOutput      string        `json:"metrics-output"`
+	PushgatewayURL     string        `json:"pushgateway-url"`
+	PushInterval       time.Duration `json:"push-interval"`
+	ReportInterval     time.Duration `json:"report-interval"`
+	WorkerPool         bool          `json:"worker-pool"`
+	Concurrency        int           `json:"concurrency"`
+	SynchronizedStart  bool          `json:"synchronized-start"`
+	ConfirmConcurrency int           `json:"confirm-concurrency"`
+	EndpointLimit      int           `json:"endpoint-limit"`
+	ConfirmSampleRate  float64       `json:"confirm-sample-rate"`
+	MaxInflight        int           `json:"max-inflight"`
+	MaxConfirmWait     time.Duration `json:"max-confirm-wait"`
+	BatchLatencyTarget time.Duration `json:"batch-latency-target"`
+	MinBatchSize       uint64        `json:"min-batch-size"`
+	MaxBatchSize       uint64        `json:"max-batch-size"`
+	VerboseWorkers     string        `json:"verbose-workers"`
+	TxRecordFile       string        `json:"tx-record-file"`
+	ReplayFile         string        `json:"replay-file"`
+	Signer             string        `json:"signer"`
+	RemoteSigner       string        `json:"remote-signer-endpoint"`
+	NumKeys            int           `json:"num-keys"`
+	FundingAmount      uint64        `json:"funding-amount"`
+	SkipFunding        bool          `json:"skip-funding"`
+	FundingRetries     int           `json:"funding-retries"`
+	FundingBackoff     time.Duration `json:"funding-backoff"`
+	FundingFanout      int           `json:"funding-fanout"`
+	ReclaimFunds       bool          `json:"reclaim-funds"`
+	MinPayloadSize     uint64        `json:"min-payload-size"`
+	MaxPayloadSize     uint64        `json:"max-payload-size"`
+	GasLimit           uint64        `json:"gas-limit"`
+	MetricsEnabled     bool          `json:"metrics-enabled"`
+	ReadinessTimeout   time.Duration `json:"readiness-timeout"`
+	HealthEndpoints    []string      `json:"health-endpoints"`
+	TxType             string        `json:"tx-type"`
+	TxMix              string        `json:"tx-mix"`
+	Seed               int64         `json:"seed"`
+	BlobsPerTx         int           `json:"blobs-per-tx"`
+	MaxBlobFeeCap      int64         `json:"max-blob-fee-cap"`
+	EntryPoint         string        `json:"entry-point"`
+	AccountFactory     string        `json:"account-factory"`
+	BundlerEndpoint    string        `json:"bundler-endpoint"`
+	TopologyFile       string        `json:"topology-file"`
+	InjectLatency      string        `json:"inject-latency"`
+	CheckpointFile     string        `json:"checkpoint-file"`
+	CheckpointInterval time.Duration `json:"checkpoint-interval"`
+	Resume             bool          `json:"resume"`
+	WorkerWeights      string        `json:"worker-weights"`
 }
 
 func BuildConfig(v *viper.Viper) (Config, error) {
 	c := Config{
-		Endpoints:     v.GetStringSlice(EndpointsKey),
-		MaxFeeCap:     v.GetInt64(MaxFeeCapKey),
-		MaxTipCap:     v.GetInt64(MaxTipCapKey),
-		Workers:       v.GetInt(WorkersKey),
-		TxsPerWorker:  v.GetUint64(TxsPerWorkerKey),
-		KeyDir:        v.GetString(KeyDirKey),
-		Timeout:       v.GetDuration(TimeoutKey),
-		BatchSize:     v.GetUint64(BatchSizeKey),
-		MetricsPort:   v.GetUint64(MetricsPortKey),
-		MetricsOutput: v.GetString(MetricsOutputKey),
+		Endpoints:          v.GetStringSlice(EndpointsKey),
+		ConfirmEndpoints:   v.GetStringSlice(ConfirmEndpointsKey),
+		MaxFeeCap:          v.GetInt64(MaxFeeCapKey),
+		MaxTipCap:          v.GetInt64(MaxTipCapKey),
+		MinFeeCap:          v.GetInt64(MinFeeCapKey),
+		MinTipCap:          v.GetInt64(MinTipCapKey),
+		FeeTiers:           v.GetInt(FeeTiersKey),
+		OnError:            v.GetString(OnErrorKey),
+		ConfirmByReceipt:   v.GetBool(ConfirmByReceiptKey),
+		ConfirmByLog:       v.GetBool(ConfirmByLogKey),
+		Confirmations:      v.GetUint64(ConfirmationsKey),
+		FeeBumpRetries:     v.GetInt(FeeBumpRetriesKey),
+		FeeBumpPercent:     v.GetUint64(FeeBumpPercentKey),
+		TxCostMetrics:      v.GetBool(TxCostMetricsKey),
+		BlockStats:         v.GetBool(BlockStatsKey),
+		Workers:            v.GetInt(WorkersKey),
+		KeysPerWorker:      v.GetInt(KeysPerWorkerKey),
+		TxsPerWorker:       v.GetUint64(TxsPerWorkerKey),
+		TotalTxs:           v.GetUint64(TotalTxsKey),
+		Duration:           v.GetDuration(DurationKey),
+		WarmupTxs:          v.GetUint64(WarmupTxsKey),
+		Presign:            v.GetBool(PresignKey),
+		PresignBuffer:      v.GetUint64(PresignBufferKey),
+		KeyDir:             v.GetString(KeyDirKey),
+		KeyPassphrase:      v.GetString(KeyPassphraseKey),
+		Timeout:            v.GetDuration(TimeoutKey),
+		BatchSize:          v.GetUint64(BatchSizeKey),
+		MetricsPort:        v.GetUint64(MetricsPortKey),
+		MetricsOutput:      v.GetString(MetricsOutputKey),
+		PushgatewayURL:     v.GetString(PushgatewayURLKey),
+		PushInterval:       v.GetDuration(PushIntervalKey),
+		ReportInterval:     v.GetDuration(ReportIntervalKey),
+		WorkerPool:         v.GetBool(WorkerPoolKey),
+		Concurrency:        v.GetInt(ConcurrencyKey),
+		SynchronizedStart:  v.GetBool(SynchronizedStartKey),
+		ConfirmConcurrency: v.GetInt(ConfirmConcurrencyKey),
+		EndpointLimit:      v.GetInt(EndpointLimitKey),
+		ConfirmSampleRate:  v.GetFloat64(ConfirmSampleRateKey),
+		MaxInflight:        v.GetInt(MaxInflightKey),
+		MaxConfirmWait:     v.GetDuration(MaxConfirmWaitKey),
+		BatchLatencyTarget: v.GetDuration(BatchLatencyTargetKey),
+		MinBatchSize:       v.GetUint64(MinBatchSizeKey),
+		MaxBatchSize:       v.GetUint64(MaxBatchSizeKey),
+		VerboseWorkers:     v.GetString(VerboseWorkersKey),
+		TxRecordFile:       v.GetString(TxRecordFileKey),
+		ReplayFile:         v.GetString(ReplayFileKey),
+		Signer:             v.GetString(SignerKey),
+		RemoteSigner:       v.GetString(RemoteSignerKey),
+		NumKeys:            v.GetInt(NumKeysKey),
+		FundingAmount:      v.GetUint64(FundingAmountKey),
+		SkipFunding:        v.GetBool(SkipFundingKey),
+		FundingRetries:     v.GetInt(FundingRetriesKey),
+		FundingBackoff:     v.GetDuration(FundingBackoffKey),
+		FundingFanout:      v.GetInt(FundingFanoutKey),
+		ReclaimFunds:       v.GetBool(ReclaimFundsKey),
+		MinPayloadSize:     v.GetUint64(MinPayloadSizeKey),
+		MaxPayloadSize:     v.GetUint64(MaxPayloadSizeKey),
+		GasLimit:           v.GetUint64(GasLimitKey),
+		MetricsEnabled:     v.GetBool(MetricsEnabledKey),
+		ReadinessTimeout:   v.GetDuration(ReadinessTimeoutKey),
+		HealthEndpoints:    v.GetStringSlice(HealthEndpointsKey),
+		TxType:             v.GetString(TxTypeKey),
+		TxMix:              v.GetString(TxMixKey),
+		Seed:               v.GetInt64(SeedKey),
+		BlobsPerTx:         v.GetInt(BlobsPerTxKey),
+		MaxBlobFeeCap:      v.GetInt64(MaxBlobFeeCapKey),
+		EntryPoint:         v.GetString(EntryPointKey),
+		AccountFactory:     v.GetString(AccountFactoryKey),
+		BundlerEndpoint:    v.GetString(BundlerEndpointKey),
+		TopologyFile:       v.GetString(TopologyFileKey),
+		InjectLatency:      v.GetString(InjectLatencyKey),
+		CheckpointFile:     v.GetString(CheckpointFileKey),
+		CheckpointInterval: v.GetDuration(CheckpointIntervalKey),
+		Resume:             v.GetBool(ResumeKey),
+		WorkerWeights:      v.GetString(WorkerWeightsKey),
 	}
 	if len(c.Endpoints) == 0 {
 		return c, ErrNoEndpoints
@@ -69,9 +329,45 @@ func BuildConfig(v *viper.Viper) (Config, error) {
 	if c.Workers == 0 {
 		return c, ErrNoWorkers
 	}
+	if c.KeysPerWorker < 1 {
+		return c, fmt.Errorf("invalid keys per worker %d < 1", c.KeysPerWorker)
+	}
+	if c.KeysPerWorker > 1 && (c.ReplayFile != "" || c.TopologyFile != "" || c.IssuesTxType(UserOpTxType) || c.FeeBumpRetries > 0 || c.CheckpointFile != "") {
+		return c, ErrKeysPerWorkerOptions
+	}
 	if c.TxsPerWorker == 0 {
 		return c, ErrNoTxs
 	}
+	if c.TotalTxs != 0 {
+		if v.IsSet(TxsPerWorkerKey) {
+			return c, ErrTotalTxsAndTxsPerWorker
+		}
+		if c.TotalTxs < uint64(c.Workers) {
+			return c, fmt.Errorf("invalid total txs %d < workers %d", c.TotalTxs, c.Workers)
+		}
+	}
+	if c.Duration < 0 {
+		return c, fmt.Errorf("invalid duration %s < 0", c.Duration)
+	}
+	if c.Duration > 0 {
+		if c.TotalTxs != 0 {
+			return c, ErrDurationAndTotalTxs
+		}
+		// The timeout must leave time to drain the txs issued before the duration elapses.
+		if c.Timeout > 0 && c.Timeout <= c.Duration {
+			return c, fmt.Errorf("invalid timeout %s <= duration %s", c.Timeout, c.Duration)
+		}
+		// The number of txs is unknown ahead of time, so keys are funded with the funding amount.
+		if !c.SkipFunding && c.FundingAmount == 0 {
+			return c, ErrDurationFundingAmount
+		}
+		if c.ReplayFile != "" {
+			return c, ErrDurationReplay
+		}
+		if c.TxType == UserOpTxType {
+			return c, ErrDurationUserOps
+		}
+	}
 	// Note: it's technically valid for the fee/tip cap to be 0, but cannot
 	// be less than 0.
 	if c.MaxFeeCap < 0 {
@@ -80,9 +376,385 @@ func BuildConfig(v *viper.Viper) (Config, error) {
 	if c.MaxTipCap < 0 {
 		return c, fmt.Errorf("invalid max tip cap %d <= 0", c.MaxTipCap)
 	}
+	if c.FeeTiers <= 0 {
+		return c, fmt.Errorf("invalid fee tiers %d <= 0", c.FeeTiers)
+	}
+	if c.FeeTiers > 1 {
+		if c.MinFeeCap < 0 || c.MinFeeCap > c.MaxFeeCap {
+			return c, fmt.Errorf("invalid min fee cap %d, must be in [0, %d]", c.MinFeeCap, c.MaxFeeCap)
+		}
+		if c.MinTipCap < 0 || c.MinTipCap > c.MaxTipCap {
+			return c, fmt.Errorf("invalid min tip cap %d, must be in [0, %d]", c.MinTipCap, c.MaxTipCap)
+		}
+	}
+	if c.FeeBumpRetries < 0 {
+		return c, fmt.Errorf("invalid fee bump retries %d < 0", c.FeeBumpRetries)
+	}
+	if c.FeeBumpRetries > 0 && c.FeeBumpPercent == 0 {
+		return c, fmt.Errorf("invalid fee bump percent %d <= 0", c.FeeBumpPercent)
+	}
+	if c.Concurrency < 0 {
+		return c, fmt.Errorf("invalid concurrency %d < 0", c.Concurrency)
+	}
+	if c.ConfirmConcurrency < 1 {
+		return c, fmt.Errorf("invalid confirm concurrency %d < 1", c.ConfirmConcurrency)
+	}
+	if c.ReportInterval < 0 {
+		return c, fmt.Errorf("invalid report interval %s < 0", c.ReportInterval)
+	}
+	if c.PushgatewayURL != "" && c.PushInterval <= 0 {
+		return c, fmt.Errorf("invalid push interval %s <= 0", c.PushInterval)
+	}
+	if c.MaxInflight < 0 {
+		return c, fmt.Errorf("invalid max inflight %d < 0", c.MaxInflight)
+	}
+	if c.ConfirmByLog && c.TopologyFile == "" {
+		return c, ErrConfirmByLogWorkload
+	}
+	if c.EndpointLimit < 0 {
+		return c, fmt.Errorf("invalid endpoint limit %d < 0", c.EndpointLimit)
+	}
+	if c.EndpointLimit > 0 && (c.ReplayFile != "" || c.TopologyFile != "" || c.IssuesTxType(UserOpTxType)) {
+		return c, ErrEndpointLimitOptions
+	}
+	if c.ConfirmSampleRate <= 0 || c.ConfirmSampleRate > 1 {
+		return c, fmt.Errorf("invalid confirm sample rate %v not in (0, 1]", c.ConfirmSampleRate)
+	}
+	if c.ConfirmSampleRate < 1 && (c.ReplayFile != "" || c.TopologyFile != "" || c.IssuesTxType(UserOpTxType) || c.FeeBumpRetries > 0 || c.CheckpointFile != "") {
+		return c, ErrConfirmSampleOptions
+	}
+	if c.MaxConfirmWait < 0 {
+		return c, fmt.Errorf("invalid max confirm wait %s < 0", c.MaxConfirmWait)
+	}
+	if c.BatchLatencyTarget < 0 {
+		return c, fmt.Errorf("invalid batch latency target %s < 0", c.BatchLatencyTarget)
+	}
+	if c.BatchLatencyTarget > 0 {
+		if c.MaxInflight > 0 {
+			return c, fmt.Errorf("cannot specify both %s and %s", BatchLatencyTargetKey, MaxInflightKey)
+		}
+		if c.MinBatchSize == 0 {
+			return c, fmt.Errorf("invalid min batch size %d <= 0", c.MinBatchSize)
+		}
+		if c.BatchSize < c.MinBatchSize || c.BatchSize > c.MaxBatchSize {
+			return c, fmt.Errorf("invalid batch size %d, must be in [%d, %d]", c.BatchSize, c.MinBatchSize, c.MaxBatchSize)
+		}
+	}
+	if c.MaxPayloadSize != 0 && c.MaxPayloadSize < c.MinPayloadSize {
+		return c, fmt.Errorf("invalid max payload size %d < min payload size %d", c.MaxPayloadSize, c.MinPayloadSize)
+	}
+	if c.FundingRetries < 0 {
+		return c, fmt.Errorf("invalid funding retries %d < 0", c.FundingRetries)
+	}
+	if c.FundingBackoff < 0 {
+		return c, fmt.Errorf("invalid funding backoff %s < 0", c.FundingBackoff)
+	}
+	if c.FundingFanout < 0 {
+		return c, fmt.Errorf("invalid funding fanout %d < 0", c.FundingFanout)
+	}
+	if c.ReclaimFunds && c.SkipFunding {
+		return c, ErrReclaimWithoutFunding
+	}
+	if _, err := parseVerboseWorkers(c.VerboseWorkers, c.Workers); err != nil {
+		return c, err
+	}
+	if c.OnError != AbortOnError && c.OnError != ContinueOnError {
+		return c, fmt.Errorf("invalid on-error policy %q, must be %q or %q", c.OnError, AbortOnError, ContinueOnError)
+	}
+	switch c.TxType {
+	case TransferTxType, BlobTxType:
+	case UserOpTxType:
+		if !common.IsHexAddress(c.EntryPoint) {
+			return c, fmt.Errorf("invalid entry point address %q", c.EntryPoint)
+		}
+		if !common.IsHexAddress(c.AccountFactory) {
+			return c, fmt.Errorf("invalid account factory address %q", c.AccountFactory)
+		}
+		if c.BundlerEndpoint == "" {
+			return c, ErrNoBundlerEndpoint
+		}
+		if c.Signer == RemoteSigner {
+			return c, ErrUserOpsRemoteSigner
+		}
+		if c.GasLimit != 0 {
+			return c, ErrUserOpsGasLimit
+		}
+		if len(c.ConfirmEndpoints) != 0 {
+			return c, ErrUserOpsConfirmEndpoint
+		}
+	default:
+		return c, fmt.Errorf("invalid tx type %q, must be %q, %q, or %q", c.TxType, TransferTxType, BlobTxType, UserOpTxType)
+	}
+	if c.TxMix != "" {
+		if v.IsSet(TxTypeKey) {
+			return c, ErrTxMixAndTxType
+		}
+		if _, err := parseTxMix(c.TxMix); err != nil {
+			return c, err
+		}
+	}
+	if _, _, err := parseInjectLatency(c.InjectLatency); err != nil {
+		return c, err
+	}
+	if c.WorkerWeights != "" {
+		if c.Duration > 0 || c.ReplayFile != "" || c.TopologyFile != "" {
+			return c, ErrWorkerWeightsOptions
+		}
+		if _, err := parseWorkerWeights(c.WorkerWeights, c.Workers); err != nil {
+			return c, err
+		}
+	}
+	if c.Resume && c.CheckpointFile == "" {
+		return c, ErrResumeWithoutCheckpoint
+	}
+	if c.CheckpointFile != "" {
+		if c.Duration > 0 || c.ReplayFile != "" || c.TopologyFile != "" || c.IssuesTxType(UserOpTxType) {
+			return c, ErrCheckpointOptions
+		}
+		if c.CheckpointInterval <= 0 {
+			return c, fmt.Errorf("invalid checkpoint interval %s <= 0", c.CheckpointInterval)
+		}
+	}
+	if c.Presign && (c.ReplayFile != "" || c.TopologyFile != "" || c.IssuesTxType(UserOpTxType)) {
+		return c, ErrPresignOptions
+	}
+	if c.TopologyFile != "" {
+		if c.Duration > 0 || c.WarmupTxs > 0 || c.ReplayFile != "" || v.IsSet(TxTypeKey) || c.TxMix != "" || len(c.ConfirmEndpoints) > 0 || c.BlockStats {
+			return c, ErrTopologyOptions
+		}
+		if c.OnError == ContinueOnError {
+			return c, ErrTopologyOnError
+		}
+	}
+	if c.IssuesTxType(BlobTxType) {
+		if c.BlobsPerTx <= 0 || c.BlobsPerTx > MaxBlobsPerTx {
+			return c, fmt.Errorf("invalid blobs per tx %d, must be in [1, %d]", c.BlobsPerTx, MaxBlobsPerTx)
+		}
+		if c.MaxBlobFeeCap < 0 {
+			return c, fmt.Errorf("invalid max blob fee cap %d < 0", c.MaxBlobFeeCap)
+		}
+	}
+	if c.GasLimit != 0 {
+		// Every tx must be able to carry the largest payload.
+		payloadSize := max(c.MinPayloadSize, c.MaxPayloadSize)
+		if intrinsicGas := IntrinsicTxGas(payloadSize); c.GasLimit < intrinsicGas {
+			return c, fmt.Errorf("invalid gas limit %d < intrinsic gas %d of a %s tx with a %d byte payload", c.GasLimit, intrinsicGas, c.TxType, payloadSize)
+		}
+	}
+	switch c.Signer {
+	case LocalSigner:
+	case RemoteSigner:
+		if c.RemoteSigner == "" {
+			return c, ErrNoRemoteSignerEndpoint
+		}
+	default:
+		return c, fmt.Errorf("invalid signer %q, must be %q or %q", c.Signer, LocalSigner, RemoteSigner)
+	}
 	return c, nil
 }
 
+// IntrinsicTxGas returns the intrinsic gas of a transfer or blob tx carrying a calldata payload of
+// [payloadSize] bytes. Every byte is charged as non-zero, so the result covers any payload of the given size.
+func IntrinsicTxGas(payloadSize uint64) uint64 {
+	return params.TxGas + payloadSize*params.TxDataNonZeroGasEIP2028
+}
+
+// TxGasLimit returns the gas limit of a tx specified by [c] carrying a calldata payload of [payloadSize]
+// bytes: GasLimit if set, or otherwise the intrinsic gas of the tx.
+func (c Config) TxGasLimit(payloadSize uint64) uint64 {
+	if c.GasLimit != 0 {
+		return c.GasLimit
+	}
+	return IntrinsicTxGas(payloadSize)
+}
+
+// TxTypeShare is the percentage of the workers of a tx mix that issue txs of a tx type.
+type TxTypeShare struct {
+	TxType  string
+	Percent int
+}
+
+// TxMixShares returns the share of each tx type of the tx mix specified by [c], in the order they are
+// specified, or nil if [c] does not specify a tx mix.
+func (c Config) TxMixShares() []TxTypeShare {
+	shares, _ := parseTxMix(c.TxMix)
+	return shares
+}
+
+// ConfirmsByReceipt returns true if the workers specified by [c] confirm txs by their receipts rather
+// than by polling the nonce of their sender, which is required once a worker issues txs from several keys.
+func (c Config) ConfirmsByReceipt() bool {
+	return c.ConfirmByReceipt || c.KeysPerWorker > 1
+}
+
+// IssuesTxType returns true if any worker specified by [c] issues txs of [txType].
+func (c Config) IssuesTxType(txType string) bool {
+	if c.TxMix == "" {
+		return c.TxType == txType
+	}
+	for _, share := range c.TxMixShares() {
+		if share.TxType == txType {
+			return true
+		}
+	}
+	return false
+}
+
+// InjectedLatency returns the minimum and maximum artificial latency to add to every tx issuance and
+// confirmation, which are both 0 if no latency is injected.
+func (c Config) InjectedLatency() (time.Duration, time.Duration) {
+	minDelay, maxDelay, _ := parseInjectLatency(c.InjectLatency)
+	return minDelay, maxDelay
+}
+
+// parseInjectLatency parses [latency], either a fixed duration such as "200ms" or a range of durations
+// such as "100ms-500ms" to draw a random delay from, into its minimum and maximum delays.
+func parseInjectLatency(latency string) (time.Duration, time.Duration, error) {
+	if latency == "" {
+		return 0, 0, nil
+	}
+	minStr, maxStr, isRange := strings.Cut(latency, "-")
+	minDelay, err := time.ParseDuration(strings.TrimSpace(minStr))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid inject latency %q, must be a duration or <min>-<max>: %w", latency, err)
+	}
+	maxDelay := minDelay
+	if isRange {
+		maxDelay, err = time.ParseDuration(strings.TrimSpace(maxStr))
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid inject latency %q, must be a duration or <min>-<max>: %w", latency, err)
+		}
+	}
+	if minDelay <= 0 || maxDelay < minDelay {
+		return 0, 0, fmt.Errorf("invalid inject latency %q, must be positive with min <= max", latency)
+	}
+	return minDelay, maxDelay, nil
+}
+
+// parseTxMix parses [txMix], a comma separated list of <tx type>:<percent> pairs such as "transfer:70,blob:30",
+// into the share of each tx type. Returns nil if [txMix] is empty.
+func parseTxMix(txMix string) ([]TxTypeShare, error) {
+	if txMix == "" {
+		return nil, nil
+	}
+	var (
+		shares []TxTypeShare
+		total  int
+	)
+	for _, entry := range strings.Split(txMix, ",") {
+		txType, percentStr, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid tx mix entry %q, must be <tx type>:<percent>", entry)
+		}
+		if txType != TransferTxType && txType != BlobTxType {
+			return nil, fmt.Errorf("invalid tx mix type %q, must be %q or %q", txType, TransferTxType, BlobTxType)
+		}
+		for _, share := range shares {
+			if share.TxType == txType {
+				return nil, fmt.Errorf("duplicate tx mix type %q", txType)
+			}
+		}
+		percent, err := strconv.Atoi(percentStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tx mix percent %q of %q: %w", percentStr, txType, err)
+		}
+		if percent <= 0 {
+			return nil, fmt.Errorf("invalid tx mix percent %d of %q <= 0", percent, txType)
+		}
+		shares = append(shares, TxTypeShare{TxType: txType, Percent: percent})
+		total += percent
+	}
+	if total != 100 {
+		return nil, fmt.Errorf("%w, got %d", ErrTxMixPercentages, total)
+	}
+	return shares, nil
+}
+
+// Weights returns the weight of each worker specified by [c], or nil if the txs are not distributed
+// across the workers by weight.
+func (c Config) Weights() []uint64 {
+	weights, _ := parseWorkerWeights(c.WorkerWeights, c.Workers)
+	return weights
+}
+
+// parseWorkerWeights parses [workerWeights], a comma separated list of the non-negative weight of each
+// of the [workers] workers such as "10,1,1,1", of which at least one must be positive. Returns nil if
+// [workerWeights] is empty.
+func parseWorkerWeights(workerWeights string, workers int) ([]uint64, error) {
+	if workerWeights == "" {
+		return nil, nil
+	}
+	entries := strings.Split(workerWeights, ",")
+	if len(entries) != workers {
+		return nil, fmt.Errorf("invalid worker weights %q, must specify a weight for each of the %d workers", workerWeights, workers)
+	}
+	weights := make([]uint64, 0, len(entries))
+	var total uint64
+	for i, entry := range entries {
+		weight, err := strconv.ParseInt(strings.TrimSpace(entry), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q of worker %d: %w", entry, i, err)
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("invalid weight %d of worker %d < 0", weight, i)
+		}
+		if total+uint64(weight) < total {
+			return nil, fmt.Errorf("invalid worker weights %q, the sum of the weights overflows", workerWeights)
+		}
+		total += uint64(weight)
+		weights = append(weights, uint64(weight))
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("invalid worker weights %q, at least one weight must be > 0", workerWeights)
+	}
+	return weights, nil
+}
+
+// IsVerboseWorker returns true if the worker at index [worker] logs the progress of each of its batches.
+func (c Config) IsVerboseWorker(worker int) bool {
+	verbose, _ := parseVerboseWorkers(c.VerboseWorkers, c.Workers)
+	return verbose[worker]
+}
+
+// parseVerboseWorkers returns the set of the indices of the [workers] workers selected by [verboseWorkers],
+// which is AllVerboseWorkers, NoVerboseWorkers, FirstVerboseWorker or a comma separated list of indices.
+func parseVerboseWorkers(verboseWorkers string, workers int) (map[int]bool, error) {
+	verbose := make(map[int]bool)
+	switch verboseWorkers {
+	case AllVerboseWorkers:
+		for i := 0; i < workers; i++ {
+			verbose[i] = true
+		}
+	case NoVerboseWorkers:
+	case FirstVerboseWorker:
+		verbose[0] = true
+	default:
+		for _, index := range strings.Split(verboseWorkers, ",") {
+			worker, err := strconv.Atoi(strings.TrimSpace(index))
+			if err != nil {
+				return nil, fmt.Errorf("invalid verbose workers %q, must be %q, %q, %q or a comma separated list of worker indices: %w",
+					verboseWorkers, AllVerboseWorkers, NoVerboseWorkers, FirstVerboseWorker, err)
+			}
+			if worker < 0 || worker >= workers {
+				return nil, fmt.Errorf("invalid verbose worker %d, must be in [0, %d)", worker, workers)
+			}
+			verbose[worker] = true
+		}
+	}
+	return verbose, nil
+}
+
+// VerifyFundKeys returns an error if [c] does not specify the keys to fund for the fund-keys command.
+func (c Config) VerifyFundKeys() error {
+	if c.NumKeys <= 0 {
+		return ErrNoKeys
+	}
+	if c.FundingAmount == 0 {
+		return ErrNoFundingAmount
+	}
+	return nil
+}
+
 func BuildViper(fs *pflag.FlagSet, args []string) (*viper.Viper, error) {
 	if err := fs.Parse(args); err != nil {
 		return nil, err
@@ -116,14 +788,80 @@ func addSimulatorFlags(fs *pflag.FlagSet) {
 	fs.Bool(VersionKey, false, "Print the version and exit")
 	fs.String(ConfigFilePathKey, "", "Specify the config path to use to load a YAML config for the simulator")
 	fs.StringSlice(EndpointsKey, []string{"ws://127.0.0.1:9650/ext/bc/C/ws"}, "Specify a comma separated list of RPC Websocket Endpoints (minimum of 1 endpoint)")
+	fs.StringSlice(ConfirmEndpointsKey, nil, "Specify a comma separated list of RPC Websocket Endpoints to confirm txs on, assigned to workers like endpoints (empty confirms txs on the endpoint they are issued to)")
 	fs.Int64(MaxFeeCapKey, 50, "Specify the maximum fee cap to use for transactions denominated in GWei (must be > 0)")
 	fs.Int64(MaxTipCapKey, 1, "Specify the max tip cap for transactions denominated in GWei (must be >= 0)")
+	fs.Int(FeeTiersKey, 1, "Specify the number of fee tiers to assign workers to in round robin order, evenly spaced from the min to the max fee and tip caps (1 gives every worker the max caps)")
+	fs.Int64(MinFeeCapKey, 0, "Specify the fee cap of the lowest fee tier denominated in GWei (must be <= max-fee-cap)")
+	fs.Int64(MinTipCapKey, 0, "Specify the tip cap of the lowest fee tier denominated in GWei (must be <= max-tip-cap)")
 	fs.Uint64(TxsPerWorkerKey, 100, "Specify the number of transactions to create per worker (must be > 0)")
+	fs.Uint64(TotalTxsKey, 0, "Specify the total number of transactions to create, distributed evenly across workers (overrides txs-per-worker, 0 uses txs-per-worker)")
+	fs.Duration(DurationKey, 0, "Specify a duration to issue txs for continuously instead of a number of txs per worker, after which issued txs are confirmed (0 issues txs-per-worker txs per worker)")
+	fs.Uint64(WarmupTxsKey, 0, "Specify the number of transactions each worker issues and confirms before the load test, which are excluded from all metrics")
+	fs.Bool(PresignKey, false, "Encode every tx to its raw bytes when it is generated, before the load test, and issue the raw bytes directly so that no signing or encoding happens while issuing txs")
+	fs.Uint64(PresignBufferKey, 0, "Specify the maximum number of pre-signed txs each worker holds ahead of issuance if presign is set, pre-signing the rest in the background to bound memory (0 pre-signs every tx before the load test)")
 	fs.Int(WorkersKey, 1, "Specify the number of workers to create for the simulator (must be > 0)")
+	fs.Int(KeysPerWorkerKey, 1, "Specify the number of keys each worker issues txs from, rotating across them so that the nonce sequences of several accounts are in flight at once (more than 1 confirms txs by receipt)")
 	fs.String(KeyDirKey, ".simulator/keys", "Specify the directory to save private keys in (INSECURE: only use for testing)")
+	fs.String(KeyPassphraseKey, "", "Specify the passphrase to decrypt keystore files in the key directory and to encrypt generated keys with. Prefer setting EVM_SIMULATOR_KEY_PASSPHRASE to keep it out of the process arguments. If empty, generated keys are saved in plaintext.")
 	fs.Duration(TimeoutKey, 5*time.Minute, "Specify the timeout for the simulator to complete (0 indicates no timeout)")
 	fs.String(LogLevelKey, "info", "Specify the log level to use in the simulator")
+	fs.String(LogFormatKey, TextLogFormat, "Specify the log format to use in the simulator (text or json lines)")
 	fs.Uint64(BatchSizeKey, 100, "Specify the batchsize for the worker to issue and confirm txs")
-	fs.Uint64(MetricsPortKey, 8082, "Specify the port to use for the metrics server")
+	fs.Uint64(MetricsPortKey, 8082, "Specify the port to use for the metrics server (0 binds to an ephemeral port)")
 	fs.String(MetricsOutputKey, "", "Specify the file to write metrics in json format, or empy to write to stdout (defaults to stdout)")
+	fs.Bool(MetricsEnabledKey, true, "Start the metrics server")
+	fs.String(PushgatewayURLKey, "", "Specify the URL of a Prometheus Pushgateway to push metrics to, in addition to serving them (empty disables pushing)")
+	fs.Duration(PushIntervalKey, 10*time.Second, "Specify the interval between pushes of metrics to the Pushgateway (must be > 0 if pushgateway-url is set)")
+	fs.Duration(ReportIntervalKey, 0, "Specify the interval between logs of the TPS, in-flight txs, and error rate of the load test while it runs (0 disables progress reports)")
+	fs.String(OnErrorKey, AbortOnError, "Specify whether a tx that fails to issue or confirm aborts the load test or is counted in the metrics and skipped (abort or continue)")
+	fs.Bool(ConfirmByReceiptKey, false, "Confirm txs by fetching the receipts of each batch in a single batch RPC call instead of polling the sender's nonce")
+	fs.Bool(ConfirmByLogKey, false, "Additionally require the receipt of each confirmed tx to contain the log expected by its workload, failing the confirmation of txs that succeeded without emitting it (adds a receipt request per tx)")
+	fs.Uint64(ConfirmationsKey, 0, "Specify the number of blocks that must be built on top of the block of a tx before it is confirmed, re-checking that the tx is still in that block (0 confirms txs once they are included)")
+	fs.Int(FeeBumpRetriesKey, 0, "Specify the maximum number of times to re-issue a tx rejected as underpriced with bumped tip and fee caps (0 disables fee bumps)")
+	fs.Uint64(FeeBumpPercentKey, 10, "Specify the percentage to bump the tip and fee caps of a tx rejected as underpriced by on each retry")
+	fs.Bool(TxCostMetricsKey, false, "Record the gas used and effective tip of every confirmed tx from its receipt (adds receipt and header requests during the load test)")
+	fs.Bool(BlockStatsKey, false, "Analyze the blocks produced during the load test once it completes, reporting their gas utilization, txs per block, and block times (adds a header and tx count request per block)")
+	fs.Bool(WorkerPoolKey, false, "Execute tx sequences with a bounded pool of goroutines instead of one goroutine per worker")
+	fs.Int(ConcurrencyKey, 0, "Specify the number of goroutines in the worker pool (0 defaults to GOMAXPROCS)")
+	fs.Bool(SynchronizedStartKey, false, "Wait until every worker is ready to issue txs and then release them together, measuring the load test from their release to reduce the variance of short runs")
+	fs.Int(EndpointLimitKey, 0, "Specify the maximum number of concurrent RPCs the workers sharing an endpoint make to it to issue and confirm txs, reporting how often workers were blocked on the limit (0 does not limit RPCs)")
+	fs.Float64(ConfirmSampleRateKey, 1, "Specify the fraction of issued txs to confirm, chosen at random, issuing every tx but measuring confirmation latency only on the sample and labelling the results as sampled (1 confirms every tx)")
+	fs.Int(ConfirmConcurrencyKey, 1, "Specify the maximum number of txs of a batch each worker confirms concurrently (1 confirms txs one at a time)")
+	fs.Int(MaxInflightKey, 0, "Specify the maximum number of issued but unconfirmed txs of each worker, confirming the oldest tx before issuing more (0 confirms each batch once it is issued)")
+	fs.Duration(MaxConfirmWaitKey, 0, "Specify the maximum time each worker waits for its pending txs to confirm once it has issued its last tx, reporting the txs still unconfirmed as unconfirmed instead of waiting until the timeout (0 waits until the timeout)")
+	fs.Duration(BatchLatencyTargetKey, 0, "Specify the confirmation time of a batch to adapt the batch size of each worker to, growing it while batches confirm faster and shrinking it once they confirm slower (0 keeps the batch size fixed)")
+	fs.Uint64(MinBatchSizeKey, 1, "Specify the minimum batch size of each worker if batch-latency-target is set")
+	fs.Uint64(MaxBatchSizeKey, 1000, "Specify the maximum batch size of each worker if batch-latency-target is set")
+	fs.String(VerboseWorkersKey, AllVerboseWorkers, "Specify the workers that log the progress of each batch at info level (all, none, first, or a comma separated list of worker indices such as 0,3)")
+	fs.String(TxRecordFileKey, "", "Specify the file to record the hash and outcome of every issued and confirmed tx as json lines (empty disables recording)")
+	fs.String(ReplayFileKey, "", "Specify a file of RLP encoded signed txs to issue in order instead of generating transfers (the senders must already be funded)")
+	fs.String(SignerKey, LocalSigner, "Specify the signer to sign txs with (local or remote)")
+	fs.String(RemoteSignerKey, "", "Specify the endpoint of the clef-style external signer to use with the remote signer")
+	fs.Int(NumKeysKey, 0, fmt.Sprintf("Specify the number of keys to generate and fund with the %s command (must be > 0)", FundKeysCommand))
+	fs.Uint64(FundingAmountKey, 0, fmt.Sprintf("Specify the minimum balance of each key funded by the %s command or for a duration load test denominated in GWei (must be > 0)", FundKeysCommand))
+	fs.Bool(SkipFundingKey, false, "Skip distributing funds and use the keys in the key directory as already funded")
+	fs.Int(FundingRetriesKey, 3, "Specify the maximum number of times to re-issue funding txs that fail to fund their address")
+	fs.Duration(FundingBackoffKey, time.Second, "Specify the time to wait before re-issuing failed funding txs, which doubles after each retry")
+	fs.Int(FundingFanoutKey, 0, "Specify the number of intermediate keys that fund the other keys in parallel when more keys need funds (0 funds every key from a single key)")
+	fs.Bool(ReclaimFundsKey, false, "Return the unused funds of each worker key to the funding address after the load test")
+	fs.Uint64(MinPayloadSizeKey, 0, "Specify the size in bytes of the calldata payload attached to each tx (or the minimum size if max-payload-size is set)")
+	fs.Uint64(MaxPayloadSizeKey, 0, "Specify the maximum size in bytes of the calldata payload, to pick a random size in [min-payload-size, max-payload-size] for each tx (0 uses a fixed min-payload-size)")
+	fs.Uint64(GasLimitKey, 0, "Specify the gas limit of every transfer or blob tx, which must cover the intrinsic gas of the largest payload (0 uses the intrinsic gas of each tx)")
+	fs.String(TxTypeKey, TransferTxType, "Specify the type of txs to issue (transfer, blob, or user-op)")
+	fs.Int64(SeedKey, 0, "Specify the seed of the randomness of generated txs, such as their payload sizes and contents, to reproduce a previous run (0 generates a random seed, which is logged)")
+	fs.String(TxMixKey, "", "Specify the percentage of workers issuing each tx type as a comma separated list of <tx type>:<percent> pairs summing to 100, such as transfer:70,blob:30 (overrides tx-type, empty issues tx-type)")
+	fs.Int(BlobsPerTxKey, 1, fmt.Sprintf("Specify the number of blobs of random data attached to each blob tx (must be in [1, %d])", MaxBlobsPerTx))
+	fs.Int64(MaxBlobFeeCapKey, 1, "Specify the maximum fee cap per unit of blob gas for blob txs denominated in GWei (must be >= 0)")
+	fs.String(EntryPointKey, "0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789", "Specify the address of the ERC-4337 (v0.6) EntryPoint to submit user operations to")
+	fs.String(AccountFactoryKey, "", "Specify the address of the SimpleAccountFactory deploying the account of each worker key for user operations")
+	fs.String(BundlerEndpointKey, "", "Specify the RPC endpoint of the bundler to submit user operations to")
+	fs.String(InjectLatencyKey, "", "Testing aid: delay every tx issuance and confirmation by a fixed duration (e.g. 200ms) or a random duration in a range (e.g. 100ms-500ms) to simulate a slow node (empty injects no latency)")
+	fs.String(WorkerWeightsKey, "", "Specify a comma separated list of the non-negative weight of each worker, such as 10,1,1,1, to distribute the txs of all workers in proportion to the weights instead of evenly (empty distributes txs evenly)")
+	fs.String(CheckpointFileKey, "", "Specify a json file to periodically save the next nonce and progress of each worker to, so that an interrupted load test can be resumed (empty disables checkpointing)")
+	fs.Duration(CheckpointIntervalKey, 10*time.Second, "Specify the interval between saves of the checkpoint-file")
+	fs.Bool(ResumeKey, false, "Resume the load test saved in checkpoint-file, issuing only the remaining txs of each worker starting at its checkpointed nonce")
+	fs.String(TopologyFileKey, "", "Specify a json file describing subnets and the pairs of their blockchains to send and deliver warp messages between instead of issuing transfers (empty issues txs to endpoints)")
+	fs.Duration(ReadinessTimeoutKey, time.Minute, "Specify the timeout to wait for every endpoint to be ready before starting (0 skips the readiness check)")
+	fs.StringSlice(HealthEndpointsKey, nil, "Specify a comma separated list of AvalancheGo node URIs (e.g. http://127.0.0.1:9650) to check for readiness before starting")
 }
@@ -0,0 +1,151 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	ErrNoTopologySubnets = errors.New("topology must specify at least one subnet")
+	ErrNoWarpPairs       = errors.New("topology must specify at least one warp pair")
+)
+
+// Topology describes the subnets of a multi-subnet warp load test and the pairs of their blockchains
+// to send warp messages between.
+type Topology struct {
+	Subnets   []TopologySubnet `json:"subnets"`
+	WarpPairs []WarpPair       `json:"warpPairs"`
+}
+
+// TopologySubnet describes a subnet and the Subnet-EVM blockchain of it to send or deliver warp messages on.
+type TopologySubnet struct {
+	// Name identifies the subnet in [WarpPair]s.
+	Name string `json:"name"`
+	// SubnetID is the ID of the subnet, or the empty ID for the Primary Network.
+	SubnetID ids.ID `json:"subnetID"`
+	// BlockchainID is the ID of the blockchain, which every endpoint must serve.
+	BlockchainID ids.ID `json:"blockchainID"`
+	// Endpoints are the RPC Websocket Endpoints of the blockchain, assigned to workers in round robin order.
+	Endpoints []string `json:"endpoints"`
+	// NodeURI is the base URI of a node validating the subnet (e.g. http://127.0.0.1:9650) to fetch the
+	// aggregate signatures of the warp messages sent on the blockchain from. Only required if the
+	// blockchain is the source of a warp pair.
+	NodeURI string `json:"nodeURI"`
+}
+
+// WarpPair is a pair of blockchains, named by their subnets, to send warp messages from [Source] to
+// [Destination] between.
+type WarpPair struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+}
+
+// String returns the pair as "<source>-><destination>".
+func (p WarpPair) String() string {
+	return p.Source + "->" + p.Destination
+}
+
+// LoadTopology reads the Topology in json format at [path] and verifies it.
+func LoadTopology(path string) (*Topology, error) {
+	topologyBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topology file %s: %w", path, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(topologyBytes))
+	decoder.DisallowUnknownFields()
+	topology := &Topology{}
+	if err := decoder.Decode(topology); err != nil {
+		return nil, fmt.Errorf("failed to parse topology file %s: %w", path, err)
+	}
+	if err := topology.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid topology file %s: %w", path, err)
+	}
+	return topology, nil
+}
+
+// Verify returns an error if [t] does not describe a valid warp load test.
+func (t *Topology) Verify() error {
+	if len(t.Subnets) == 0 {
+		return ErrNoTopologySubnets
+	}
+	if len(t.WarpPairs) == 0 {
+		return ErrNoWarpPairs
+	}
+	names := make(map[string]bool, len(t.Subnets))
+	blockchainIDs := make(map[ids.ID]bool, len(t.Subnets))
+	for i, subnet := range t.Subnets {
+		if subnet.Name == "" {
+			return fmt.Errorf("subnet %d has no name", i)
+		}
+		if names[subnet.Name] {
+			return fmt.Errorf("duplicate subnet %q", subnet.Name)
+		}
+		names[subnet.Name] = true
+		if subnet.BlockchainID == ids.Empty {
+			return fmt.Errorf("subnet %q has no blockchain ID", subnet.Name)
+		}
+		if blockchainIDs[subnet.BlockchainID] {
+			return fmt.Errorf("duplicate blockchain ID %s of subnet %q", subnet.BlockchainID, subnet.Name)
+		}
+		blockchainIDs[subnet.BlockchainID] = true
+		if len(subnet.Endpoints) == 0 {
+			return fmt.Errorf("subnet %q has no endpoints", subnet.Name)
+		}
+	}
+	pairs := make(map[WarpPair]bool, len(t.WarpPairs))
+	for _, pair := range t.WarpPairs {
+		source := t.Subnet(pair.Source)
+		if source == nil {
+			return fmt.Errorf("warp pair %s references unknown subnet %q", pair, pair.Source)
+		}
+		if t.Subnet(pair.Destination) == nil {
+			return fmt.Errorf("warp pair %s references unknown subnet %q", pair, pair.Destination)
+		}
+		if pair.Source == pair.Destination {
+			return fmt.Errorf("warp pair %s must be between different subnets", pair)
+		}
+		if pairs[pair] {
+			return fmt.Errorf("duplicate warp pair %s", pair)
+		}
+		pairs[pair] = true
+		if source.NodeURI == "" {
+			return fmt.Errorf("subnet %q is the source of warp pair %s but has no node URI", pair.Source, pair)
+		}
+	}
+	return nil
+}
+
+// Subnet returns the subnet of [t] named [name], or nil if there is none.
+func (t *Topology) Subnet(name string) *TopologySubnet {
+	for i := range t.Subnets {
+		if t.Subnets[i].Name == name {
+			return &t.Subnets[i]
+		}
+	}
+	return nil
+}
+
+// PairedSubnets returns the subnets of [t] that are the source or destination of a warp pair, in the
+// order they are specified.
+func (t *Topology) PairedSubnets() []*TopologySubnet {
+	paired := make(map[string]bool, len(t.Subnets))
+	for _, pair := range t.WarpPairs {
+		paired[pair.Source] = true
+		paired[pair.Destination] = true
+	}
+	subnets := make([]*TopologySubnet, 0, len(paired))
+	for i := range t.Subnets {
+		if paired[t.Subnets[i].Name] {
+			subnets = append(subnets, &t.Subnets[i])
+		}
+	}
+	return subnets
+}
@@ -4,16 +4,27 @@
 package key
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/ava-labs/subnet-evm/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
 )
 
+// ErrNoPassphrase is returned when loading an encrypted key without a passphrase.
+var ErrNoPassphrase = errors.New("passphrase required to decrypt keystore file")
+
+// encryptedKeyExt is the extension of keys saved as keystore files, which distinguishes
+// them from plaintext keys saved under the same address.
+const encryptedKeyExt = ".json"
+
 type Key struct {
 	PrivKey *ecdsa.PrivateKey
 	Address common.Address
@@ -32,8 +43,36 @@ func Load(file string) (*Key, error) {
 	return CreateKey(pk), nil
 }
 
-// LoadAll loads all keys in [dir].
-func LoadAll(ctx context.Context, dir string) ([]*Key, error) {
+// LoadEncrypted attempts to open a [Key] stored in the Web3 Secret Storage (keystore)
+// format at [file] by decrypting it with [passphrase].
+func LoadEncrypted(file string, passphrase string) (*Key, error) {
+	keyJSON, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("problem reading keystore file %s: %w", file, err)
+	}
+	return decrypt(file, keyJSON, passphrase)
+}
+
+func decrypt(file string, keyJSON []byte, passphrase string) (*Key, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("%w: %s", ErrNoPassphrase, file)
+	}
+	k, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("problem decrypting keystore file %s: %w", file, err)
+	}
+	return CreateKey(k.PrivateKey), nil
+}
+
+// isKeystore returns true if [contents] is a keystore file rather than a hex-encoded
+// plaintext key.
+func isKeystore(contents []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(contents), []byte("{"))
+}
+
+// LoadAll loads all keys in [dir]. Keystore files are decrypted with [passphrase] and
+// take precedence over plaintext keys for the same address.
+func LoadAll(ctx context.Context, dir string, passphrase string) ([]*Key, error) {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			return nil, fmt.Errorf("unable to create %s: %w", dir, err)
@@ -56,14 +95,38 @@ func LoadAll(ctx context.Context, dir string) ([]*Key, error) {
 		return nil, fmt.Errorf("could not walk %s: %w", dir, err)
 	}
 
-	ks := make([]*Key, len(files))
-	for i, file := range files {
-		k, err := Load(file)
+	ks := make([]*Key, 0, len(files))
+	// Track the index of each loaded address in [ks] and whether it was loaded from a
+	// keystore file, so that an encrypted key replaces a plaintext key for the same address.
+	indices := make(map[common.Address]int)
+	encryptedAddrs := make(map[common.Address]bool)
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read key at %s: %w", file, err)
+		}
+		encrypted := isKeystore(contents)
+		var k *Key
+		if encrypted {
+			k, err = decrypt(file, contents, passphrase)
+		} else {
+			k, err = Load(file)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("could not load key at %s: %w", file, err)
 		}
 
-		ks[i] = k
+		i, ok := indices[k.Address]
+		switch {
+		case !ok:
+			indices[k.Address] = len(ks)
+			ks = append(ks, k)
+		case encrypted && !encryptedAddrs[k.Address]:
+			ks[i] = k
+		default:
+			continue
+		}
+		encryptedAddrs[k.Address] = encrypted
 	}
 	return ks, nil
 }
@@ -75,6 +138,30 @@ func (k *Key) Save(dir string) error {
 	return ethcrypto.SaveECDSA(fp, k.PrivKey)
 }
 
+// SaveEncrypted persists a [Key] to [dir] as a keystore file encrypted with
+// [passphrase] (where the filename is the hex-encoded address with a .json extension).
+// Keys are encrypted with the light scrypt parameters, so that the many keys used by a
+// load test can be decrypted quickly.
+func (k *Key) SaveEncrypted(dir string, passphrase string) error {
+	if passphrase == "" {
+		return ErrNoPassphrase
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("%w: cannot generate key id", err)
+	}
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         id,
+		Address:    k.Address,
+		PrivateKey: k.PrivKey,
+	}, passphrase, keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		return fmt.Errorf("%w: cannot encrypt key", err)
+	}
+	fp := filepath.Join(dir, k.Address.Hex()+encryptedKeyExt)
+	return os.WriteFile(fp, keyJSON, 0600)
+}
+
 // Generate creates a new [Key] and returns it.
 func Generate() (*Key, error) {
 	pk, err := ethcrypto.GenerateKey()
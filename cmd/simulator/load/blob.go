@@ -0,0 +1,110 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+var ErrBlobsNotSupported = errors.New("target chain does not support blob transactions")
+
+var _ txs.Worker[*types.Transaction] = (*blobMetricsWorker)(nil)
+
+// blobTxs returns true if [c] specifies that any worker should issue blob txs.
+func blobTxs(c config.Config) bool {
+	return c.IssuesTxType(config.BlobTxType)
+}
+
+// isBlobTxType returns true if [txType] is the tx type of blob txs.
+func isBlobTxType(txType string) bool {
+	return txType == config.BlobTxType
+}
+
+// blobTxBlobGas returns the blob gas consumed by each blob tx specified by [c].
+func blobTxBlobGas(c config.Config) uint64 {
+	return uint64(c.BlobsPerTx) * params.BlobTxBlobGasPerBlob
+}
+
+// checkBlobSupport returns ErrBlobsNotSupported if the latest header served by [client] does not
+// carry the EIP-4844 blob gas fields, which indicates the chain has not activated blob transactions.
+func checkBlobSupport(ctx context.Context, client ethclient.Client) error {
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest header: %w", err)
+	}
+	if header.ExcessBlobGas == nil || header.BlobGasUsed == nil {
+		return fmt.Errorf("%w: latest header %d has no blob gas fields", ErrBlobsNotSupported, header.Number)
+	}
+	return nil
+}
+
+// newBlobSidecar returns a sidecar of [numBlobs] blobs filled with random data from [rng], along with
+// their commitments and proofs.
+func newBlobSidecar(numBlobs int, rng *rand.Rand) (*types.BlobTxSidecar, error) {
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       make([]kzg4844.Blob, numBlobs),
+		Commitments: make([]kzg4844.Commitment, numBlobs),
+		Proofs:      make([]kzg4844.Proof, numBlobs),
+	}
+	for i := range sidecar.Blobs {
+		blob := &sidecar.Blobs[i]
+		if _, err := rng.Read(blob[:]); err != nil {
+			return nil, fmt.Errorf("failed to generate blob %d: %w", i, err)
+		}
+		// Clear the most significant byte of every field element, so that it is a canonical
+		// element of the BLS12-381 scalar field.
+		for j := 0; j < len(blob); j += params.BlobTxBytesPerFieldElement {
+			blob[j] = 0
+		}
+		commitment, err := kzg4844.BlobToCommitment(*blob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute commitment of blob %d: %w", i, err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(*blob, commitment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute proof of blob %d: %w", i, err)
+		}
+		sidecar.Commitments[i] = commitment
+		sidecar.Proofs[i] = proof
+	}
+	return sidecar, nil
+}
+
+// blobMetricsWorker wraps a Worker to record the blobs and blob gas of every confirmed tx.
+type blobMetricsWorker struct {
+	txs.Worker[*types.Transaction]
+	metrics *metrics.Metrics
+}
+
+func newBlobMetricsWorker(worker txs.Worker[*types.Transaction], metrics *metrics.Metrics) *blobMetricsWorker {
+	return &blobMetricsWorker{
+		Worker:  worker,
+		metrics: metrics,
+	}
+}
+
+func (w *blobMetricsWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	if err := w.Worker.ConfirmTx(ctx, tx); err != nil {
+		return err
+	}
+	w.metrics.BlobsConfirmed.Add(float64(len(tx.BlobHashes())))
+	w.metrics.BlobGasUsed.Add(float64(tx.BlobGas()))
+	return nil
+}
+
+func (w *blobMetricsWorker) Forget(txHash common.Hash) {
+	txs.Forget(w.Worker, txHash)
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// blockStatsConcurrency is the maximum number of blocks fetched concurrently when analyzing the blocks
+// produced during a load test.
+const blockStatsConcurrency = 16
+
+// blockTimeQuantiles are the quantiles of the time between blocks reported in BlockStats.
+var blockTimeQuantiles = []float64{0.5, 0.9, 0.99}
+
+// blockStatsCollector analyzes the blocks produced by a chain since it was created. A nil
+// blockStatsCollector collects nothing, so that callers need not check whether block stats are enabled.
+type blockStatsCollector struct {
+	client      ethclient.Client
+	startHeight uint64
+}
+
+// newBlockStatsCollector returns a blockStatsCollector of the blocks produced by the chain of [client]
+// from now on, or nil if block stats are not enabled by [c].
+func newBlockStatsCollector(ctx context.Context, c config.Config, client ethclient.Client) (*blockStatsCollector, error) {
+	if !c.BlockStats {
+		return nil, nil
+	}
+	startHeight, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block height before load test: %w", err)
+	}
+	return &blockStatsCollector{
+		client:      client,
+		startHeight: startHeight,
+	}, nil
+}
+
+// collect fetches the blocks produced since [b] was created, records their gas utilization, number of
+// txs, and block times in [m], and logs and returns a summary of them.
+func (b *blockStatsCollector) collect(ctx context.Context, m *metrics.Metrics) (*BlockStats, error) {
+	if b == nil {
+		return nil, nil
+	}
+	endHeight, err := b.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block height after load test: %w", err)
+	}
+	stats := &BlockStats{
+		BlockTimeQuantiles: make(map[float64]time.Duration, len(blockTimeQuantiles)),
+	}
+	if endHeight <= b.startHeight {
+		log.Info("No blocks produced during load test", "height", endHeight)
+		return stats, nil
+	}
+
+	// The header at the start height is fetched as well for the time of the first block produced.
+	headers := make([]*types.Header, endHeight-b.startHeight+1)
+	txCounts := make([]uint, len(headers))
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(blockStatsConcurrency)
+	for i := range headers {
+		i := i
+		eg.Go(func() error {
+			height := b.startHeight + uint64(i)
+			header, err := b.client.HeaderByNumber(egCtx, new(big.Int).SetUint64(height))
+			if err != nil {
+				return fmt.Errorf("failed to fetch header of block %d: %w", height, err)
+			}
+			headers[i] = header
+			if i == 0 {
+				return nil
+			}
+			txCount, err := b.client.TransactionCount(egCtx, header.Hash())
+			if err != nil {
+				return fmt.Errorf("failed to fetch tx count of block %d: %w", height, err)
+			}
+			txCounts[i] = txCount
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	var (
+		totalGasUsed  uint64
+		totalGasLimit uint64
+		blockTimes    = make([]time.Duration, 0, len(headers)-1)
+	)
+	for i, header := range headers[1:] {
+		txCount := uint64(txCounts[i+1])
+		// Block timestamps are in seconds, so the time between blocks produced within the same second is 0.
+		blockTime := time.Duration(header.Time-headers[i].Time) * time.Second
+		totalGasUsed += header.GasUsed
+		totalGasLimit += header.GasLimit
+		stats.Txs += txCount
+		stats.MaxTxsPerBlock = max(stats.MaxTxsPerBlock, txCount)
+		blockTimes = append(blockTimes, blockTime)
+		if header.GasLimit > 0 {
+			m.BlockGasUtilization.Observe(float64(header.GasUsed) / float64(header.GasLimit))
+		}
+		m.BlockTxs.Observe(float64(txCount))
+		m.BlockTime.Observe(blockTime.Seconds())
+	}
+	numBlocks := float64(len(blockTimes))
+	stats.FirstBlock = headers[1].Number.Uint64()
+	stats.LastBlock = endHeight
+	stats.Blocks = uint64(len(blockTimes))
+	stats.AvgGasUsed = float64(totalGasUsed) / numBlocks
+	stats.AvgGasLimit = float64(totalGasLimit) / numBlocks
+	if totalGasLimit > 0 {
+		stats.GasUtilization = float64(totalGasUsed) / float64(totalGasLimit)
+	}
+	stats.AvgTxsPerBlock = float64(stats.Txs) / numBlocks
+	stats.AvgBlockTime = time.Duration(headers[len(headers)-1].Time-headers[0].Time) * time.Second / time.Duration(len(blockTimes))
+	slices.Sort(blockTimes)
+	for _, quantile := range blockTimeQuantiles {
+		// Nearest-rank quantile of the sorted block times
+		rank := int(math.Ceil(quantile*numBlocks)) - 1
+		stats.BlockTimeQuantiles[quantile] = blockTimes[max(rank, 0)]
+	}
+
+	log.Info("Block utilization",
+		"blocks", stats.Blocks,
+		"firstBlock", stats.FirstBlock,
+		"lastBlock", stats.LastBlock,
+		"gasUtilization", stats.GasUtilization,
+		"avgGasUsed", stats.AvgGasUsed,
+		"avgGasLimit", stats.AvgGasLimit,
+		"avgTxsPerBlock", stats.AvgTxsPerBlock,
+		"maxTxsPerBlock", stats.MaxTxsPerBlock,
+		"avgBlockTime", stats.AvgBlockTime,
+		"p50BlockTime", stats.BlockTimeQuantiles[0.5],
+		"p99BlockTime", stats.BlockTimeQuantiles[0.99],
+	)
+	return stats, nil
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Checkpoint is the progress of a load test saved to resume it if it is interrupted.
+type Checkpoint struct {
+	Workers []WorkerCheckpoint `json:"workers"`
+}
+
+// WorkerCheckpoint is the progress of a single worker of a load test.
+type WorkerCheckpoint struct {
+	Address common.Address `json:"address"`
+	// NextNonce is the nonce of the next tx the worker issues.
+	NextNonce    uint64 `json:"nextNonce"`
+	IssuedTxs    uint64 `json:"issuedTxs"`
+	ConfirmedTxs uint64 `json:"confirmedTxs"`
+	TotalTxs     uint64 `json:"totalTxs"`
+}
+
+// RemainingTxs returns the number of txs the worker has yet to issue.
+func (w WorkerCheckpoint) RemainingTxs() uint64 {
+	if w.IssuedTxs >= w.TotalTxs {
+		return 0
+	}
+	return w.TotalTxs - w.IssuedTxs
+}
+
+// loadCheckpoint reads the Checkpoint saved at [path].
+func loadCheckpoint(path string) (*Checkpoint, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file %s: %w", path, err)
+	}
+	checkpoint := new(Checkpoint)
+	if err := json.Unmarshal(b, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint file %s: %w", path, err)
+	}
+	return checkpoint, nil
+}
+
+// newCheckpoint returns the Checkpoint of a fresh load test issuing [txCounts[i]] txs from each of
+// [senders], starting at their current nonce on [client].
+func newCheckpoint(ctx context.Context, client ethclient.Client, senders []common.Address, txCounts []uint64) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{Workers: make([]WorkerCheckpoint, len(senders))}
+	for i, sender := range senders {
+		nonce, err := client.NonceAt(ctx, sender, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch nonce of %s: %w", sender, err)
+		}
+		checkpoint.Workers[i] = WorkerCheckpoint{
+			Address:   sender,
+			NextNonce: nonce,
+			TotalTxs:  txCounts[i],
+		}
+	}
+	return checkpoint, nil
+}
+
+// resumeCheckpoint returns the progress of each of [senders] in the Checkpoint saved at [path], in the
+// order of [senders], reconciled against their current nonce on [client].
+//
+// The checkpoint is only saved periodically, so a worker may have issued txs after it was last saved.
+// If the nonce of a sender on chain has moved past its checkpointed nonce, the txs in between are
+// counted as issued and the worker resumes at the nonce on chain. Otherwise, the worker resumes at its
+// checkpointed nonce, since the txs it issued before being interrupted may still be pending.
+func resumeCheckpoint(ctx context.Context, path string, client ethclient.Client, senders []common.Address) (*Checkpoint, error) {
+	saved, err := loadCheckpoint(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(saved.Workers) != len(senders) {
+		return nil, fmt.Errorf("checkpoint file %s has %d workers, but the load test has %d", path, len(saved.Workers), len(senders))
+	}
+	savedWorkers := make(map[common.Address]WorkerCheckpoint, len(saved.Workers))
+	for _, w := range saved.Workers {
+		savedWorkers[w.Address] = w
+	}
+
+	checkpoint := &Checkpoint{Workers: make([]WorkerCheckpoint, len(senders))}
+	for i, sender := range senders {
+		w, ok := savedWorkers[sender]
+		if !ok {
+			return nil, fmt.Errorf("checkpoint file %s has no progress for worker %s", path, sender)
+		}
+		nonce, err := client.NonceAt(ctx, sender, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch nonce of %s: %w", sender, err)
+		}
+		if nonce > w.NextNonce {
+			log.Info("Reconciling checkpoint with nonce on chain", "address", sender, "checkpointNonce", w.NextNonce, "chainNonce", nonce)
+			w.IssuedTxs = min(w.IssuedTxs+nonce-w.NextNonce, w.TotalTxs)
+			w.NextNonce = nonce
+		} else if nonce < w.NextNonce {
+			log.Warn("Resuming ahead of nonce on chain, which stalls if the pending txs were dropped", "address", sender, "checkpointNonce", w.NextNonce, "chainNonce", nonce)
+		}
+		checkpoint.Workers[i] = w
+	}
+	return checkpoint, nil
+}
+
+// generateCheckpointTxSequences calls Setup on [generator] and then generates the remaining txs of
+// each worker of [checkpoint] for [keys[i]] with it, starting at its checkpointed nonce.
+func generateCheckpointTxSequences(ctx context.Context, generator txs.TxGenerator, keys []*ecdsa.PrivateKey, checkpoint *Checkpoint) ([]txs.TxSequence[*types.Transaction], error) {
+	if err := generator.Setup(ctx); err != nil {
+		return nil, fmt.Errorf("failed to set up tx generator: %w", err)
+	}
+	txSequences := make([]txs.TxSequence[*types.Transaction], len(keys))
+	for i, key := range keys {
+		w := checkpoint.Workers[i]
+		txSequence, err := txs.GenerateTxSequenceAt(ctx, generator.GenerateTx, key, w.NextNonce, w.RemainingTxs(), false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate tx sequence at index %d: %w", i, err)
+		}
+		txSequences[i] = txSequence
+	}
+	return txSequences, nil
+}
+
+// checkpointer records the progress of the workers of a load test and saves it to a file.
+type checkpointer struct {
+	path string
+
+	lock       sync.Mutex
+	checkpoint *Checkpoint
+}
+
+func newCheckpointer(path string, checkpoint *Checkpoint) *checkpointer {
+	return &checkpointer{
+		path:       path,
+		checkpoint: checkpoint,
+	}
+}
+
+// issued records that worker [i] issued a tx with [nonce].
+func (c *checkpointer) issued(i int, nonce uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	w := &c.checkpoint.Workers[i]
+	w.NextNonce = max(w.NextNonce, nonce+1)
+	w.IssuedTxs++
+}
+
+// confirmed records that worker [i] confirmed a tx.
+func (c *checkpointer) confirmed(i int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.checkpoint.Workers[i].ConfirmedTxs++
+}
+
+// save writes the recorded progress to the checkpoint file. The file is replaced atomically, so that
+// it is never left partially written if the load test is interrupted.
+func (c *checkpointer) save() error {
+	c.lock.Lock()
+	b, err := json.MarshalIndent(c.checkpoint, "", "  ")
+	c.lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to replace checkpoint file %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// run saves the recorded progress every [interval] until [ctx] is done.
+func (c *checkpointer) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.save(); err != nil {
+				log.Warn("Failed to save checkpoint", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+var _ txs.Worker[*types.Transaction] = (*checkpointWorker)(nil)
+
+// checkpointWorker wraps a Worker to record the txs it issues and confirms in a checkpointer.
+type checkpointWorker struct {
+	txs.Worker[*types.Transaction]
+	index        int
+	checkpointer *checkpointer
+}
+
+func newCheckpointWorker(worker txs.Worker[*types.Transaction], index int, checkpointer *checkpointer) *checkpointWorker {
+	return &checkpointWorker{
+		Worker:       worker,
+		index:        index,
+		checkpointer: checkpointer,
+	}
+}
+
+func (w *checkpointWorker) IssueTx(ctx context.Context, tx *types.Transaction) error {
+	if err := w.Worker.IssueTx(ctx, tx); err != nil {
+		return err
+	}
+	w.checkpointer.issued(w.index, tx.Nonce())
+	return nil
+}
+
+func (w *checkpointWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	if err := w.Worker.ConfirmTx(ctx, tx); err != nil {
+		return err
+	}
+	w.checkpointer.confirmed(w.index)
+	return nil
+}
+
+func (w *checkpointWorker) Forget(txHash common.Hash) {
+	txs.Forget(w.Worker, txHash)
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ txs.Worker[*types.Transaction] = (*confirmSampleWorker)(nil)
+
+// confirmSampleWorker wraps a Worker to confirm only a random sample of the txs it issues, so that the
+// RPCs confirming every tx do not cap the issuance of a fast chain. Each tx is sampled as it is issued,
+// and the wrapped worker forgets the txs it does not sample right away, so that a worker confirming
+// txs by receipt never fetches their receipts. The txs it does not sample fail to confirm with
+// txs.ErrConfirmNotSampled, which the agent counts as unsampled rather than failed.
+type confirmSampleWorker struct {
+	txs.Worker[*types.Transaction]
+	rate float64
+	rng  *rand.Rand
+
+	// Guards unsampled, since ConfirmTx may be called concurrently.
+	lock      sync.Mutex
+	unsampled set.Set[common.Hash]
+}
+
+// newConfirmSampleWorker returns a confirmSampleWorker wrapping [worker] that samples each tx with
+// probability [rate], drawn from a source seeded with [seed] so that a run can be reproduced.
+func newConfirmSampleWorker(worker txs.Worker[*types.Transaction], rate float64, seed int64) *confirmSampleWorker {
+	return &confirmSampleWorker{
+		Worker:    worker,
+		rate:      rate,
+		rng:       rand.New(rand.NewSource(seed)),
+		unsampled: set.NewSet[common.Hash](0),
+	}
+}
+
+func (w *confirmSampleWorker) IssueTx(ctx context.Context, tx *types.Transaction) error {
+	if err := w.Worker.IssueTx(ctx, tx); err != nil {
+		return err
+	}
+	// IssueTx is never called concurrently, so the sample drawn for each tx is reproducible.
+	if w.rng.Float64() < w.rate {
+		return nil
+	}
+	w.lock.Lock()
+	w.unsampled.Add(tx.Hash())
+	w.lock.Unlock()
+	txs.Forget(w.Worker, tx.Hash())
+	return nil
+}
+
+func (w *confirmSampleWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	w.lock.Lock()
+	sampled := !w.unsampled.Contains(tx.Hash())
+	w.unsampled.Remove(tx.Hash())
+	w.lock.Unlock()
+	if !sampled {
+		return txs.ErrConfirmNotSampled
+	}
+	return w.Worker.ConfirmTx(ctx, tx)
+}
+
+func (w *confirmSampleWorker) Forget(txHash common.Hash) {
+	w.lock.Lock()
+	w.unsampled.Remove(txHash)
+	w.lock.Unlock()
+	txs.Forget(w.Worker, txHash)
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// noopWorker issues and confirms every tx without an RPC.
+type noopWorker struct{}
+
+func (noopWorker) IssueTx(context.Context, *types.Transaction) error   { return nil }
+func (noopWorker) ConfirmTx(context.Context, *types.Transaction) error { return nil }
+func (noopWorker) LatestHeight(context.Context) (uint64, error)        { return 0, nil }
+
+func TestConfirmSampleDrainsDecorators(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+
+	// Decorate the sample worker as the loader does, with the workers keeping state for each tx wrapping it.
+	var worker txs.Worker[*types.Transaction] = newConfirmSampleWorker(noopWorker{}, 0.5, 1)
+	mempoolWorker := newMempoolWorker(worker, m)
+	typeWorker := newTxTypeWorker(mempoolWorker, "transfer", m)
+	tierWorker := newFeeTierWorker(typeWorker, feeTier{}, m)
+
+	var sampled, unsampled int
+	for i := uint64(0); i < 100; i++ {
+		tx := types.NewTx(&types.LegacyTx{Nonce: i})
+		require.NoError(tierWorker.IssueTx(ctx, tx))
+		err := tierWorker.ConfirmTx(ctx, tx)
+		if errors.Is(err, txs.ErrConfirmNotSampled) {
+			unsampled++
+			continue
+		}
+		require.NoError(err)
+		sampled++
+	}
+	require.Positive(sampled)
+	require.Positive(unsampled)
+	require.Empty(mempoolWorker.acceptedAt)
+	require.Empty(typeWorker.issuedAt)
+	require.Empty(tierWorker.issuedAt)
+}
+
+func TestConfirmSampleSkipsUnsampledReceipts(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	service := &receiptService{sent: make(map[common.Hash]bool)}
+	server := rpc.NewServer(0)
+	require.NoError(server.RegisterName("eth", service))
+	defer server.Stop()
+	receiptWorker := NewTxReceiptWorker(ctx, ethclient.NewClient(rpc.DialInProc(server)))
+	worker := newConfirmSampleWorker(receiptWorker, 0.5, 1)
+
+	const numTxs = 100
+	issued := make([]*types.Transaction, 0, numTxs)
+	for i := uint64(0); i < numTxs; i++ {
+		tx := types.NewTx(&types.LegacyTx{Nonce: i})
+		require.NoError(worker.IssueTx(ctx, tx))
+		issued = append(issued, tx)
+	}
+	// Only the sampled txs are pending receipts.
+	sampled := len(receiptWorker.pending)
+	require.Positive(sampled)
+	require.Less(sampled, numTxs)
+	require.Equal(numTxs-sampled, worker.unsampled.Len())
+
+	var confirmed int
+	for _, tx := range issued {
+		err := worker.ConfirmTx(ctx, tx)
+		if errors.Is(err, txs.ErrConfirmNotSampled) {
+			continue
+		}
+		require.NoError(err)
+		confirmed++
+	}
+	require.Equal(sampled, confirmed)
+	// The receipts of the unsampled txs are never requested.
+	require.Equal(sampled, service.requested)
+	require.Empty(receiptWorker.pending)
+	require.Zero(receiptWorker.confirmed.Len())
+	require.Zero(worker.unsampled.Len())
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// confirmationPollInterval is the interval between polls for the receipt of a tx and the height of
+// the chain while waiting for the block of a tx to reach the required depth.
+const confirmationPollInterval = time.Second
+
+var _ txs.Worker[*types.Transaction] = (*confirmationDepthWorker)(nil)
+
+// confirmationDepthWorker wraps a Worker to confirm a tx only once the block including it is [depth]
+// blocks deep. At that depth, the receipt of the tx is fetched again to check that the tx is still in
+// the same block. If the tx was reorged out of its block, the reorg is recorded and the tx must reach
+// [depth] blocks again in the block it is included in next.
+type confirmationDepthWorker struct {
+	txs.Worker[*types.Transaction]
+	client  ethclient.Client
+	depth   uint64
+	metrics *metrics.Metrics
+}
+
+func newConfirmationDepthWorker(worker txs.Worker[*types.Transaction], client ethclient.Client, depth uint64, metrics *metrics.Metrics) *confirmationDepthWorker {
+	return &confirmationDepthWorker{
+		Worker:  worker,
+		client:  client,
+		depth:   depth,
+		metrics: metrics,
+	}
+}
+
+func (w *confirmationDepthWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	if err := w.Worker.ConfirmTx(ctx, tx); err != nil {
+		return err
+	}
+	for {
+		receipt, err := w.awaitReceipt(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if err := w.awaitHeight(ctx, receipt.BlockNumber.Uint64()+w.depth); err != nil {
+			return fmt.Errorf("failed to await %d confirmations of tx %s: %w", w.depth, tx.Hash(), err)
+		}
+		deepReceipt, err := w.client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil && !errors.Is(err, interfaces.NotFound) {
+			return fmt.Errorf("failed to fetch receipt of tx %s: %w", tx.Hash(), err)
+		}
+		if err == nil && deepReceipt.BlockHash == receipt.BlockHash {
+			return nil
+		}
+		w.metrics.ReorgedTxs.Inc()
+		log.Warn("Tx was reorged out of its block", "txHash", tx.Hash(), "nonce", tx.Nonce(),
+			"blockNumber", receipt.BlockNumber, "blockHash", receipt.BlockHash)
+	}
+}
+
+func (w *confirmationDepthWorker) Forget(txHash common.Hash) {
+	txs.Forget(w.Worker, txHash)
+}
+
+// awaitReceipt returns the receipt of [tx], waiting for [tx] to be included in a block.
+func (w *confirmationDepthWorker) awaitReceipt(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	for {
+		receipt, err := w.client.TransactionReceipt(ctx, tx.Hash())
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, interfaces.NotFound) {
+			return nil, fmt.Errorf("failed to fetch receipt of tx %s: %w", tx.Hash(), err)
+		}
+		select {
+		case <-time.After(confirmationPollInterval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to await receipt of tx %s: %w", tx.Hash(), ctx.Err())
+		}
+	}
+}
+
+// awaitHeight waits for the chain to reach [height].
+func (w *confirmationDepthWorker) awaitHeight(ctx context.Context, height uint64) error {
+	for {
+		latestHeight, err := w.client.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		if latestHeight >= height {
+			return nil
+		}
+		select {
+		case <-time.After(confirmationPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// frequentEndpointWaits is the fraction of the RPCs to an endpoint that must wait for its concurrency
+// limit for the limit to be reported as frequently blocking the workers.
+const frequentEndpointWaits = 0.1
+
+// endpointLimiter bounds the number of concurrent RPCs that the workers sharing an endpoint make to it,
+// so that overloading the endpoint does not show up as a slower chain.
+// A nil endpointLimiter does not limit RPCs.
+type endpointLimiter struct {
+	endpoint string
+	sem      chan struct{}
+	m        *metrics.Metrics
+
+	// Number of RPCs made, and the number of them that waited for the limit and for how long in total.
+	calls    atomic.Uint64
+	waits    atomic.Uint64
+	waitTime atomic.Int64
+}
+
+// newEndpointLimiters returns a limiter of [limit] concurrent RPCs for each distinct endpoint of
+// [endpoints], keyed by endpoint, or nil if [limit] is 0.
+func newEndpointLimiters(limit int, m *metrics.Metrics, endpoints ...[]string) map[string]*endpointLimiter {
+	if limit == 0 {
+		return nil
+	}
+	limiters := make(map[string]*endpointLimiter)
+	for _, list := range endpoints {
+		for _, endpoint := range list {
+			if _, ok := limiters[endpoint]; ok {
+				continue
+			}
+			limiters[endpoint] = &endpointLimiter{
+				endpoint: endpoint,
+				sem:      make(chan struct{}, limit),
+				m:        m,
+			}
+		}
+	}
+	return limiters
+}
+
+// acquire blocks until an RPC can be made to the endpoint without exceeding its limit, or returns
+// ctx.Err() if [ctx] is done first. Every successful call must be followed by a call to release.
+func (l *endpointLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.calls.Add(1)
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	default:
+	}
+
+	start := time.Now()
+	defer func() {
+		waitTime := time.Since(start)
+		l.waits.Add(1)
+		l.waitTime.Add(int64(waitTime))
+		l.m.EndpointLimitWaits.WithLabelValues(l.endpoint).Inc()
+		l.m.EndpointLimitWaitTime.WithLabelValues(l.endpoint).Add(waitTime.Seconds())
+	}()
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the slot taken by a successful call to acquire.
+func (l *endpointLimiter) release() {
+	if l == nil {
+		return
+	}
+	<-l.sem
+}
+
+// logEndpointLimits logs how often the RPCs to each endpoint of [limiters] waited for its concurrency
+// limit, warning if they frequently did, since the throughput is then limited by the client.
+func logEndpointLimits(limiters map[string]*endpointLimiter) {
+	for endpoint, l := range limiters {
+		calls, waits := l.calls.Load(), l.waits.Load()
+		if calls == 0 {
+			continue
+		}
+		waitFraction := float64(waits) / float64(calls)
+		logFn := log.Info
+		msg := "Endpoint concurrency limit"
+		if waitFraction >= frequentEndpointWaits {
+			logFn = log.Warn
+			msg = "Workers were frequently blocked on the endpoint concurrency limit"
+		}
+		logFn(msg, "endpoint", endpoint, "limit", cap(l.sem), "rpcs", calls, "waits", waits,
+			"waitFraction", waitFraction, "waitTime", time.Duration(l.waitTime.Load()))
+	}
+}
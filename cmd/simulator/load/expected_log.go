@@ -0,0 +1,85 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ txs.Worker[*types.Transaction] = (*expectedLogWorker)(nil)
+
+var ErrExpectedLogMissing = errors.New("tx did not emit expected log")
+
+// expectedLog identifies the event a tx of a workload is expected to emit by the address of the
+// emitting contract and the first topic of the event, which is its signature hash.
+type expectedLog struct {
+	address common.Address
+	topic   common.Hash
+}
+
+// expectedLogGenerator is implemented by the tx generators of workloads whose txs must emit an event,
+// so that their confirmation can be checked against it.
+type expectedLogGenerator interface {
+	txs.TxGenerator
+	expectedLog() expectedLog
+}
+
+// expectedLogWorker wraps a Worker to fail the confirmation of every tx whose receipt does not contain
+// [expected], even if the tx succeeded, which catches txs that executed a different path than the
+// workload intended. Each mismatch is recorded in the ExpectedLogMismatches metric.
+type expectedLogWorker struct {
+	txs.Worker[*types.Transaction]
+	client   ethclient.Client
+	expected expectedLog
+	m        *metrics.Metrics
+}
+
+// newExpectedLogWorker returns an expectedLogWorker wrapping [worker] that fetches the receipts of
+// its confirmed txs from [client].
+func newExpectedLogWorker(worker txs.Worker[*types.Transaction], client ethclient.Client, expected expectedLog, m *metrics.Metrics) *expectedLogWorker {
+	return &expectedLogWorker{
+		Worker:   worker,
+		client:   client,
+		expected: expected,
+		m:        m,
+	}
+}
+
+func (w *expectedLogWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	if err := w.Worker.ConfirmTx(ctx, tx); err != nil {
+		return err
+	}
+	receipt, err := w.client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to fetch receipt of tx %s: %w", tx.Hash(), err)
+	}
+	if !w.expected.emittedBy(receipt) {
+		w.m.ExpectedLogMismatches.Inc()
+		return fmt.Errorf("%w: tx %s with status %d has no log of %s with topic %s",
+			ErrExpectedLogMissing, tx.Hash(), receipt.Status, w.expected.address, w.expected.topic)
+	}
+	return nil
+}
+
+func (w *expectedLogWorker) Forget(txHash common.Hash) {
+	txs.Forget(w.Worker, txHash)
+}
+
+// emittedBy returns true if [receipt] contains a log of [e.address] whose first topic is [e.topic].
+func (e expectedLog) emittedBy(receipt *types.Receipt) bool {
+	for _, l := range receipt.Logs {
+		if l.Address == e.address && len(l.Topics) > 0 && l.Topics[0] == e.topic {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+)
+
+var _ txs.Worker[*types.Transaction] = (*feeBumpWorker)(nil)
+
+// underpricedErrs are the mempool errors of a tx that may be accepted if re-issued with higher fees.
+// Since the errors are returned over RPC, they are matched by their message.
+var underpricedErrs = []error{
+	txpool.ErrUnderpriced,
+	txpool.ErrReplaceUnderpriced,
+	core.ErrFeeCapTooLow,
+}
+
+// isUnderpriced returns true if [err] was returned by the mempool for a tx with too low fees.
+func isUnderpriced(err error) bool {
+	msg := err.Error()
+	for _, underpricedErr := range underpricedErrs {
+		if strings.Contains(msg, underpricedErr.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+// feeBumpWorker wraps a Worker to re-issue txs rejected as underpriced with their tip and fee caps
+// bumped by [percent], up to [maxRetries] times per tx. Since a re-issued tx has a different hash, the
+// re-issued tx is confirmed in place of the tx passed to ConfirmTx.
+type feeBumpWorker struct {
+	txs.Worker[*types.Transaction]
+	key        *ecdsa.PrivateKey
+	signer     txs.TxSigner
+	percent    uint64
+	maxRetries int
+	metrics    *metrics.Metrics
+
+	// Guards bumped, since ConfirmTx may be called concurrently.
+	lock sync.Mutex
+	// Maps the hash of each issued tx that was re-issued with bumped fees to the re-issued tx.
+	bumped map[common.Hash]*types.Transaction
+}
+
+func newFeeBumpWorker(worker txs.Worker[*types.Transaction], key *ecdsa.PrivateKey, signer txs.TxSigner, percent uint64, maxRetries int, metrics *metrics.Metrics) *feeBumpWorker {
+	return &feeBumpWorker{
+		Worker:     worker,
+		key:        key,
+		signer:     signer,
+		percent:    percent,
+		maxRetries: maxRetries,
+		metrics:    metrics,
+		bumped:     make(map[common.Hash]*types.Transaction),
+	}
+}
+
+func (w *feeBumpWorker) IssueTx(ctx context.Context, tx *types.Transaction) error {
+	issuedTx := tx
+	for retry := 0; ; retry++ {
+		err := w.Worker.IssueTx(ctx, issuedTx)
+		if err == nil {
+			if issuedTx != tx {
+				w.lock.Lock()
+				w.bumped[tx.Hash()] = issuedTx
+				w.lock.Unlock()
+			}
+			return nil
+		}
+		if retry == w.maxRetries || !isUnderpriced(err) || ctx.Err() != nil {
+			return err
+		}
+		issuedTx, err = w.bumpFees(issuedTx)
+		if err != nil {
+			return err
+		}
+		w.metrics.FeeBumps.Inc()
+		log.Debug("Re-issuing underpriced tx with bumped fees", "txHash", tx.Hash(), "nonce", tx.Nonce(), "retry", retry+1,
+			"gasTipCap", issuedTx.GasTipCap(), "gasFeeCap", issuedTx.GasFeeCap())
+	}
+}
+
+func (w *feeBumpWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	w.lock.Lock()
+	bumpedTx, ok := w.bumped[tx.Hash()]
+	delete(w.bumped, tx.Hash())
+	w.lock.Unlock()
+	if ok {
+		return w.Worker.ConfirmTx(ctx, bumpedTx)
+	}
+	return w.Worker.ConfirmTx(ctx, tx)
+}
+
+func (w *feeBumpWorker) Forget(txHash common.Hash) {
+	w.lock.Lock()
+	bumpedTx, ok := w.bumped[txHash]
+	delete(w.bumped, txHash)
+	w.lock.Unlock()
+	if ok {
+		// The wrapped worker only issued the re-issued tx.
+		txHash = bumpedTx.Hash()
+	}
+	txs.Forget(w.Worker, txHash)
+}
+
+// bumpFees returns [tx] with its tip and fee caps bumped by [w.percent], signed by [w.key].
+func (w *feeBumpWorker) bumpFees(tx *types.Transaction) (*types.Transaction, error) {
+	var txData types.TxData
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		txData = &types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  w.bump(tx.GasTipCap()),
+			GasFeeCap:  w.bump(tx.GasFeeCap()),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	case types.BlobTxType:
+		txData = &types.BlobTx{
+			ChainID:    uint256.MustFromBig(tx.ChainId()),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  uint256.MustFromBig(w.bump(tx.GasTipCap())),
+			GasFeeCap:  uint256.MustFromBig(w.bump(tx.GasFeeCap())),
+			Gas:        tx.Gas(),
+			To:         *tx.To(),
+			Value:      uint256.MustFromBig(tx.Value()),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+			BlobFeeCap: uint256.MustFromBig(tx.BlobGasFeeCap()),
+			BlobHashes: tx.BlobHashes(),
+			Sidecar:    tx.BlobTxSidecar(),
+		}
+	default:
+		return nil, fmt.Errorf("cannot bump the fees of tx %s of type %d", tx.Hash(), tx.Type())
+	}
+	return w.signer.SignTx(w.key, types.NewTx(txData))
+}
+
+// bump returns [fee] increased by [w.percent], and by at least 1 wei so that a zero fee is bumped too.
+func (w *feeBumpWorker) bump(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+w.percent))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, common.Big1)
+	}
+	return bumped
+}
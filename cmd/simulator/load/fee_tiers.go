@@ -0,0 +1,104 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ txs.Worker[*types.Transaction] = (*feeTierWorker)(nil)
+
+// feeTier is the gas tip cap and gas fee cap bid by the workers assigned to a tier.
+type feeTier struct {
+	index     int
+	gasTipCap *big.Int
+	gasFeeCap *big.Int
+}
+
+// feeTiers returns the [c.FeeTiers] tiers specified by [c], evenly spaced from the min to the max
+// tip and fee caps, so that the last tier bids the highest.
+func feeTiers(c config.Config) []feeTier {
+	bigGwei := big.NewInt(params.GWei)
+	tiers := make([]feeTier, c.FeeTiers)
+	for i := range tiers {
+		tipCap, feeCap := c.MaxTipCap, c.MaxFeeCap
+		if c.FeeTiers > 1 {
+			steps := int64(c.FeeTiers - 1)
+			tipCap = c.MinTipCap + (c.MaxTipCap-c.MinTipCap)*int64(i)/steps
+			feeCap = c.MinFeeCap + (c.MaxFeeCap-c.MinFeeCap)*int64(i)/steps
+		}
+		tiers[i] = feeTier{
+			index:     i,
+			gasTipCap: new(big.Int).Mul(bigGwei, big.NewInt(tipCap)),
+			gasFeeCap: new(big.Int).Mul(bigGwei, big.NewInt(feeCap)),
+		}
+	}
+	return tiers
+}
+
+// assignFeeTiers assigns each of [senders] a tier from [tiers] in round robin order.
+func assignFeeTiers(tiers []feeTier, senders []common.Address) map[common.Address]feeTier {
+	assigned := make(map[common.Address]feeTier, len(senders))
+	for i, sender := range senders {
+		assigned[sender] = tiers[i%len(tiers)]
+	}
+	return assigned
+}
+
+// feeTierWorker wraps a Worker to record the issuance to confirmation time of every tx by fee tier.
+type feeTierWorker struct {
+	txs.Worker[*types.Transaction]
+	tier    string
+	metrics *metrics.Metrics
+
+	// Guards issuedAt, since ConfirmTx may be called concurrently.
+	lock     sync.Mutex
+	issuedAt map[common.Hash]time.Time
+}
+
+func newFeeTierWorker(worker txs.Worker[*types.Transaction], tier feeTier, metrics *metrics.Metrics) *feeTierWorker {
+	return &feeTierWorker{
+		Worker:   worker,
+		tier:     strconv.Itoa(tier.index),
+		metrics:  metrics,
+		issuedAt: make(map[common.Hash]time.Time),
+	}
+}
+
+func (w *feeTierWorker) IssueTx(ctx context.Context, tx *types.Transaction) error {
+	w.lock.Lock()
+	w.issuedAt[tx.Hash()] = time.Now()
+	w.lock.Unlock()
+	return w.Worker.IssueTx(ctx, tx)
+}
+
+func (w *feeTierWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	err := w.Worker.ConfirmTx(ctx, tx)
+	w.lock.Lock()
+	issuedAt, ok := w.issuedAt[tx.Hash()]
+	delete(w.issuedAt, tx.Hash())
+	w.lock.Unlock()
+	if ok && err == nil {
+		w.metrics.FeeTierIssuanceToConfirmationTxTimes.WithLabelValues(w.tier).Observe(time.Since(issuedAt).Seconds())
+	}
+	return err
+}
+
+func (w *feeTierWorker) Forget(txHash common.Hash) {
+	w.lock.Lock()
+	delete(w.issuedAt, txHash)
+	w.lock.Unlock()
+	txs.Forget(w.Worker, txHash)
+}
@@ -8,6 +8,7 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ava-labs/subnet-evm/cmd/simulator/key"
 	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
@@ -17,14 +18,52 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
 )
 
+// FundingRetryPolicy specifies how funding txs that fail to fund their address are re-issued.
+type FundingRetryPolicy struct {
+	// MaxRetries is the maximum number of times the funding txs are re-issued.
+	MaxRetries int
+	// InitialBackoff is the time to wait before the first retry, which doubles after each retry.
+	InitialBackoff time.Duration
+}
+
+// DefaultFundingRetryPolicy re-issues failed funding txs up to 3 times, waiting 1s, 2s and 4s.
+var DefaultFundingRetryPolicy = FundingRetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: time.Second,
+}
+
+// FundingOptions configures DistributeFunds. The zero value funds every key from a single key and
+// does not re-issue failed funding txs.
+type FundingOptions struct {
+	// Fanout, if positive and more than Fanout keys need funds, distributes the funds in two levels
+	// to avoid serializing every funding tx on the nonce of a single key: the keys are split into Fanout
+	// groups, the first key of each group is funded with enough to fund the rest of its group, and then
+	// each group is funded by its first key in parallel.
+	Fanout int
+	// RetryPolicy specifies how funding txs that fail to fund their address are re-issued.
+	RetryPolicy FundingRetryPolicy
+}
+
 // DistributeFunds ensures that each address in keys has at least [minFundsPerAddr] by sending funds
-// from the key with the highest starting balance.
+// from the key with the highest starting balance, as configured by [opts].
 // This function returns a set of at least [numKeys] keys, each having a minimum balance [minFundsPerAddr].
-func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.Key, numKeys int, minFundsPerAddr *big.Int, m *metrics.Metrics) ([]*key.Key, error) {
+func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.Key, numKeys int, minFundsPerAddr *big.Int, m *metrics.Metrics, opts FundingOptions) ([]*key.Key, error) {
+	fundedKeys, _, err := distributeFunds(ctx, client, keys, numKeys, minFundsPerAddr, m, opts)
+	return fundedKeys, err
+}
+
+// DistributeFundsFrom is the same as DistributeFunds, but additionally returns the address of the key
+// the funds were distributed from, so that unused funds can be returned to it with ReclaimFunds.
+func DistributeFundsFrom(ctx context.Context, client ethclient.Client, keys []*key.Key, numKeys int, minFundsPerAddr *big.Int, m *metrics.Metrics, opts FundingOptions) ([]*key.Key, common.Address, error) {
+	return distributeFunds(ctx, client, keys, numKeys, minFundsPerAddr, m, opts)
+}
+
+func distributeFunds(ctx context.Context, client ethclient.Client, keys []*key.Key, numKeys int, minFundsPerAddr *big.Int, m *metrics.Metrics, opts FundingOptions) ([]*key.Key, common.Address, error) {
 	if len(keys) < numKeys {
-		return nil, fmt.Errorf("insufficient number of keys %d < %d", len(keys), numKeys)
+		return nil, common.Address{}, fmt.Errorf("insufficient number of keys %d < %d", len(keys), numKeys)
 	}
 	fundedKeys := make([]*key.Key, 0, numKeys)
 	// TODO: clean up fund distribution.
@@ -37,7 +76,7 @@ func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.K
 	for _, key := range keys {
 		balance, err := client.BalanceAt(ctx, key.Address, nil)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch balance for addr %s: %w", key.Address, err)
+			return nil, common.Address{}, fmt.Errorf("failed to fetch balance for addr %s: %w", key.Address, err)
 		}
 
 		if balance.Cmp(minFundsPerAddr) < 0 {
@@ -54,11 +93,11 @@ func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.K
 	}
 	requiredFunds := new(big.Int).Mul(minFundsPerAddr, big.NewInt(int64(numKeys)))
 	if maxFundsBalance.Cmp(requiredFunds) < 0 {
-		return nil, fmt.Errorf("insufficient funds to distribute %d < %d", maxFundsBalance, requiredFunds)
+		return nil, common.Address{}, fmt.Errorf("insufficient funds to distribute %d < %d", maxFundsBalance, requiredFunds)
 	}
 	log.Info("Found max funded key", "address", maxFundsKey.Address, "balance", maxFundsBalance, "numFundAddrs", len(needFundsAddrs))
 	if len(fundedKeys) >= numKeys {
-		return fundedKeys[:numKeys], nil
+		return fundedKeys[:numKeys], maxFundsKey.Address, nil
 	}
 
 	// If there are not enough funded keys, cut [needFundsAddrs] to the number of keys that
@@ -67,17 +106,148 @@ func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.K
 	needFundsKeys = needFundsKeys[:fundKeysCutLen]
 	needFundsAddrs = needFundsAddrs[:fundKeysCutLen]
 
+	var (
+		retriedTxs int
+		err        error
+	)
+	if opts.Fanout > 0 && len(needFundsKeys) > opts.Fanout {
+		retriedTxs, err = fanOutFunds(ctx, client, maxFundsKey, needFundsKeys, minFundsPerAddr, opts.Fanout, opts.RetryPolicy, m)
+	} else {
+		retriedTxs, err = fundAddrs(ctx, client, maxFundsKey, needFundsAddrs, requiredFunds, minFundsPerAddr, opts.RetryPolicy, m)
+	}
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	// Verify the final balance of every funded address before the keys are used, since the intermediate
+	// keys of a fan-out spend part of their balance after being funded.
+	for _, addr := range needFundsAddrs {
+		balance, err := client.BalanceAt(ctx, addr, nil)
+		if err != nil {
+			return nil, common.Address{}, fmt.Errorf("failed to fetch balance for addr %s: %w", addr, err)
+		}
+		if balance.Cmp(minFundsPerAddr) < 0 {
+			return nil, common.Address{}, fmt.Errorf("funded addr %s has balance %d < %d", addr, balance, minFundsPerAddr)
+		}
+		log.Info("Funded address has balance", "addr", addr, "balance", balance)
+	}
+	log.Info("Funded all addresses", "numTxs", len(needFundsAddrs), "retriedTxs", retriedTxs)
+	fundedKeys = append(fundedKeys, needFundsKeys...)
+	return fundedKeys, maxFundsKey.Address, nil
+}
+
+// fanOutFunds funds each of [keys] with at least [minFundsPerAddr] from [from] through [fanout]
+// intermediate keys, which are the first key of each of [fanout] groups of [keys].
+// [from] funds each intermediate key with enough to cover its own minimum balance and to fund the
+// rest of its group, and then the intermediate keys fund their groups in parallel.
+// Returns the number of funding txs that were re-issued.
+func fanOutFunds(ctx context.Context, client ethclient.Client, from *key.Key, keys []*key.Key, minFundsPerAddr *big.Int, fanout int, retryPolicy FundingRetryPolicy, m *metrics.Metrics) (int, error) {
+	baseFee, err := client.EstimateBaseFee(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch estimated base fee: %w", err)
+	}
+	// Leave room for the base fee to double before the intermediate keys issue their funding txs.
+	txCost := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(2*params.TxGas))
+
+	groups := make([][]*key.Key, fanout)
+	for i, k := range keys {
+		groups[i%fanout] = append(groups[i%fanout], k)
+	}
+	intermediateAddrs := make([]common.Address, 0, fanout)
+	var intermediateFunds *big.Int
+	for _, group := range groups {
+		intermediateAddrs = append(intermediateAddrs, group[0].Address)
+		// Groups differ in size by at most one, so fund every intermediate key for the largest group.
+		if intermediateFunds == nil {
+			numFunded := big.NewInt(int64(len(group) - 1))
+			intermediateFunds = new(big.Int).Mul(minFundsPerAddr, big.NewInt(int64(len(group))))
+			intermediateFunds.Add(intermediateFunds, new(big.Int).Mul(txCost, numFunded))
+		}
+	}
+	log.Info("Funding intermediate keys", "numKeys", len(intermediateAddrs), "funds", intermediateFunds)
+	retriedTxs, err := fundAddrs(ctx, client, from, intermediateAddrs, intermediateFunds, intermediateFunds, retryPolicy, m)
+	if err != nil {
+		return retriedTxs, fmt.Errorf("failed to fund intermediate keys: %w", err)
+	}
+
+	log.Info("Funding keys from intermediate keys", "numKeys", len(keys)-len(intermediateAddrs), "fanout", fanout)
+	groupRetriedTxs := make([]int, len(groups))
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i, group := range groups {
+		i, group := i, group
+		eg.Go(func() error {
+			addrs := make([]common.Address, 0, len(group)-1)
+			for _, k := range group[1:] {
+				addrs = append(addrs, k.Address)
+			}
+			var err error
+			groupRetriedTxs[i], err = fundAddrs(egCtx, client, group[0], addrs, minFundsPerAddr, minFundsPerAddr, retryPolicy, m)
+			if err != nil {
+				return fmt.Errorf("failed to fund keys from intermediate key %s: %w", group[0].Address, err)
+			}
+			return nil
+		})
+	}
+	err = eg.Wait()
+	for _, n := range groupRetriedTxs {
+		retriedTxs += n
+	}
+	return retriedTxs, err
+}
+
+// fundAddrs sends [value] from [from] to each of [addrs] and re-issues the funding txs of the addresses
+// that remain below [minBalance], either because a funding tx failed or because it was dropped after
+// issuance, according to [retryPolicy] until every address is funded.
+// Returns the number of funding txs that were re-issued.
+func fundAddrs(ctx context.Context, client ethclient.Client, from *key.Key, addrs []common.Address, value *big.Int, minBalance *big.Int, retryPolicy FundingRetryPolicy, m *metrics.Metrics) (int, error) {
+	unfundedAddrs := addrs
+	backoff := retryPolicy.InitialBackoff
+	retriedTxs := 0
+	for retry := 0; ; retry++ {
+		sendErr := sendFunds(ctx, client, from, unfundedAddrs, value, m)
+		if sendErr != nil {
+			log.Warn("Failed to send funding transactions", "from", from.Address, "numTxs", len(unfundedAddrs), "err", sendErr)
+		}
+		var err error
+		unfundedAddrs, err = filterUnfunded(ctx, client, unfundedAddrs, minBalance)
+		if err != nil {
+			return retriedTxs, err
+		}
+		if len(unfundedAddrs) == 0 {
+			return retriedTxs, nil
+		}
+		if retry >= retryPolicy.MaxRetries {
+			if sendErr != nil {
+				return retriedTxs, fmt.Errorf("failed to fund %d addresses after %d retries: %w", len(unfundedAddrs), retry, sendErr)
+			}
+			return retriedTxs, fmt.Errorf("failed to fund %d addresses after %d retries", len(unfundedAddrs), retry)
+		}
+		retriedTxs += len(unfundedAddrs)
+		m.FundingRetries.Add(float64(len(unfundedAddrs)))
+		log.Info("Retrying funding transactions", "from", from.Address, "numTxs", len(unfundedAddrs), "retry", retry+1, "backoff", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return retriedTxs, fmt.Errorf("failed to fund %d addresses: %w", len(unfundedAddrs), ctx.Err())
+		}
+		backoff *= 2
+	}
+}
+
+// sendFunds issues a tx from [from] sending [value] to each address in [addrs] and waits for them to confirm.
+// The fees and starting nonce are fetched from [client] on each call, so that a retry reflects the current
+// state of the chain.
+func sendFunds(ctx context.Context, client ethclient.Client, from *key.Key, addrs []common.Address, value *big.Int, m *metrics.Metrics) error {
 	chainID, err := client.ChainID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch chainID: %w", err)
+		return fmt.Errorf("failed to fetch chainID: %w", err)
 	}
 	gasFeeCap, err := client.EstimateBaseFee(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch estimated base fee: %w", err)
+		return fmt.Errorf("failed to fetch estimated base fee: %w", err)
 	}
 	gasTipCap, err := client.SuggestGasTipCap(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch suggested gas tip: %w", err)
+		return fmt.Errorf("failed to fetch suggested gas tip: %w", err)
 	}
 	signer := types.LatestSignerForChainID(chainID)
 
@@ -91,9 +261,9 @@ func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.K
 			GasTipCap: gasTipCap,
 			GasFeeCap: gasFeeCap,
 			Gas:       params.TxGas,
-			To:        &needFundsAddrs[i],
+			To:        &addrs[i],
 			Data:      nil,
-			Value:     requiredFunds,
+			Value:     value,
 		})
 		if err != nil {
 			return nil, err
@@ -102,24 +272,98 @@ func DistributeFunds(ctx context.Context, client ethclient.Client, keys []*key.K
 		return tx, nil
 	}
 
-	numTxs := uint64(len(needFundsAddrs))
-	txSequence, err := txs.GenerateTxSequence(ctx, txGenerator, client, maxFundsKey.PrivKey, numTxs, false)
+	numTxs := uint64(len(addrs))
+	txSequence, err := txs.GenerateTxSequence(ctx, txGenerator, client, from.PrivKey, numTxs, false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate fund distribution sequence from %s of length %d", maxFundsKey.Address, len(needFundsAddrs))
+		return fmt.Errorf("failed to generate fund distribution sequence from %s of length %d", from.Address, len(addrs))
 	}
-	worker := NewSingleAddressTxWorker(ctx, client, maxFundsKey.Address)
-	txFunderAgent := txs.NewIssueNAgent[*types.Transaction](txSequence, worker, numTxs, m)
+	worker := NewSingleAddressTxWorker(ctx, client, from.Address)
+	txFunderAgent := txs.NewIssueNAgent[*types.Transaction](txSequence, worker, numTxs, m, txs.IssueNAgentOptions{
+		Logger: log.New("worker", "funder"),
+	})
+	return txFunderAgent.Execute(ctx)
+}
 
-	if err := txFunderAgent.Execute(ctx); err != nil {
-		return nil, err
-	}
-	for _, addr := range needFundsAddrs {
+// filterUnfunded returns the addresses in [addrs] with a balance less than [minFundsPerAddr].
+func filterUnfunded(ctx context.Context, client ethclient.Client, addrs []common.Address, minFundsPerAddr *big.Int) ([]common.Address, error) {
+	unfundedAddrs := make([]common.Address, 0, len(addrs))
+	for _, addr := range addrs {
 		balance, err := client.BalanceAt(ctx, addr, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch balance for addr %s: %w", addr, err)
 		}
-		log.Info("Funded address has balance", "addr", addr, "balance", balance)
+		if balance.Cmp(minFundsPerAddr) < 0 {
+			unfundedAddrs = append(unfundedAddrs, addr)
+		}
 	}
-	fundedKeys = append(fundedKeys, needFundsKeys...)
-	return fundedKeys, nil
+	return unfundedAddrs, nil
+}
+
+// ReclaimFunds sweeps the balance of each key in [keys], minus the gas required for the sweep
+// transaction, back to [to]. Keys with a balance that cannot cover the gas of the sweep
+// transaction are skipped.
+// This function returns the total amount of funds reclaimed.
+func ReclaimFunds(ctx context.Context, client ethclient.Client, keys []*key.Key, to common.Address) (*big.Int, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chainID: %w", err)
+	}
+	gasFeeCap, err := client.EstimateBaseFee(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch estimated base fee: %w", err)
+	}
+	gasTipCap, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch suggested gas tip: %w", err)
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	sweepGasCost := new(big.Int).Mul(gasFeeCap, new(big.Int).SetUint64(params.TxGas))
+
+	log.Info("Reclaiming funds", "to", to, "numKeys", len(keys))
+	worker := NewTxReceiptWorker(ctx, client)
+	sweepTxs := make([]*types.Transaction, 0, len(keys))
+	totalReclaimed := new(big.Int)
+	for _, key := range keys {
+		if key.Address == to {
+			continue
+		}
+		balance, err := client.BalanceAt(ctx, key.Address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch balance for addr %s: %w", key.Address, err)
+		}
+		if balance.Cmp(sweepGasCost) <= 0 {
+			log.Debug("Skipping reclaim for key with insufficient balance", "addr", key.Address, "balance", balance)
+			continue
+		}
+		nonce, err := client.NonceAt(ctx, key.Address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch nonce for addr %s: %w", key.Address, err)
+		}
+		value := new(big.Int).Sub(balance, sweepGasCost)
+		tx, err := types.SignNewTx(key.PrivKey, signer, &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       params.TxGas,
+			To:        &to,
+			Data:      nil,
+			Value:     value,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign reclaim tx for addr %s: %w", key.Address, err)
+		}
+		if err := worker.IssueTx(ctx, tx); err != nil {
+			return nil, fmt.Errorf("failed to issue reclaim tx for addr %s: %w", key.Address, err)
+		}
+		sweepTxs = append(sweepTxs, tx)
+		totalReclaimed.Add(totalReclaimed, value)
+	}
+	for _, tx := range sweepTxs {
+		if err := worker.ConfirmTx(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+	log.Info("Reclaimed funds successfully", "to", to, "numTxs", len(sweepTxs), "totalReclaimed", totalReclaimed)
+	return totalReclaimed, nil
 }
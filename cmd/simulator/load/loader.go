@@ -7,10 +7,16 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"math"
 	"math/big"
+	"math/bits"
+	"math/rand"
 	"os"
 	"os/signal"
+	"runtime"
+	"slices"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -28,9 +34,50 @@ import (
 )
 
 const (
-	MetricsEndpoint = "/metrics" // Endpoint for the Prometheus Metrics Server
+	MetricsEndpoint = "/metrics"  // Endpoint for the Prometheus Metrics Server
+	PushgatewayJob  = "simulator" // Job to push metrics to the Prometheus Pushgateway under
+
+	tipProgressLogFrequency = 10 * time.Second // Frequency to log the progress of clients lagging behind the tip
+
+	// DefaultTipPollMaxInterval is the default cap on the interval between polls of a client lagging behind the tip
+	DefaultTipPollMaxInterval = 5 * time.Second
+	tipPollInitialInterval    = 100 * time.Millisecond // Interval before the first poll of a client lagging behind the tip
 )
 
+// LoaderOptions configures a Loader created by New. The zero value executes every worker/txSequence
+// pair in its own goroutine, confirms the txs of each batch one at a time and aborts on the first
+// failed tx.
+type LoaderOptions struct {
+	// Concurrency, if non-zero, is the size of a pool of goroutines pulling pairs from a shared queue,
+	// so that at most Concurrency pairs are executed at a time. Otherwise, every pair is executed in
+	// its own goroutine.
+	Concurrency int
+	// SynchronizedStart, if true, makes the agents that are executed at once wait until all of them are
+	// ready and then releases them together, and the execution is measured from their release rather
+	// than from the call of Execute, which reduces the variance of short runs. StartTime returns the
+	// start of the execution once Execute has returned.
+	SynchronizedStart bool
+	// ConfirmConcurrency is the number of txs of a batch each worker confirms concurrently, which
+	// requires the workers to be safe for concurrent calls of ConfirmTx. If it is 0 or 1, the txs of a
+	// batch are confirmed one at a time.
+	ConfirmConcurrency int
+	// MaxInflight, if non-zero, makes each worker confirm its txs as it issues them instead of
+	// confirming each batch, so that it has at most MaxInflight unconfirmed txs at a time.
+	MaxInflight int
+	// MaxConfirmWait, if non-zero, makes each worker stop confirming its txs MaxConfirmWait after it
+	// issued its last tx, and the txs it did not confirm in time are reported as unconfirmed.
+	MaxConfirmWait time.Duration
+	// AdaptiveBatch, if enabled, makes each worker adapt the size of its batches, starting at the
+	// batch size, to the confirmation time of its previous batch, and the size of the last batch of
+	// each worker is returned by FinalBatchSizes once the execution completes.
+	AdaptiveBatch txs.AdaptiveBatchPolicy
+	// OnError specifies whether a failed tx aborts the execution or is skipped.
+	OnError txs.ErrorPolicy
+	// VerboseWorkers, if non-nil, makes only the workers for which it returns true log the progress of
+	// each batch at info level, and the other workers log it at debug level.
+	VerboseWorkers func(worker int) bool
+}
+
 // Loader executes a series of worker/tx sequence pairs.
 // Each worker/txSequence pair issues [batchSize] transactions, confirms all
 // of them as accepted, and then moves to the next batch until the txSequence
@@ -40,53 +87,164 @@ type Loader[T txs.THash] struct {
 	txSequences []txs.TxSequence[T]
 	batchSize   uint64
 	metrics     *metrics.Metrics
+	opts        LoaderOptions
+
+	// The size of the last batch of each worker, written by its agent once it completes.
+	finalBatchSizes []uint64
+	// The start of the last execution, written by Execute.
+	startTime time.Time
 }
 
+// New returns a Loader executing each of [clients] with the tx sequence at the same index of
+// [txSequences], configured by [opts].
 func New[T txs.THash](
 	clients []txs.Worker[T],
 	txSequences []txs.TxSequence[T],
 	batchSize uint64,
 	metrics *metrics.Metrics,
+	opts LoaderOptions,
 ) *Loader[T] {
 	return &Loader[T]{
-		clients:     clients,
-		txSequences: txSequences,
-		batchSize:   batchSize,
-		metrics:     metrics,
+		clients:         clients,
+		txSequences:     txSequences,
+		batchSize:       batchSize,
+		metrics:         metrics,
+		opts:            opts,
+		finalBatchSizes: make([]uint64, len(txSequences)),
 	}
 }
 
+// quietLogger is a log.Logger that logs info messages at debug level, so that the progress of
+// workers that do not log verbosely is only shown with --log-level=debug.
+type quietLogger struct {
+	log.Logger
+}
+
+func (l quietLogger) New(ctx ...interface{}) log.Logger {
+	return quietLogger{l.Logger.New(ctx...)}
+}
+
+func (l quietLogger) Info(msg string, ctx ...interface{}) {
+	l.Logger.Debug(msg, ctx...)
+}
+
 func (l *Loader[T]) Execute(ctx context.Context) error {
+	l.startTime = time.Now()
+	var startBarrier *txs.StartBarrier
+	if l.opts.SynchronizedStart {
+		// Only the agents executed at once can be released together. Agents queued behind the
+		// worker pool start as soon as a goroutine of the pool is free.
+		parties := len(l.txSequences)
+		if l.opts.Concurrency > 0 {
+			parties = min(parties, l.opts.Concurrency)
+		}
+		startBarrier = txs.NewStartBarrier(parties)
+	}
+
 	log.Info("Constructing tx agents...", "numAgents", len(l.txSequences))
 	agents := make([]txs.Agent[T], 0, len(l.txSequences))
 	for i := 0; i < len(l.txSequences); i++ {
-		agents = append(agents, txs.NewIssueNAgent(l.txSequences[i], l.clients[i], l.batchSize, l.metrics))
+		logger := log.New("worker", i)
+		if l.opts.VerboseWorkers != nil && !l.opts.VerboseWorkers(i) {
+			logger = quietLogger{logger}
+		}
+		adaptiveBatch := l.opts.AdaptiveBatch
+		if adaptiveBatch.Enabled() {
+			i := i
+			adaptiveBatch.OnBatchSize = func(batchSize uint64) {
+				l.finalBatchSizes[i] = batchSize
+			}
+		}
+		agents = append(agents, txs.NewIssueNAgent(l.txSequences[i], l.clients[i], l.batchSize, l.metrics, txs.IssueNAgentOptions{
+			ConfirmConcurrency: l.opts.ConfirmConcurrency,
+			MaxInflight:        l.opts.MaxInflight,
+			MaxConfirmWait:     l.opts.MaxConfirmWait,
+			AdaptiveBatch:      adaptiveBatch,
+			OnError:            l.opts.OnError,
+			StartBarrier:       startBarrier,
+			Logger:             logger,
+		}))
 	}
 
-	log.Info("Starting tx agents...")
 	eg := errgroup.Group{}
-	for _, agent := range agents {
-		agent := agent
-		eg.Go(func() error {
-			return agent.Execute(ctx)
-		})
+	if l.opts.Concurrency == 0 {
+		log.Info("Starting tx agents...")
+		for _, agent := range agents {
+			agent := agent
+			eg.Go(func() error {
+				return agent.Execute(ctx)
+			})
+		}
+	} else {
+		log.Info("Starting tx agent worker pool...", "concurrency", l.opts.Concurrency)
+		agentQueue := make(chan txs.Agent[T], len(agents))
+		for _, agent := range agents {
+			agentQueue <- agent
+		}
+		close(agentQueue)
+		for i := 0; i < l.opts.Concurrency; i++ {
+			eg.Go(func() error {
+				for agent := range agentQueue {
+					if err := agent.Execute(ctx); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
 	}
 
 	log.Info("Waiting for tx agents...")
-	if err := eg.Wait(); err != nil {
+	err := eg.Wait()
+	if startBarrier != nil {
+		if start := startBarrier.Start(); !start.IsZero() {
+			log.Info("Measuring execution from synchronized start", "delay", start.Sub(l.startTime))
+			l.startTime = start
+		}
+	}
+	if err != nil {
 		return err
 	}
 	log.Info("Tx agents completed successfully.")
+	if l.opts.AdaptiveBatch.Enabled() {
+		log.Info("Adapted batch sizes", "finalBatchSizes", l.finalBatchSizes)
+	}
 	return nil
 }
 
+// StartTime returns the time at which the agents were released if the start is synchronized, and
+// otherwise the time at which Execute was called. It must only be called once Execute has returned.
+func (l *Loader[T]) StartTime() time.Time {
+	return l.startTime
+}
+
+// FinalBatchSizes returns the size of the last batch of each worker if the batch size is adaptive,
+// and nil otherwise. It must only be called once Execute has returned.
+func (l *Loader[T]) FinalBatchSizes() []uint64 {
+	if !l.opts.AdaptiveBatch.Enabled() {
+		return nil
+	}
+	return l.finalBatchSizes
+}
+
 // ConfirmReachedTip finds the max height any client has reached and then ensures every client
 // reaches at least that height.
 //
 // This allows the network to continue to roll forward and creates a synchronization point to ensure
 // that every client in the loader has reached at least the max height observed of any client at
 // the time this function was called.
-func (l *Loader[T]) ConfirmReachedTip(ctx context.Context) error {
+//
+// If [timeout] is non-zero and a client has not reached the max height within [timeout], an error
+// naming every lagging client and how far behind it is will be returned.
+//
+// Lagging clients are polled with exponential backoff and jitter, capped at [maxPollInterval], so
+// that clients are not polled in lockstep. The backoff of a client is reset whenever its height
+// increases. If [maxPollInterval] is zero, DefaultTipPollMaxInterval is used.
+func (l *Loader[T]) ConfirmReachedTip(ctx context.Context, timeout time.Duration, maxPollInterval time.Duration) error {
+	if maxPollInterval == 0 {
+		maxPollInterval = DefaultTipPollMaxInterval
+	}
+
 	maxHeight := uint64(0)
 	for i, client := range l.clients {
 		latestHeight, err := client.LatestHeight(ctx)
@@ -98,33 +256,429 @@ func (l *Loader[T]) ConfirmReachedTip(ctx context.Context) error {
 		}
 	}
 
+	tipCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		tipCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Each goroutine only writes the height of its own client, which is read after they complete.
+	latestHeights := make([]uint64, len(l.clients))
 	eg := errgroup.Group{}
 	for i, client := range l.clients {
 		i := i
 		client := client
 		eg.Go(func() error {
+			lastLogged := time.Now()
+			pollInterval := min(tipPollInitialInterval, maxPollInterval)
 			for {
-				latestHeight, err := client.LatestHeight(ctx)
+				latestHeight, err := client.LatestHeight(tipCtx)
 				if err != nil {
 					return fmt.Errorf("failed to get latest height from client %d: %w", i, err)
 				}
+				if latestHeight > latestHeights[i] {
+					pollInterval = min(tipPollInitialInterval, maxPollInterval)
+				} else {
+					pollInterval = min(2*pollInterval, maxPollInterval)
+				}
+				latestHeights[i] = latestHeight
 				if latestHeight >= maxHeight {
+					log.Debug("Client reached tip", "client", i, "height", latestHeight, "target", maxHeight)
 					return nil
 				}
+				if time.Since(lastLogged) >= tipProgressLogFrequency {
+					log.Info("Waiting for client to reach tip", "client", i, "height", latestHeight, "target", maxHeight)
+					lastLogged = time.Now()
+				}
 				select {
-				case <-ctx.Done():
-					return fmt.Errorf("failed to get latest height from client %d: %w", i, ctx.Err())
-				case <-time.After(time.Second):
+				case <-tipCtx.Done():
+					return fmt.Errorf("failed to get latest height from client %d: %w", i, tipCtx.Err())
+				case <-time.After(jitter(pollInterval)):
 				}
 			}
 		})
 	}
 
-	return eg.Wait()
+	err := eg.Wait()
+	// Only report lagging clients if the tip-sync timeout expired rather than the parent context.
+	if err != nil && ctx.Err() == nil && tipCtx.Err() != nil {
+		lagging := make([]string, 0, len(l.clients))
+		for i, latestHeight := range latestHeights {
+			if latestHeight < maxHeight {
+				lagging = append(lagging, fmt.Sprintf("client %d at height %d (%d behind)", i, latestHeight, maxHeight-latestHeight))
+			}
+		}
+		return fmt.Errorf("clients failed to reach height %d within %s: %s", maxHeight, timeout, strings.Join(lagging, ", "))
+	}
+	return err
+}
+
+// injectLatency wraps [worker] to delay each tx issuance and confirmation by the artificial latency
+// specified by [c], or returns [worker] if no latency is injected.
+func injectLatency[T txs.THash](c config.Config, worker txs.Worker[T]) txs.Worker[T] {
+	minDelay, maxDelay := c.InjectedLatency()
+	if maxDelay == 0 {
+		return worker
+	}
+	return txs.NewLatencyWorker(worker, minDelay, maxDelay)
+}
+
+// jitter returns a random duration in [d/2, d], which spreads out polls that would otherwise
+// be issued at the same time.
+func jitter(d time.Duration) time.Duration {
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// loadOrGenerateKeys loads all keys in [keyDir] and ensures there are at least [numKeys] keys
+// by generating and saving any additional keys. If [passphrase] is non-empty, it decrypts the
+// keystore files in [keyDir] and the generated keys are saved encrypted with it.
+func loadOrGenerateKeys(ctx context.Context, keyDir string, passphrase string, numKeys int) ([]*key.Key, error) {
+	keys, err := key.LoadAll(ctx, keyDir, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; len(keys) < numKeys; i++ {
+		newKey, err := key.Generate()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %d new key: %w", i, err)
+		}
+		if passphrase != "" {
+			err = newKey.SaveEncrypted(keyDir, passphrase)
+		} else {
+			err = newKey.Save(keyDir)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to save %d new key: %w", i, err)
+		}
+		keys = append(keys, newKey)
+	}
+	return keys, nil
+}
+
+// ExecuteFundKeys ensures [config.KeyDir] contains at least [config.NumKeys] keys and that
+// [config.NumKeys] of them hold at least [config.FundingAmount] GWei, so that they can be
+// reused across load tests run with --skip-funding.
+func ExecuteFundKeys(ctx context.Context, config config.Config) error {
+	if err := config.VerifyFundKeys(); err != nil {
+		return err
+	}
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	client, err := ethclient.Dial(config.Endpoints[0])
+	if err != nil {
+		return fmt.Errorf("failed to dial client at %s: %w", config.Endpoints[0], err)
+	}
+
+	keys, err := loadOrGenerateKeys(ctx, config.KeyDir, config.KeyPassphrase, config.NumKeys)
+	if err != nil {
+		return err
+	}
+
+	minFundsPerAddr := new(big.Int).Mul(big.NewInt(params.GWei), new(big.Int).SetUint64(config.FundingAmount))
+	fundStart := time.Now()
+	log.Info("Distributing funds", "numKeys", config.NumKeys, "minFunds", minFundsPerAddr)
+	if _, err := DistributeFunds(ctx, client, keys, config.NumKeys, minFundsPerAddr, metrics.NewDefaultMetrics(), fundingOptions(config)); err != nil {
+		return err
+	}
+	log.Info("Distributed funds successfully", "time", time.Since(fundStart))
+	return nil
+}
+
+// maxPayloadSize returns the maximum size of the calldata payload attached to txs specified by [c].
+func maxPayloadSize(c config.Config) uint64 {
+	if c.MaxPayloadSize == 0 {
+		return c.MinPayloadSize
+	}
+	return c.MaxPayloadSize
+}
+
+// randomPayload returns a calldata payload of random bytes from [rng] with a size in the range specified
+// by [c]. If [c] does not specify a payload size, nil is returned.
+func randomPayload(c config.Config, rng *rand.Rand) ([]byte, error) {
+	size := c.MinPayloadSize
+	if maxSize := maxPayloadSize(c); maxSize > size {
+		size += rng.Uint64() % (maxSize - size + 1)
+	}
+	if size == 0 {
+		return nil, nil
+	}
+	payload := make([]byte, size)
+	if _, err := rng.Read(payload); err != nil {
+		return nil, fmt.Errorf("failed to generate payload of size %d: %w", size, err)
+	}
+	return payload, nil
+}
+
+// logReceiptRoundTrips logs the number of RPC round trips [workers] made to confirm txs by receipt,
+// compared to the one round trip per tx it would take to fetch each receipt individually.
+func logReceiptRoundTrips(workers []*ethereumTxWorker) {
+	var confirmedTxs, roundTrips uint64
+	for _, worker := range workers {
+		workerTxs, workerRoundTrips := worker.ReceiptRoundTrips()
+		confirmedTxs += workerTxs
+		roundTrips += workerRoundTrips
+	}
+	reduction := 0.0
+	if confirmedTxs > 0 {
+		reduction = 1 - float64(roundTrips)/float64(confirmedTxs)
+	}
+	log.Info("Receipt confirmation round trips", "txs", confirmedTxs, "roundTrips", roundTrips, "reduction", reduction)
+}
+
+// workerTxCounts returns the number of txs each worker specified by [c] issues. If TotalTxs is set,
+// it is divided evenly across the workers, with the remainder assigned one tx each to the first workers.
+// Otherwise, each worker issues TxsPerWorker txs. If worker weights are set, the txs of all workers are
+// instead divided in proportion to the weights.
+func workerTxCounts(c config.Config) []uint64 {
+	if weights := c.Weights(); weights != nil {
+		totalTxs := c.TotalTxs
+		if totalTxs == 0 {
+			totalTxs = c.TxsPerWorker * uint64(c.Workers)
+		}
+		return weightedTxCounts(totalTxs, weights)
+	}
+	txCounts := make([]uint64, c.Workers)
+	for i := range txCounts {
+		if c.TotalTxs == 0 {
+			txCounts[i] = c.TxsPerWorker
+			continue
+		}
+		txCounts[i] = c.TotalTxs / uint64(c.Workers)
+		if uint64(i) < c.TotalTxs%uint64(c.Workers) {
+			txCounts[i]++
+		}
+	}
+	return txCounts
+}
+
+// weightedTxCounts divides [totalTxs] in proportion to [weights], of which at least one is positive.
+// Each worker is assigned the txs up to its cumulative share of [totalTxs] rounded down, so the counts
+// sum to [totalTxs] and each count is within one tx of its exact share.
+func weightedTxCounts(totalTxs uint64, weights []uint64) []uint64 {
+	var totalWeight uint64
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	txCounts := make([]uint64, len(weights))
+	var cumulativeWeight, assignedTxs uint64
+	for i, weight := range weights {
+		cumulativeWeight += weight
+		// totalTxs * cumulativeWeight / totalWeight <= totalTxs, so the 128 bit division cannot overflow.
+		hi, lo := bits.Mul64(totalTxs, cumulativeWeight)
+		cumulativeTxs, _ := bits.Div64(hi, lo, totalWeight)
+		txCounts[i] = cumulativeTxs - assignedTxs
+		assignedTxs = cumulativeTxs
+	}
+	return txCounts
+}
+
+// keyTxCounts splits the txs of each worker of [txCounts] across its [keysPerWorker] keys, returning
+// the number of txs of each key, ordered by worker. The first keys of a worker take one more tx if its
+// txs do not divide evenly.
+func keyTxCounts(txCounts []uint64, keysPerWorker int) []uint64 {
+	keyCounts := make([]uint64, 0, len(txCounts)*keysPerWorker)
+	for _, txCount := range txCounts {
+		for i := 0; i < keysPerWorker; i++ {
+			keyCount := txCount / uint64(keysPerWorker)
+			if uint64(i) < txCount%uint64(keysPerWorker) {
+				keyCount++
+			}
+			keyCounts = append(keyCounts, keyCount)
+		}
+	}
+	return keyCounts
+}
+
+// mergeWorkerSequences merges each consecutive [keysPerWorker] sequences of [txSequences], which are
+// the sequences of the keys of a worker, into a single sequence for the worker that takes a tx from
+// each key in turn. Since the sequence of each key tracks its own nonces, the txs of every key of a
+// worker are issued in nonce order.
+func mergeWorkerSequences(txSequences []txs.TxSequence[*types.Transaction], keysPerWorker int) []txs.TxSequence[*types.Transaction] {
+	if keysPerWorker == 1 {
+		return txSequences
+	}
+	merged := make([]txs.TxSequence[*types.Transaction], 0, len(txSequences)/keysPerWorker)
+	for i := 0; i < len(txSequences); i += keysPerWorker {
+		merged = append(merged, txs.MergeSequences(txs.RoundRobinMerge, txSequences[i:i+keysPerWorker]...))
+	}
+	return merged
+}
+
+// generateTxSequences calls Setup on [generator] and then generates a sequence of [txCounts[i]] txs
+// for [keys[i]] with it. If [bufferSize] is non-zero, only [bufferSize] txs of each sequence are generated
+// up front and the rest are generated as the sequence is consumed. Generation stops as soon as [ctx] is cancelled, such as by the SIGINT handler
+// of ExecuteLoader, in which case the returned error wraps ctx.Err().
+func generateTxSequences(ctx context.Context, generator txs.TxGenerator, client ethclient.Client, keys []*ecdsa.PrivateKey, txCounts []uint64, bufferSize uint64) ([]txs.TxSequence[*types.Transaction], error) {
+	if err := generator.Setup(ctx); err != nil {
+		return nil, fmt.Errorf("failed to set up tx generator: %w", err)
+	}
+	txSequences := make([]txs.TxSequence[*types.Transaction], len(keys))
+	for i, key := range keys {
+		txSequence, err := txs.GenerateTxSequenceBuffered(ctx, generator.GenerateTx, client, key, txCounts[i], bufferSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate tx sequence at index %d: %w", i, err)
+		}
+		txSequences[i] = txSequence
+	}
+	return txSequences, nil
+}
+
+// generateTxSequencesUntilDone calls Setup on [generator] and then generates txs for each of [keys] with
+// it until [ctx] is done, buffering up to [bufferSize] txs per key ahead of issuance.
+func generateTxSequencesUntilDone(ctx context.Context, generator txs.TxGenerator, client ethclient.Client, keys []*ecdsa.PrivateKey, bufferSize uint64) ([]txs.TxSequence[*types.Transaction], error) {
+	if err := generator.Setup(ctx); err != nil {
+		return nil, fmt.Errorf("failed to set up tx generator: %w", err)
+	}
+	txSequences := make([]txs.TxSequence[*types.Transaction], len(keys))
+	for i, key := range keys {
+		txSequence, err := txs.GenerateTxSequenceUntilDone(ctx, generator.GenerateTx, client, key, bufferSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate tx sequence at index %d: %w", i, err)
+		}
+		txSequences[i] = txSequence
+	}
+	return txSequences, nil
+}
+
+// warmup issues and confirms [c.WarmupTxs] txs generated by [generator] from the keys of each worker
+// with the corresponding clients in [clients] and [confirmClients], so that connections and caches are warm before the load test.
+// [keys] holds the [c.KeysPerWorker] keys of each worker in order.
+// The warmup txs are recorded in separate metrics, which are discarded.
+//
+// Since the txs of the load test are generated after the warmup txs are confirmed, they start at the
+// nonce following the last warmup tx.
+func warmup(ctx context.Context, c config.Config, clients []ethclient.Client, confirmClients []ethclient.Client, keys []*ecdsa.PrivateKey, generator txs.TxGenerator) error {
+	log.Info("Issuing warmup txs...", "txsPerWorker", c.WarmupTxs)
+	txCounts := make([]uint64, c.Workers)
+	for i := range txCounts {
+		txCounts[i] = c.WarmupTxs
+	}
+	txSequences, err := generateTxSequences(ctx, generator, clients[0], keys, keyTxCounts(txCounts, c.KeysPerWorker), 0)
+	if err != nil {
+		return fmt.Errorf("failed to generate warmup txs: %w", err)
+	}
+	txSequences = mergeWorkerSequences(txSequences, c.KeysPerWorker)
+	workers := make([]txs.Worker[*types.Transaction], 0, len(clients))
+	for i, client := range clients {
+		if c.ConfirmsByReceipt() {
+			workers = append(workers, newEthereumTxWorker(ctx, client, confirmClients[i], common.Address{}))
+		} else {
+			workers = append(workers, newEthereumTxWorker(ctx, client, confirmClients[i], ethcrypto.PubkeyToAddress(keys[i*c.KeysPerWorker].PublicKey)))
+		}
+	}
+	warmupStart := time.Now()
+	opts := LoaderOptions{
+		ConfirmConcurrency: c.ConfirmConcurrency,
+		MaxInflight:        c.MaxInflight,
+		OnError:            errorPolicy(c),
+		VerboseWorkers:     c.IsVerboseWorker,
+	}
+	if err := New(workers, txSequences, c.BatchSize, metrics.NewDefaultMetrics(), opts).Execute(ctx); err != nil {
+		return fmt.Errorf("failed to execute warmup txs: %w", err)
+	}
+	log.Info("Completed warmup", "time", time.Since(warmupStart))
+	return nil
+}
+
+// reportProgress starts logging the progress of the load test recorded in [m] every [c.ReportInterval]
+// if it is set, and returns a function that stops the reports.
+func reportProgress(c config.Config, m *metrics.Metrics) func() {
+	if c.ReportInterval == 0 {
+		return func() {}
+	}
+	return m.Report(c.ReportInterval).Shutdown
+}
+
+// errorPolicy returns the policy specified by [c] for handling txs that fail to issue or confirm.
+func errorPolicy(c config.Config) txs.ErrorPolicy {
+	if c.OnError == config.ContinueOnError {
+		return txs.ContinueOnError
+	}
+	return txs.AbortOnError
+}
+
+// loaderOptions returns the LoaderOptions specified by [c], executing every worker in its own goroutine.
+func loaderOptions(c config.Config) LoaderOptions {
+	return LoaderOptions{
+		SynchronizedStart:  c.SynchronizedStart,
+		ConfirmConcurrency: c.ConfirmConcurrency,
+		MaxInflight:        c.MaxInflight,
+		MaxConfirmWait:     c.MaxConfirmWait,
+		AdaptiveBatch:      adaptiveBatchPolicy(c),
+		OnError:            errorPolicy(c),
+		VerboseWorkers:     c.IsVerboseWorker,
+	}
+}
+
+// fundingOptions returns the FundingOptions specified by [c].
+func fundingOptions(c config.Config) FundingOptions {
+	return FundingOptions{
+		Fanout:      c.FundingFanout,
+		RetryPolicy: fundingRetryPolicy(c),
+	}
+}
+
+// fundingRetryPolicy returns the FundingRetryPolicy specified by [c].
+func fundingRetryPolicy(c config.Config) FundingRetryPolicy {
+	return FundingRetryPolicy{
+		MaxRetries:     c.FundingRetries,
+		InitialBackoff: c.FundingBackoff,
+	}
+}
+
+// checkConfirmChainIDs returns an error unless each confirmation endpoint in [c], served by the first of
+// [confirmClients], serves the same chain as the issuance endpoint served by [client].
+func checkConfirmChainIDs(ctx context.Context, c config.Config, client ethclient.Client, confirmClients []ethclient.Client) error {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch chain ID of %s: %w", c.Endpoints[0], err)
+	}
+	for i, confirmClient := range confirmClients[:min(len(c.ConfirmEndpoints), len(confirmClients))] {
+		confirmChainID, err := confirmClient.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch chain ID of confirmation endpoint %s: %w", c.ConfirmEndpoints[i], err)
+		}
+		if confirmChainID.Cmp(chainID) != 0 {
+			return fmt.Errorf("confirmation endpoint %s serves chain ID %d, but endpoint %s serves chain ID %d",
+				c.ConfirmEndpoints[i], confirmChainID, c.Endpoints[0], chainID)
+		}
+	}
+	return nil
+}
+
+// adaptiveBatchPolicy returns the AdaptiveBatchPolicy specified by [c].
+func adaptiveBatchPolicy(c config.Config) txs.AdaptiveBatchPolicy {
+	return txs.AdaptiveBatchPolicy{
+		TargetLatency: c.BatchLatencyTarget,
+		MinBatchSize:  c.MinBatchSize,
+		MaxBatchSize:  c.MaxBatchSize,
+	}
+}
+
+// newTxSigner returns the TxSigner specified by [c] for transactions on [chainID].
+func newTxSigner(c config.Config, chainID *big.Int) (txs.TxSigner, error) {
+	if c.Signer == config.RemoteSigner {
+		return txs.NewRemoteSigner(c.RemoteSigner, chainID)
+	}
+	return txs.NewLocalSigner(types.LatestSignerForChainID(chainID)), nil
 }
 
 // ExecuteLoader creates txSequences from [config] and has txAgents execute the specified simulation.
 func ExecuteLoader(ctx context.Context, config config.Config) error {
+	_, err := ExecuteLoaderWithResult(ctx, config)
+	return err
+}
+
+// ExecuteLoaderWithResult executes the simulation specified by [config] like ExecuteLoader and returns
+// the LoadResult of the simulation. The result is returned whenever txs were issued, even if the
+// simulation failed, and is nil if it failed before issuing any tx.
+func ExecuteLoaderWithResult(ctx context.Context, config config.Config) (*LoadResult, error) {
 	if config.Timeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
@@ -150,98 +704,602 @@ func ExecuteLoader(ctx context.Context, config config.Config) error {
 	}()
 
 	m := metrics.NewDefaultMetrics()
-	metricsCtx := context.Background()
-	ms := m.Serve(metricsCtx, strconv.Itoa(int(config.MetricsPort)), MetricsEndpoint)
-	defer ms.Shutdown()
-
-	// Construct the arguments for the load simulator
-	clients := make([]ethclient.Client, 0, len(config.Endpoints))
-	for i := 0; i < config.Workers; i++ {
-		clientURI := config.Endpoints[i%len(config.Endpoints)]
-		client, err := ethclient.Dial(clientURI)
+	if config.MetricsEnabled {
+		metricsCtx := context.Background()
+		ms, err := m.Serve(metricsCtx, strconv.Itoa(int(config.MetricsPort)), MetricsEndpoint)
 		if err != nil {
-			return fmt.Errorf("failed to dial client at %s: %w", clientURI, err)
+			return nil, err
 		}
-		clients = append(clients, client)
+		defer ms.Shutdown()
+	}
+	if config.PushgatewayURL != "" {
+		mp := m.Push(config.PushgatewayURL, PushgatewayJob, config.PushInterval)
+		defer mp.Shutdown()
+	}
+
+	if minDelay, maxDelay := config.InjectedLatency(); maxDelay > 0 {
+		log.Warn("Injecting artificial latency into every tx issuance and confirmation for testing", "minDelay", minDelay, "maxDelay", maxDelay)
+	}
+
+	if config.TopologyFile != "" {
+		return executeWarpPairs(ctx, config, m)
 	}
 
-	keys, err := key.LoadAll(ctx, config.KeyDir)
+	clients, confirmClients, err := dialClients(ctx, config)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// Ensure there are at least [config.Workers] keys and save any newly generated ones.
-	if len(keys) < config.Workers {
-		for i := 0; len(keys) < config.Workers; i++ {
-			newKey, err := key.Generate()
-			if err != nil {
-				return fmt.Errorf("failed to generate %d new key: %w", i, err)
+
+	if config.ReplayFile != "" {
+		return executeReplay(ctx, config, clients[0], confirmClients[0], m)
+	}
+
+	if blobTxs(config) {
+		if err := checkBlobSupport(ctx, clients[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	// Each worker issues txs from KeysPerWorker keys of its own.
+	numKeys := config.Workers * config.KeysPerWorker
+	keys, err := loadOrGenerateKeys(ctx, config.KeyDir, config.KeyPassphrase, numKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	txCounts := workerTxCounts(config)
+	keyCounts := keyTxCounts(txCounts, config.KeysPerWorker)
+	logTxDistribution(config, txCounts)
+
+	if userOps(config) {
+		pks := make([]*ecdsa.PrivateKey, 0, config.Workers)
+		for _, key := range keys[:config.Workers] {
+			pks = append(pks, key.PrivKey)
+		}
+		return executeUserOps(ctx, config, clients, pks, txCounts, m)
+	}
+
+	if config.SkipFunding {
+		log.Info("Skipping fund distribution", "numKeys", numKeys)
+		keys = keys[:numKeys]
+	} else {
+		var funder common.Address
+		keys, funder, err = fundKeys(ctx, config, clients[0], keys, slices.Max(keyCounts), m)
+		if err != nil {
+			return nil, err
+		}
+		if config.ReclaimFunds {
+			workerKeys := keys
+			defer func() {
+				// Reclaim funds regardless of execution error, since the worker keys are still funded.
+				if _, err := ReclaimFunds(context.Background(), clients[0], workerKeys, funder); err != nil {
+					log.Warn("Failed to reclaim funds", "error", err)
+				}
+			}()
+		}
+	}
+
+	senders, err := newLoadSenders(config, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Seed == 0 {
+		config.Seed = rand.Int63n(math.MaxInt64) + 1
+	}
+	log.Info("Creating transaction sequences...", "seed", config.Seed)
+	txGenerator := newTransferTxGenerator(config, clients[0], senders.feeTiers, senders.txTypes)
+	if config.WarmupTxs > 0 && config.Resume {
+		log.Info("Skipping warmup txs when resuming from a checkpoint", "checkpointFile", config.CheckpointFile)
+	} else if config.WarmupTxs > 0 {
+		if err := warmup(ctx, config, clients, confirmClients, senders.pks, txGenerator); err != nil {
+			return nil, err
+		}
+	}
+	checkpoint, err := openCheckpoint(ctx, config, clients[0], senders.workers, txCounts)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		generator     txs.TxGenerator = txGenerator
+		rawTxs        *txs.RawTxStore
+		presignBuffer uint64
+	)
+	if config.Presign {
+		rawTxs = txs.NewRawTxStore()
+		generator = txs.Presign(txGenerator, rawTxs)
+		presignBuffer = config.PresignBuffer
+	}
+	generateCtx := ctx
+	if config.Duration > 0 {
+		// Only generation is bounded by the duration, so that the agents drain the txs issued before
+		// it elapses.
+		var cancel context.CancelFunc
+		generateCtx, cancel = context.WithTimeout(ctx, config.Duration)
+		defer cancel()
+	}
+	txSequenceStart := time.Now()
+	txSequences, err := generateLoadTxSequences(generateCtx, config, generator, clients[0], senders.pks, keyCounts, presignBuffer, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	presignDuration := time.Since(txSequenceStart)
+	log.Info("Created transaction sequences successfully", "time", presignDuration)
+	if rawTxs != nil {
+		// Txs pre-signed in the background once the load test starts are not included.
+		numTxs, numBytes := rawTxs.Stats()
+		log.Info("Pre-signed transactions", "txs", numTxs, "bytes", numBytes, "time", presignDuration)
+	}
+
+	var recorder *txs.TxRecorder
+	if config.TxRecordFile != "" {
+		recordFile, err := os.Create(config.TxRecordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tx record file %s: %w", config.TxRecordFile, err)
+		}
+		defer recordFile.Close()
+		recorder = txs.NewTxRecorder(recordFile)
+	}
+
+	var checkpoints *checkpointer
+	if checkpoint != nil {
+		checkpoints = newCheckpointer(config.CheckpointFile, checkpoint)
+		if err := checkpoints.save(); err != nil {
+			return nil, err
+		}
+		checkpointCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go checkpoints.run(checkpointCtx, config.CheckpointInterval)
+		defer func() {
+			// Save the final progress regardless of execution error, so that a failed run can be resumed.
+			if err := checkpoints.save(); err != nil {
+				log.Warn("Failed to save checkpoint", "error", err)
 			}
-			if err := newKey.Save(config.KeyDir); err != nil {
-				return fmt.Errorf("failed to save %d new key: %w", i, err)
+		}()
+	}
+
+	// Workers share the limiter of each endpoint, whether they issue or confirm txs on it.
+	endpointLimiters := newEndpointLimiters(config.EndpointLimit, m, config.Endpoints, config.ConfirmEndpoints)
+	workers, receiptWorkers := newLoadWorkers(ctx, config, clients, confirmClients, senders, workerDecorations{
+		rawTxs:           rawTxs,
+		endpointLimiters: endpointLimiters,
+		recorder:         recorder,
+		checkpoints:      checkpoints,
+		txSigner:         txGenerator.txSigner,
+	}, m)
+	opts := loaderOptions(config)
+	if config.WorkerPool {
+		opts.Concurrency = config.Concurrency
+		if opts.Concurrency == 0 {
+			opts.Concurrency = runtime.GOMAXPROCS(0)
+		}
+	}
+	workers, resultWorkers := trackResults(workers)
+	loader := New(workers, txSequences, config.BatchSize, m, opts)
+	blocks, err := newBlockStatsCollector(ctx, config, confirmClients[0])
+	if err != nil {
+		return nil, err
+	}
+	stopReports := reportProgress(config, m)
+	err = loader.Execute(ctx)
+	stopReports()
+	executeDuration := time.Since(loader.StartTime())
+	if err == nil {
+		logLoadSummary(config, senders, resultWorkers, receiptWorkers, endpointLimiters, executeDuration, m)
+	}
+	result := reportLoadResult(ctx, config, loadReport{
+		executeDuration: executeDuration,
+		resultWorkers:   resultWorkers,
+		finalBatchSizes: loader.FinalBatchSizes(),
+		blocks:          blocks,
+		senders:         senders,
+		presigned:       rawTxs != nil,
+		presignDuration: presignDuration,
+	}, m)
+	return result, err
+}
+
+// dialClients dials the client of each of [c.Workers] workers, and the client confirming its txs if
+// confirmation endpoints are specified, and waits for them to be ready if [c.ReadinessTimeout] is set.
+// Txs are confirmed on the endpoint they are issued to unless confirmation endpoints are specified,
+// in which case the confirmation clients are checked to be on the chain of the clients.
+func dialClients(ctx context.Context, c config.Config) ([]ethclient.Client, []ethclient.Client, error) {
+	clients := make([]ethclient.Client, 0, len(c.Endpoints))
+	for i := 0; i < c.Workers; i++ {
+		clientURI := c.Endpoints[i%len(c.Endpoints)]
+		client, err := ethclient.Dial(clientURI)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to dial client at %s: %w", clientURI, err)
+		}
+		clients = append(clients, client)
+	}
+	confirmClients := clients
+	if len(c.ConfirmEndpoints) > 0 {
+		confirmClients = make([]ethclient.Client, 0, c.Workers)
+		for i := 0; i < c.Workers; i++ {
+			confirmURI := c.ConfirmEndpoints[i%len(c.ConfirmEndpoints)]
+			confirmClient, err := ethclient.Dial(confirmURI)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to dial confirmation client at %s: %w", confirmURI, err)
 			}
-			keys = append(keys, newKey)
+			confirmClients = append(confirmClients, confirmClient)
+		}
+	}
+
+	if c.ReadinessTimeout > 0 {
+		log.Info("Waiting for endpoints to be ready", "timeout", c.ReadinessTimeout)
+		readyClients := clients
+		if len(c.ConfirmEndpoints) > 0 {
+			readyClients = append(slices.Clip(clients), confirmClients...)
+		}
+		if err := AwaitReady(ctx, readyClients, c.HealthEndpoints, c.ReadinessTimeout); err != nil {
+			return nil, nil, err
 		}
 	}
 
-	// Each address needs: params.GWei * MaxFeeCap * params.TxGas * TxsPerWorker total wei
+	if len(c.ConfirmEndpoints) > 0 {
+		if err := checkConfirmChainIDs(ctx, c, clients[0], confirmClients); err != nil {
+			return nil, nil, err
+		}
+	}
+	return clients, confirmClients, nil
+}
+
+// logTxDistribution logs how the txs of the load test are distributed across workers, if [c] does
+// not issue the same number of txs from every worker.
+func logTxDistribution(c config.Config, txCounts []uint64) {
+	if weights := c.Weights(); weights != nil {
+		var totalTxs uint64
+		for _, txCount := range txCounts {
+			totalTxs += txCount
+		}
+		log.Info("Distributing txs across workers by weight", "totalTxs", totalTxs, "workers", c.Workers, "maxTxsPerWorker", slices.Max(txCounts))
+		for i, txCount := range txCounts {
+			log.Info("Weighted worker", "worker", i, "weight", weights[i], "txs", txCount)
+		}
+	} else if c.TotalTxs != 0 {
+		log.Info("Distributing total txs across workers", "totalTxs", c.TotalTxs, "workers", c.Workers,
+			"txsPerWorker", txCounts[len(txCounts)-1], "workersWithExtraTx", c.TotalTxs%uint64(c.Workers))
+	}
+}
+
+// minFundsPerKey returns the balance each key needs to pay the fees of [maxTxsPerKey] txs and the
+// warmup txs specified by [c], or the funding amount of [c] if the number of txs is bounded by a duration.
+func minFundsPerKey(c config.Config, maxTxsPerKey uint64) *big.Int {
+	if c.Duration > 0 {
+		// The number of txs issued for a duration is unknown, so fund the configured amount instead.
+		return new(big.Int).Mul(big.NewInt(params.GWei), new(big.Int).SetUint64(c.FundingAmount))
+	}
+	// Each address needs: params.GWei * MaxFeeCap * maxTxGas * maxTxsPerKey total wei
 	// to fund gas for all of their transactions.
-	maxFeeCap := new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(config.MaxFeeCap))
-	minFundsPerAddr := new(big.Int).Mul(maxFeeCap, big.NewInt(int64(config.TxsPerWorker*params.TxGas)))
+	maxTxGas := c.TxGasLimit(maxPayloadSize(c))
+	maxFeeCap := new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(c.MaxFeeCap))
+	maxTxFee := new(big.Int).Mul(maxFeeCap, new(big.Int).SetUint64(maxTxGas))
+	if blobTxs(c) {
+		// Blob txs additionally pay up to MaxBlobFeeCap for each unit of blob gas.
+		maxBlobFeeCap := new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(c.MaxBlobFeeCap))
+		maxTxFee.Add(maxTxFee, new(big.Int).Mul(maxBlobFeeCap, new(big.Int).SetUint64(blobTxBlobGas(c))))
+	}
+	return new(big.Int).Mul(maxTxFee, new(big.Int).SetUint64(maxTxsPerKey+c.WarmupTxs))
+}
+
+// fundKeys distributes funds to the first [c.Workers]*[c.KeysPerWorker] of [keys] on [client] so that
+// each can issue [maxTxsPerKey] txs, and returns the funded keys along with the address the funds were
+// distributed from.
+func fundKeys(ctx context.Context, c config.Config, client ethclient.Client, keys []*key.Key, maxTxsPerKey uint64, m *metrics.Metrics) ([]*key.Key, common.Address, error) {
+	numKeys := c.Workers * c.KeysPerWorker
+	minFundsPerAddr := minFundsPerKey(c, maxTxsPerKey)
 	fundStart := time.Now()
-	log.Info("Distributing funds", "numTxsPerWorker", config.TxsPerWorker, "minFunds", minFundsPerAddr)
-	keys, err = DistributeFunds(ctx, clients[0], keys, config.Workers, minFundsPerAddr, m)
+	log.Info("Distributing funds", "numKeys", numKeys, "numTxsPerKey", maxTxsPerKey, "minFunds", minFundsPerAddr)
+	keys, funder, err := DistributeFundsFrom(ctx, client, keys, numKeys, minFundsPerAddr, m, fundingOptions(c))
 	if err != nil {
-		return err
+		return nil, common.Address{}, err
 	}
 	log.Info("Distributed funds successfully", "time", time.Since(fundStart))
+	return keys, funder, nil
+}
 
-	pks := make([]*ecdsa.PrivateKey, 0, len(keys))
-	senders := make([]common.Address, 0, len(keys))
+// loadSenders holds the keys the txs of a load test are issued from and what each of them issues.
+type loadSenders struct {
+	// The private key and address of each key, holding the KeysPerWorker keys of each worker in order.
+	pks     []*ecdsa.PrivateKey
+	senders []common.Address
+	// The address of the first key of each worker, which identifies the worker.
+	workers []common.Address
+	// The fee tier and tx type issued by each address.
+	feeTiers map[common.Address]feeTier
+	txTypes  map[common.Address]string
+}
+
+// newLoadSenders returns the loadSenders of [keys], assigning the fee tier and tx type specified by [c]
+// to each worker and to every key of the worker.
+func newLoadSenders(c config.Config, keys []*key.Key) (*loadSenders, error) {
+	s := &loadSenders{
+		pks:     make([]*ecdsa.PrivateKey, 0, len(keys)),
+		senders: make([]common.Address, 0, len(keys)),
+		workers: make([]common.Address, 0, c.Workers),
+	}
 	for _, key := range keys {
-		pks = append(pks, key.PrivKey)
-		senders = append(senders, key.Address)
+		s.pks = append(s.pks, key.PrivKey)
+		s.senders = append(s.senders, key.Address)
 	}
-
-	bigGwei := big.NewInt(params.GWei)
-	gasTipCap := new(big.Int).Mul(bigGwei, big.NewInt(config.MaxTipCap))
-	gasFeeCap := new(big.Int).Mul(bigGwei, big.NewInt(config.MaxFeeCap))
-	client := clients[0]
-	chainID, err := client.ChainID(ctx)
+	for i := 0; i < len(s.senders); i += c.KeysPerWorker {
+		s.workers = append(s.workers, s.senders[i])
+	}
+	s.feeTiers = assignFeeTiers(feeTiers(c), s.workers)
+	txTypes, err := assignTxTypes(c, s.workers)
 	if err != nil {
-		return fmt.Errorf("failed to fetch chainID: %w", err)
-	}
-	signer := types.LatestSignerForChainID(chainID)
-
-	log.Info("Creating transaction sequences...")
-	txGenerator := func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
-		addr := ethcrypto.PubkeyToAddress(key.PublicKey)
-		return types.SignNewTx(key, signer, &types.DynamicFeeTx{
-			ChainID:   chainID,
-			Nonce:     nonce,
-			GasTipCap: gasTipCap,
-			GasFeeCap: gasFeeCap,
-			Gas:       params.TxGas,
-			To:        &addr,
-			Data:      nil,
-			Value:     common.Big0,
-		})
+		return nil, err
+	}
+	s.txTypes = txTypes
+	if c.KeysPerWorker > 1 {
+		// Every key of a worker issues the fee tier and tx type of the worker, since both are recorded by worker.
+		for i, sender := range s.senders {
+			workerSender := s.workers[i/c.KeysPerWorker]
+			s.feeTiers[sender] = s.feeTiers[workerSender]
+			s.txTypes[sender] = s.txTypes[workerSender]
+		}
+		log.Info("Rotating each worker across its keys", "workers", c.Workers, "keysPerWorker", c.KeysPerWorker, "senders", len(s.senders))
+	}
+	return s, nil
+}
+
+// openCheckpoint returns the checkpoint the load test specified by [c] resumes from or starts, or nil if
+// it is not checkpointed.
+func openCheckpoint(ctx context.Context, c config.Config, client ethclient.Client, workerSenders []common.Address, txCounts []uint64) (*Checkpoint, error) {
+	switch {
+	case c.Resume:
+		checkpoint, err := resumeCheckpoint(ctx, c.CheckpointFile, client, workerSenders)
+		if err != nil {
+			return nil, err
+		}
+		var remainingTxs uint64
+		for _, w := range checkpoint.Workers {
+			remainingTxs += w.RemainingTxs()
+		}
+		log.Info("Resuming from checkpoint", "checkpointFile", c.CheckpointFile, "remainingTxs", remainingTxs)
+		return checkpoint, nil
+	case c.CheckpointFile != "":
+		return newCheckpoint(ctx, client, workerSenders, txCounts)
+	default:
+		return nil, nil
+	}
+}
+
+// generateLoadTxSequences generates the tx sequence of each worker from the keys of each worker in [pks],
+// starting at the checkpointed nonces if [checkpoint] is non-nil, until [ctx] is done if [c] specifies a
+// duration, and otherwise with the number of txs at the same index of [keyCounts].
+func generateLoadTxSequences(ctx context.Context, c config.Config, generator txs.TxGenerator, client ethclient.Client, pks []*ecdsa.PrivateKey, keyCounts []uint64, presignBuffer uint64, checkpoint *Checkpoint) ([]txs.TxSequence[*types.Transaction], error) {
+	var (
+		txSequences []txs.TxSequence[*types.Transaction]
+		err         error
+	)
+	switch {
+	case checkpoint != nil:
+		// The sequences start at the checkpointed nonces rather than the current nonces on chain.
+		txSequences, err = generateCheckpointTxSequences(ctx, generator, pks, checkpoint)
+	case c.Duration > 0:
+		log.Info("Issuing txs for duration", "duration", c.Duration)
+		txSequences, err = generateTxSequencesUntilDone(ctx, generator, client, pks, c.BatchSize)
+	default:
+		txSequences, err = generateTxSequences(ctx, generator, client, pks, keyCounts, presignBuffer)
 	}
-	txSequenceStart := time.Now()
-	txSequences, err := txs.GenerateTxSequences(ctx, txGenerator, clients[0], pks, config.TxsPerWorker, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	log.Info("Created transaction sequences successfully", "time", time.Since(txSequenceStart))
+	return mergeWorkerSequences(txSequences, c.KeysPerWorker), nil
+}
 
+// workerDecorations holds the state shared by the decorators of the workers of a load test.
+// Each field may be nil if the decorator using it is disabled.
+type workerDecorations struct {
+	rawTxs           *txs.RawTxStore
+	endpointLimiters map[string]*endpointLimiter
+	recorder         *txs.TxRecorder
+	checkpoints      *checkpointer
+	txSigner         txs.TxSigner
+}
+
+// newLoadWorkers returns the worker issuing txs to each of [clients] and confirming them on the client at
+// the same index of [confirmClients], decorated as specified by [c], along with the base workers confirming
+// txs by receipt if [c] confirms by receipt.
+func newLoadWorkers(ctx context.Context, c config.Config, clients []ethclient.Client, confirmClients []ethclient.Client, senders *loadSenders, d workerDecorations, m *metrics.Metrics) ([]txs.Worker[*types.Transaction], []*ethereumTxWorker) {
 	workers := make([]txs.Worker[*types.Transaction], 0, len(clients))
+	receiptWorkers := make([]*ethereumTxWorker, 0, len(clients))
 	for i, client := range clients {
-		workers = append(workers, NewSingleAddressTxWorker(ctx, client, ethcrypto.PubkeyToAddress(pks[i].PublicKey)))
+		var worker txs.Worker[*types.Transaction]
+		confirmClient := confirmClients[i]
+		workerSender := senders.workers[i]
+		address := workerSender
+		if c.ConfirmsByReceipt() {
+			address = common.Address{}
+		}
+		baseWorker := newEthereumTxWorker(ctx, client, confirmClient, address)
+		baseWorker.rawTxs = d.rawTxs
+		if d.endpointLimiters != nil {
+			endpoint := c.Endpoints[i%len(c.Endpoints)]
+			baseWorker.issueLimiter = d.endpointLimiters[endpoint]
+			baseWorker.confirmLimiter = d.endpointLimiters[endpoint]
+			if len(c.ConfirmEndpoints) > 0 {
+				baseWorker.confirmLimiter = d.endpointLimiters[c.ConfirmEndpoints[i%len(c.ConfirmEndpoints)]]
+			}
+		}
+		if c.ConfirmsByReceipt() {
+			receiptWorkers = append(receiptWorkers, baseWorker)
+		}
+		worker = baseWorker
+		worker = injectLatency(c, worker)
+		if c.Confirmations > 0 {
+			worker = newConfirmationDepthWorker(worker, confirmClient, c.Confirmations, m)
+		}
+		if c.ConfirmSampleRate > 0 && c.ConfirmSampleRate < 1 {
+			// Sample the txs of the base worker, so that it forgets the unsampled txs as soon as they are
+			// issued and the workers wrapping it see them fail to confirm.
+			worker = newConfirmSampleWorker(worker, c.ConfirmSampleRate, c.Seed+int64(i))
+		}
+		worker = newMempoolWorker(worker, m)
+		if d.recorder != nil {
+			worker = txs.NewRecordingWorker(worker, d.recorder)
+		}
+		if isBlobTxType(senders.txTypes[workerSender]) {
+			worker = newBlobMetricsWorker(worker, m)
+		}
+		if c.TxMix != "" {
+			worker = newTxTypeWorker(worker, senders.txTypes[workerSender], m)
+		}
+		if c.FeeTiers > 1 {
+			worker = newFeeTierWorker(worker, senders.feeTiers[workerSender], m)
+		}
+		if c.TxCostMetrics {
+			worker = newTxCostWorker(worker, confirmClient, m)
+		}
+		if c.FeeBumpRetries > 0 {
+			worker = newFeeBumpWorker(worker, senders.pks[i], d.txSigner, c.FeeBumpPercent, c.FeeBumpRetries, m)
+		}
+		if d.checkpoints != nil {
+			worker = newCheckpointWorker(worker, i, d.checkpoints)
+		}
+		workers = append(workers, worker)
+	}
+	return workers, receiptWorkers
+}
+
+// logLoadSummary logs the TPS breakdown, receipt round trips, endpoint limits, tx costs and blob throughput
+// of a load test that completed successfully in [executeDuration].
+func logLoadSummary(c config.Config, senders *loadSenders, resultWorkers []*resultWorker[*types.Transaction], receiptWorkers []*ethereumTxWorker, endpointLimiters map[string]*endpointLimiter, executeDuration time.Duration, m *metrics.Metrics) {
+	if err := m.LogTPSBreakdown(); err != nil {
+		log.Warn("Failed to log TPS breakdown", "error", err)
+	}
+	if len(receiptWorkers) > 0 {
+		logReceiptRoundTrips(receiptWorkers)
+	}
+	logEndpointLimits(endpointLimiters)
+	if err := m.LogTxCosts(); err != nil {
+		log.Warn("Failed to log tx costs", "error", err)
+	}
+	if blobTxs(c) {
+		var numTxs uint64
+		for i, w := range resultWorkers {
+			if isBlobTxType(senders.txTypes[senders.workers[i]]) {
+				numTxs += w.result.ConfirmedTxs
+			}
+		}
+		numBlobs := numTxs * uint64(c.BlobsPerTx)
+		blobsPerSecond := float64(numBlobs) / executeDuration.Seconds()
+		m.BlobsPerSecond.Set(blobsPerSecond)
+		log.Info("Blob throughput", "blobs", numBlobs, "blobsPerSecond", blobsPerSecond)
+	}
+}
+
+// loadReport holds the outcome of the execution of a load test reported by reportLoadResult.
+type loadReport struct {
+	executeDuration time.Duration
+	resultWorkers   []*resultWorker[*types.Transaction]
+	finalBatchSizes []uint64
+	blocks          *blockStatsCollector
+	senders         *loadSenders
+	presigned       bool
+	presignDuration time.Duration
+}
+
+// reportLoadResult logs the failed txs, collects the block stats, prints the metrics and returns the
+// LoadResult of the load test reported by [r], regardless of whether its execution failed. It returns nil
+// if the result cannot be computed.
+func reportLoadResult(ctx context.Context, c config.Config, r loadReport, m *metrics.Metrics) *LoadResult {
+	if err := m.LogFailures(); err != nil {
+		log.Warn("Failed to log failed txs", "error", err)
+	}
+	blockStats, err := r.blocks.collect(ctx, m)
+	if err != nil {
+		log.Warn("Failed to collect block stats", "error", err)
+	}
+	if err := m.Print(c.MetricsOutput); err != nil {
+		log.Warn("Failed to print metrics", "error", err)
+	}
+	result, err := newLoadResult(r.executeDuration, r.resultWorkers, r.finalBatchSizes, m)
+	if err != nil {
+		log.Warn("Failed to compute load result", "error", err)
+	}
+	if result == nil {
+		return nil
+	}
+	result.Blocks = blockStats
+	result.Senders = len(r.senders.senders)
+	if r.presigned {
+		result.PresignDuration = r.presignDuration
+	}
+	if c.ConfirmSampleRate > 0 && c.ConfirmSampleRate < 1 {
+		result.sampled(c.ConfirmSampleRate)
+		log.Info("Confirmed a sample of the issued txs", "confirmSampleRate", c.ConfirmSampleRate,
+			"sampledTxs", result.ConfirmedTxs+result.ConfirmationFailures+result.UnconfirmedTxs,
+			"unsampledTxs", result.UnsampledTxs, "estimatedConfirmedTxs", result.EstimatedConfirmedTxs, "estimatedTPS", result.TPS)
+	}
+	if c.TxMix != "" {
+		workerTxTypes := make([]string, 0, len(r.senders.workers))
+		for _, sender := range r.senders.workers {
+			workerTxTypes = append(workerTxTypes, r.senders.txTypes[sender])
+		}
+		if err := addTxTypeResults(result, workerTxTypes, m); err != nil {
+			log.Warn("Failed to compute tx type results", "error", err)
+		}
+	}
+	if c.Duration > 0 {
+		log.Info("Completed duration load test", "duration", c.Duration, "totalTime", r.executeDuration,
+			"txs", result.ConfirmedTxs, "averageTPS", result.TPS, "sampled", result.ConfirmSampleRate > 0)
 	}
-	loader := New(workers, txSequences, config.BatchSize, m)
+	return result
+}
+
+// executeReplay issues the signed txs recorded in [c.ReplayFile] in order to [client].
+// Since the recorded txs may be sent from any number of addresses, each tx is confirmed by its receipt
+// on [confirmClient].
+func executeReplay(ctx context.Context, c config.Config, client ethclient.Client, confirmClient ethclient.Client, m *metrics.Metrics) (*LoadResult, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chain ID: %w", err)
+	}
+	sequence, err := txs.NewReplayTxSequence(ctx, c.ReplayFile, chainID)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Replaying txs", "file", c.ReplayFile, "txs", sequence.Len(), "chainID", chainID)
+
+	worker := injectLatency(c, txs.Worker[*types.Transaction](newEthereumTxWorker(ctx, client, confirmClient, common.Address{})))
+	workers, resultWorkers := trackResults([]txs.Worker[*types.Transaction]{newMempoolWorker(worker, m)})
+	txSequences := []txs.TxSequence[*types.Transaction]{sequence}
+	loader := New(workers, txSequences, c.BatchSize, m, loaderOptions(c))
+	blocks, err := newBlockStatsCollector(ctx, c, confirmClient)
+	if err != nil {
+		return nil, err
+	}
+	stopReports := reportProgress(c, m)
 	err = loader.Execute(ctx)
-	prerr := m.Print(config.MetricsOutput) // Print regardless of execution error
-	if prerr != nil {
+	stopReports()
+	executeDuration := time.Since(loader.StartTime())
+	if err == nil {
+		err = sequence.Err()
+	}
+	if err == nil {
+		if lerr := m.LogTPSBreakdown(); lerr != nil {
+			log.Warn("Failed to log TPS breakdown", "error", lerr)
+		}
+	}
+	if lerr := m.LogFailures(); lerr != nil {
+		log.Warn("Failed to log failed txs", "error", lerr)
+	}
+	blockStats, berr := blocks.collect(ctx, m) // Collect regardless of execution error
+	if berr != nil {
+		log.Warn("Failed to collect block stats", "error", berr)
+	}
+	if prerr := m.Print(c.MetricsOutput); prerr != nil { // Print regardless of execution error
 		log.Warn("Failed to print metrics", "error", prerr)
 	}
-	return err
+	result, rerr := newLoadResult(executeDuration, resultWorkers, loader.FinalBatchSizes(), m)
+	if rerr != nil {
+		log.Warn("Failed to compute load result", "error", rerr)
+	}
+	if result != nil {
+		result.Blocks = blockStats
+	}
+	return result, err
 }
@@ -0,0 +1,213 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerTxCounts(t *testing.T) {
+	tests := map[string]struct {
+		config config.Config
+		want   []uint64
+	}{
+		"txs per worker": {
+			config: config.Config{Workers: 3, TxsPerWorker: 5},
+			want:   []uint64{5, 5, 5},
+		},
+		"total txs divide evenly": {
+			config: config.Config{Workers: 3, TotalTxs: 9},
+			want:   []uint64{3, 3, 3},
+		},
+		"total txs remainder goes to first workers": {
+			config: config.Config{Workers: 4, TotalTxs: 10},
+			want:   []uint64{3, 3, 2, 2},
+		},
+		"fewer total txs than workers": {
+			config: config.Config{Workers: 4, TotalTxs: 2},
+			want:   []uint64{1, 1, 0, 0},
+		},
+		"total txs take precedence over txs per worker": {
+			config: config.Config{Workers: 2, TxsPerWorker: 100, TotalTxs: 3},
+			want:   []uint64{2, 1},
+		},
+		"weighted total txs": {
+			config: config.Config{Workers: 4, TotalTxs: 13, WorkerWeights: "10,1,1,1"},
+			want:   []uint64{10, 1, 1, 1},
+		},
+		"weighted txs per worker": {
+			config: config.Config{Workers: 4, TxsPerWorker: 10, WorkerWeights: "2,1,1,0"},
+			want:   []uint64{20, 10, 10, 0},
+		},
+		"single non-zero weight": {
+			config: config.Config{Workers: 3, TotalTxs: 7, WorkerWeights: "0,5,0"},
+			want:   []uint64{0, 7, 0},
+		},
+		"all-zero weights are ignored": {
+			config: config.Config{Workers: 3, TotalTxs: 7, WorkerWeights: "0,0,0"},
+			want:   []uint64{3, 2, 2},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.want, workerTxCounts(test.config))
+		})
+	}
+}
+
+func TestWeightedTxCounts(t *testing.T) {
+	tests := map[string]struct {
+		totalTxs uint64
+		weights  []uint64
+		want     []uint64
+	}{
+		"equal weights": {
+			totalTxs: 9,
+			weights:  []uint64{1, 1, 1},
+			want:     []uint64{3, 3, 3},
+		},
+		"equal weights with remainder": {
+			totalTxs: 10,
+			weights:  []uint64{1, 1, 1},
+			want:     []uint64{3, 3, 4},
+		},
+		"proportional weights": {
+			totalTxs: 100,
+			weights:  []uint64{3, 1},
+			want:     []uint64{75, 25},
+		},
+		"uneven shares": {
+			totalTxs: 10,
+			weights:  []uint64{1, 1, 1, 1, 1, 1},
+			want:     []uint64{1, 2, 2, 1, 2, 2},
+		},
+		"single non-zero weight": {
+			totalTxs: 7,
+			weights:  []uint64{0, 0, 4},
+			want:     []uint64{0, 0, 7},
+		},
+		"zero txs": {
+			totalTxs: 0,
+			weights:  []uint64{1, 2},
+			want:     []uint64{0, 0},
+		},
+		"fewer txs than workers": {
+			totalTxs: 2,
+			weights:  []uint64{1, 1, 1, 1},
+			want:     []uint64{0, 1, 0, 1},
+		},
+		"product overflows 64 bits": {
+			totalTxs: math.MaxUint64,
+			weights:  []uint64{math.MaxUint64 / 2, math.MaxUint64 / 2},
+			want:     []uint64{math.MaxUint64 / 2, math.MaxUint64/2 + 1},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			txCounts := weightedTxCounts(test.totalTxs, test.weights)
+			require.Equal(t, test.want, txCounts)
+
+			var sum uint64
+			for _, txCount := range txCounts {
+				sum += txCount
+			}
+			require.Equal(t, test.totalTxs, sum)
+		})
+	}
+}
+
+func TestKeyTxCounts(t *testing.T) {
+	tests := map[string]struct {
+		txCounts      []uint64
+		keysPerWorker int
+		want          []uint64
+	}{
+		"single key per worker": {
+			txCounts:      []uint64{5, 3},
+			keysPerWorker: 1,
+			want:          []uint64{5, 3},
+		},
+		"keys divide worker txs": {
+			txCounts:      []uint64{6, 4},
+			keysPerWorker: 2,
+			want:          []uint64{3, 3, 2, 2},
+		},
+		"keys do not divide worker txs": {
+			txCounts:      []uint64{7, 5},
+			keysPerWorker: 3,
+			want:          []uint64{3, 2, 2, 2, 2, 1},
+		},
+		"fewer worker txs than keys": {
+			txCounts:      []uint64{1, 0},
+			keysPerWorker: 3,
+			want:          []uint64{1, 0, 0, 0, 0, 0},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.want, keyTxCounts(test.txCounts, test.keysPerWorker))
+		})
+	}
+}
+
+// newNonceSequence returns a closed sequence of txs with [nonces].
+func newNonceSequence(nonces ...uint64) txs.TxSequence[*types.Transaction] {
+	sequence := make([]*types.Transaction, 0, len(nonces))
+	for _, nonce := range nonces {
+		sequence = append(sequence, types.NewTx(&types.LegacyTx{Nonce: nonce}))
+	}
+	return txs.ConvertTxSliceToSequence(sequence)
+}
+
+func collectNonces(sequence txs.TxSequence[*types.Transaction]) []uint64 {
+	var nonces []uint64
+	for tx := range sequence.Chan() {
+		nonces = append(nonces, tx.Nonce())
+	}
+	return nonces
+}
+
+func TestMergeWorkerSequences(t *testing.T) {
+	tests := map[string]struct {
+		keyNonces     [][]uint64
+		keysPerWorker int
+		want          [][]uint64
+	}{
+		"single key per worker": {
+			keyNonces:     [][]uint64{{0, 1}, {10, 11, 12}},
+			keysPerWorker: 1,
+			want:          [][]uint64{{0, 1}, {10, 11, 12}},
+		},
+		"keys merged round robin": {
+			keyNonces:     [][]uint64{{0, 1}, {10, 11}, {20, 21}, {30, 31}},
+			keysPerWorker: 2,
+			want:          [][]uint64{{0, 10, 1, 11}, {20, 30, 21, 31}},
+		},
+		"keys do not divide worker txs": {
+			// The key counts of two workers of 7 and 5 txs across 3 keys each.
+			keyNonces:     [][]uint64{{0, 1, 2}, {10, 11}, {20, 21}, {30, 31}, {40, 41}, {50}},
+			keysPerWorker: 3,
+			want:          [][]uint64{{0, 10, 20, 1, 11, 21, 2}, {30, 40, 50, 31, 41}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sequences := make([]txs.TxSequence[*types.Transaction], 0, len(test.keyNonces))
+			for _, nonces := range test.keyNonces {
+				sequences = append(sequences, newNonceSequence(nonces...))
+			}
+			merged := mergeWorkerSequences(sequences, test.keysPerWorker)
+			require.Len(t, merged, len(test.want))
+			for i, want := range test.want {
+				require.Equal(t, want, collectNonces(merged[i]))
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/txpool/legacypool"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ txs.Worker[*types.Transaction] = (*mempoolWorker)(nil)
+
+// mempoolRejectionReasons maps the errors returned by the mempool of a node to the reason
+// label of the MempoolRejections metric. Since the errors are returned over RPC, they are
+// matched by their message.
+var mempoolRejectionReasons = []struct {
+	err    error
+	reason string
+}{
+	{core.ErrNonceTooLow, "nonce_too_low"},
+	{core.ErrNonceTooHigh, "nonce_too_high"},
+	{txpool.ErrReplaceUnderpriced, "replacement_underpriced"},
+	{txpool.ErrUnderpriced, "underpriced"},
+	{core.ErrFeeCapTooLow, "fee_cap_too_low"},
+	{core.ErrInsufficientFunds, "insufficient_funds"},
+	{core.ErrIntrinsicGas, "intrinsic_gas_too_low"},
+	{txpool.ErrGasLimit, "exceeds_gas_limit"},
+	{txpool.ErrAlreadyKnown, "already_known"},
+	{txpool.ErrAccountLimitExceeded, "account_limit_exceeded"},
+	{legacypool.ErrTxPoolOverflow, "mempool_full"},
+	{txpool.ErrOversizedData, "oversized_data"},
+}
+
+// mempoolRejectionReason returns the reason label of [err] returned by the mempool or "other"
+// if it is not a known mempool error, such as an RPC failure.
+func mempoolRejectionReason(err error) string {
+	msg := err.Error()
+	for _, r := range mempoolRejectionReasons {
+		if strings.Contains(msg, r.err.Error()) {
+			return r.reason
+		}
+	}
+	return "other"
+}
+
+// mempoolWorker wraps a Worker to distinguish txs rejected by the mempool from txs accepted into the
+// mempool and not yet confirmed. It records the reason of each rejection and the time from acceptance
+// into the mempool to confirmation of each tx.
+type mempoolWorker struct {
+	txs.Worker[*types.Transaction]
+	metrics *metrics.Metrics
+
+	// Guards acceptedAt, since ConfirmTx may be called concurrently.
+	lock       sync.Mutex
+	acceptedAt map[common.Hash]time.Time
+}
+
+func newMempoolWorker(worker txs.Worker[*types.Transaction], metrics *metrics.Metrics) *mempoolWorker {
+	return &mempoolWorker{
+		Worker:     worker,
+		metrics:    metrics,
+		acceptedAt: make(map[common.Hash]time.Time),
+	}
+}
+
+func (w *mempoolWorker) IssueTx(ctx context.Context, tx *types.Transaction) error {
+	if err := w.Worker.IssueTx(ctx, tx); err != nil {
+		w.metrics.MempoolRejections.WithLabelValues(mempoolRejectionReason(err)).Inc()
+		return err
+	}
+	w.lock.Lock()
+	w.acceptedAt[tx.Hash()] = time.Now()
+	w.lock.Unlock()
+	return nil
+}
+
+func (w *mempoolWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	err := w.Worker.ConfirmTx(ctx, tx)
+	w.lock.Lock()
+	acceptedAt, ok := w.acceptedAt[tx.Hash()]
+	delete(w.acceptedAt, tx.Hash())
+	w.lock.Unlock()
+	if ok && err == nil {
+		w.metrics.MempoolToConfirmationTxTimes.Observe(time.Since(acceptedAt).Seconds())
+	}
+	return err
+}
+
+func (w *mempoolWorker) Forget(txHash common.Hash) {
+	w.lock.Lock()
+	delete(w.acceptedAt, txHash)
+	w.lock.Unlock()
+	txs.Forget(w.Worker, txHash)
+}
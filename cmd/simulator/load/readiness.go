@@ -0,0 +1,64 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const readinessPollFrequency = time.Second
+
+// AwaitReady blocks until every node in [healthURIs] reports ready and every client in [clients]
+// successfully serves eth_blockNumber, or returns an error if this does not happen within [timeout].
+//
+// Note: the gate does not require the chain height to advance, since blocks are only produced when
+// there are transactions to include.
+func AwaitReady(ctx context.Context, clients []ethclient.Client, healthURIs []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, uri := range healthURIs {
+		uri := uri
+		eg.Go(func() error {
+			ready, err := health.AwaitReady(egCtx, health.NewClient(uri), readinessPollFrequency, nil)
+			if err != nil {
+				return fmt.Errorf("node %s never became ready: %w", uri, err)
+			}
+			if !ready {
+				return fmt.Errorf("node %s is not ready", uri)
+			}
+			log.Info("Node is ready", "uri", uri)
+			return nil
+		})
+	}
+	for i, client := range clients {
+		i := i
+		client := client
+		eg.Go(func() error {
+			for {
+				height, err := client.BlockNumber(egCtx)
+				if err == nil {
+					log.Debug("Client is ready", "client", i, "height", height)
+					return nil
+				}
+				log.Debug("Client is not ready", "client", i, "err", err)
+
+				select {
+				case <-egCtx.Done():
+					return fmt.Errorf("client %d never became ready: %w", i, err)
+				case <-time.After(readinessPollFrequency):
+				}
+			}
+		})
+	}
+	return eg.Wait()
+}
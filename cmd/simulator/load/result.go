@@ -0,0 +1,222 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LoadResult summarizes the execution of a load test.
+type LoadResult struct {
+	// ConfirmedTxs is the number of txs confirmed across all workers.
+	ConfirmedTxs uint64 `json:"confirmedTxs"`
+	// IssuanceFailures is the number of txs that failed to issue across all workers.
+	IssuanceFailures uint64 `json:"issuanceFailures"`
+	// ConfirmationFailures is the number of txs that failed to confirm across all workers.
+	ConfirmationFailures uint64 `json:"confirmationFailures"`
+	// UnconfirmedTxs is the number of issued txs across all workers that were still unconfirmed once
+	// the max confirm wait elapsed, which are neither confirmed nor failed.
+	UnconfirmedTxs uint64 `json:"unconfirmedTxs"`
+	// UnsampledTxs is the number of issued txs across all workers that were not confirmed since they
+	// were not sampled, which are neither confirmed nor failed.
+	UnsampledTxs uint64 `json:"unsampledTxs,omitempty"`
+	// ConfirmSampleRate is the fraction of the issued txs sampled for confirmation, or zero if every tx
+	// was confirmed. If it is set, the result is sampled: the confirmation counts and latencies cover
+	// only the sampled txs, and TPS is extrapolated from them.
+	ConfirmSampleRate float64 `json:"confirmSampleRate,omitempty"`
+	// EstimatedConfirmedTxs is the number of confirmed txs extrapolated to every issued tx from the
+	// fraction of the sampled txs that confirmed, or zero unless the result is sampled.
+	EstimatedConfirmedTxs uint64 `json:"estimatedConfirmedTxs,omitempty"`
+	// Duration is the time spent issuing and confirming txs, excluding setup such as
+	// funding keys and generating txs.
+	Duration time.Duration `json:"duration"`
+	// PresignDuration is the time spent pre-signing txs before the load test, which is excluded from
+	// Duration, or zero unless txs are pre-signed.
+	PresignDuration time.Duration `json:"presignDuration,omitempty"`
+	// TPS is the number of confirmed txs per second over Duration, or of EstimatedConfirmedTxs if the
+	// result is sampled.
+	TPS float64 `json:"tps"`
+	// LatencyQuantiles maps each quantile (0.5, 0.9, and 0.99) to the time from issuance
+	// to confirmation of a tx.
+	LatencyQuantiles map[float64]time.Duration `json:"latencyQuantiles"`
+	// Senders is the number of accounts the workers issued txs from, which is a multiple of the number
+	// of workers if each worker rotates across several keys, or zero for a replay or topology.
+	Senders int `json:"senders,omitempty"`
+	// Workers holds the outcome of the txs of each worker.
+	Workers []WorkerResult `json:"workers"`
+	// TxTypes holds the outcome of the txs of each tx type of a tx mix, or is nil without a tx mix.
+	TxTypes map[string]*TxTypeResult `json:"txTypes,omitempty"`
+	// WarpPairs holds the outcome of the warp messages of each warp pair of a topology, keyed by
+	// "<source>-><destination>", or is nil without a topology.
+	WarpPairs map[string]*WarpPairResult `json:"warpPairs,omitempty"`
+	// Blocks summarizes the blocks produced during the load test, or is nil unless block stats are enabled.
+	Blocks *BlockStats `json:"blocks,omitempty"`
+}
+
+// TxTypeResult summarizes the outcome of the txs of a single tx type of a tx mix.
+type TxTypeResult struct {
+	Workers              int     `json:"workers"`
+	ConfirmedTxs         uint64  `json:"confirmedTxs"`
+	IssuanceFailures     uint64  `json:"issuanceFailures"`
+	ConfirmationFailures uint64  `json:"confirmationFailures"`
+	TPS                  float64 `json:"tps"`
+	// LatencyQuantiles maps each quantile (0.5, 0.9, and 0.99) to the time from issuance
+	// to confirmation of a tx of the tx type.
+	LatencyQuantiles map[float64]time.Duration `json:"latencyQuantiles"`
+}
+
+// WarpPairResult summarizes the outcome of the warp messages sent and delivered between a single warp pair.
+type WarpPairResult struct {
+	// SentMessages is the number of confirmed txs sending a warp message on the source blockchain.
+	SentMessages uint64 `json:"sentMessages"`
+	// DeliveredMessages is the number of confirmed txs delivering a warp message on the destination blockchain.
+	DeliveredMessages    uint64 `json:"deliveredMessages"`
+	IssuanceFailures     uint64 `json:"issuanceFailures"`
+	ConfirmationFailures uint64 `json:"confirmationFailures"`
+	// DeliveryTPS is the number of delivered messages per second over the duration of the load test.
+	DeliveryTPS float64 `json:"deliveryTPS"`
+	// DeliveryLatencyQuantiles maps each quantile (0.5, 0.95, and 0.99) to the time from the issuance of
+	// the tx sending a warp message to the confirmation of the tx delivering it.
+	DeliveryLatencyQuantiles map[float64]time.Duration `json:"deliveryLatencyQuantiles"`
+}
+
+// BlockStats summarizes the utilization of the blocks produced during a load test, which tells
+// whether the chain was saturated or had headroom.
+type BlockStats struct {
+	// FirstBlock and LastBlock are the heights of the first and last block produced during the load test.
+	FirstBlock uint64 `json:"firstBlock"`
+	LastBlock  uint64 `json:"lastBlock"`
+	// Blocks is the number of blocks produced during the load test.
+	Blocks uint64 `json:"blocks"`
+	// Txs is the number of txs included in the blocks, including txs not issued by the load test.
+	Txs         uint64  `json:"txs"`
+	AvgGasUsed  float64 `json:"avgGasUsed"`
+	AvgGasLimit float64 `json:"avgGasLimit"`
+	// GasUtilization is the total gas used divided by the total gas limit of the blocks.
+	GasUtilization float64 `json:"gasUtilization"`
+	AvgTxsPerBlock float64 `json:"avgTxsPerBlock"`
+	MaxTxsPerBlock uint64  `json:"maxTxsPerBlock"`
+	// AvgBlockTime is the average time between consecutive blocks, starting from the last block
+	// produced before the load test.
+	AvgBlockTime time.Duration `json:"avgBlockTime"`
+	// BlockTimeQuantiles maps each quantile (0.5, 0.9, and 0.99) to the time between consecutive blocks.
+	BlockTimeQuantiles map[float64]time.Duration `json:"blockTimeQuantiles"`
+}
+
+// WorkerResult summarizes the outcome of the txs of a single worker.
+type WorkerResult struct {
+	ConfirmedTxs         uint64 `json:"confirmedTxs"`
+	IssuanceFailures     uint64 `json:"issuanceFailures"`
+	ConfirmationFailures uint64 `json:"confirmationFailures"`
+	UnconfirmedTxs       uint64 `json:"unconfirmedTxs"`
+	UnsampledTxs         uint64 `json:"unsampledTxs,omitempty"`
+	// FinalBatchSize is the size of the last batch of the worker, or 0 if the batch size is not adaptive.
+	FinalBatchSize uint64 `json:"finalBatchSize,omitempty"`
+}
+
+// resultWorker wraps a Worker and counts the outcome of its txs.
+type resultWorker[T txs.THash] struct {
+	txs.Worker[T]
+
+	// Guards result, since ConfirmTx may be called concurrently.
+	lock   sync.Mutex
+	result WorkerResult
+}
+
+func (w *resultWorker[T]) IssueTx(ctx context.Context, tx T) error {
+	err := w.Worker.IssueTx(ctx, tx)
+	if err != nil {
+		w.lock.Lock()
+		w.result.IssuanceFailures++
+		w.lock.Unlock()
+	}
+	return err
+}
+
+func (w *resultWorker[T]) ConfirmTx(ctx context.Context, tx T) error {
+	err := w.Worker.ConfirmTx(ctx, tx)
+	w.lock.Lock()
+	switch {
+	case errors.Is(err, txs.ErrConfirmNotSampled):
+		w.result.UnsampledTxs++
+	case err != nil && errors.Is(context.Cause(ctx), txs.ErrConfirmWaitExceeded):
+		w.result.UnconfirmedTxs++
+	case err != nil:
+		w.result.ConfirmationFailures++
+	default:
+		w.result.ConfirmedTxs++
+	}
+	w.lock.Unlock()
+	return err
+}
+
+func (w *resultWorker[T]) Forget(txHash common.Hash) {
+	txs.Forget(w.Worker, txHash)
+}
+
+// trackResults wraps each of [workers] to count the outcome of its txs for a LoadResult.
+func trackResults[T txs.THash](workers []txs.Worker[T]) ([]txs.Worker[T], []*resultWorker[T]) {
+	wrapped := make([]txs.Worker[T], 0, len(workers))
+	resultWorkers := make([]*resultWorker[T], 0, len(workers))
+	for _, worker := range workers {
+		resultWorker := &resultWorker[T]{Worker: worker}
+		wrapped = append(wrapped, resultWorker)
+		resultWorkers = append(resultWorkers, resultWorker)
+	}
+	return wrapped, resultWorkers
+}
+
+// newLoadResult returns the LoadResult of an execution lasting [duration] with [resultWorkers],
+// the final batch sizes of the workers in [finalBatchSizes] if the batch size is adaptive,
+// and the latencies recorded in [m].
+func newLoadResult[T txs.THash](duration time.Duration, resultWorkers []*resultWorker[T], finalBatchSizes []uint64, m *metrics.Metrics) (*LoadResult, error) {
+	result := &LoadResult{
+		Duration:         duration,
+		LatencyQuantiles: make(map[float64]time.Duration),
+		Workers:          make([]WorkerResult, 0, len(resultWorkers)),
+	}
+	for i, w := range resultWorkers {
+		result.ConfirmedTxs += w.result.ConfirmedTxs
+		result.IssuanceFailures += w.result.IssuanceFailures
+		result.ConfirmationFailures += w.result.ConfirmationFailures
+		result.UnconfirmedTxs += w.result.UnconfirmedTxs
+		result.UnsampledTxs += w.result.UnsampledTxs
+		workerResult := w.result
+		if finalBatchSizes != nil {
+			workerResult.FinalBatchSize = finalBatchSizes[i]
+		}
+		result.Workers = append(result.Workers, workerResult)
+	}
+	if duration > 0 {
+		result.TPS = float64(result.ConfirmedTxs) / duration.Seconds()
+	}
+	quantiles, err := m.IssuanceToConfirmationQuantiles()
+	if err != nil {
+		return nil, err
+	}
+	for quantile, seconds := range quantiles {
+		result.LatencyQuantiles[quantile] = time.Duration(seconds * float64(time.Second))
+	}
+	return result, nil
+}
+
+// sampled labels [r] as the result of confirming only a [rate] fraction of the issued txs, and
+// extrapolates its confirmed txs and TPS from the sampled txs to every issued tx.
+func (r *LoadResult) sampled(rate float64) {
+	r.ConfirmSampleRate = rate
+	r.EstimatedConfirmedTxs = r.ConfirmedTxs
+	if sampledTxs := r.ConfirmedTxs + r.ConfirmationFailures + r.UnconfirmedTxs; sampledTxs > 0 {
+		r.EstimatedConfirmedTxs += uint64(float64(r.UnsampledTxs) * float64(r.ConfirmedTxs) / float64(sampledTxs))
+	}
+	if r.Duration > 0 {
+		r.TPS = float64(r.EstimatedConfirmedTxs) / r.Duration.Seconds()
+	}
+}
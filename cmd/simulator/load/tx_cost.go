@@ -0,0 +1,89 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ txs.Worker[*types.Transaction] = (*txCostWorker)(nil)
+
+// txCostWorker wraps a Worker to record the gas used and the effective tip paid by every confirmed tx,
+// as reported by its receipt.
+type txCostWorker struct {
+	txs.Worker[*types.Transaction]
+	client  ethclient.Client
+	metrics *metrics.Metrics
+
+	// baseFees caches the base fee of each block a confirmed tx was included in, since
+	// a block typically includes many txs of a load test. It is guarded by [lock], since
+	// ConfirmTx may be called concurrently.
+	lock     sync.Mutex
+	baseFees map[uint64]*big.Int
+}
+
+func newTxCostWorker(worker txs.Worker[*types.Transaction], client ethclient.Client, metrics *metrics.Metrics) *txCostWorker {
+	return &txCostWorker{
+		Worker:   worker,
+		client:   client,
+		metrics:  metrics,
+		baseFees: make(map[uint64]*big.Int),
+	}
+}
+
+func (w *txCostWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	if err := w.Worker.ConfirmTx(ctx, tx); err != nil {
+		return err
+	}
+	receipt, err := w.client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to fetch receipt of tx %s: %w", tx.Hash(), err)
+	}
+	baseFee, err := w.baseFee(ctx, receipt.BlockNumber)
+	if err != nil {
+		return err
+	}
+	// The effective tip is the part of the effective gas price paid on top of the base fee.
+	effectiveTip := new(big.Int).Sub(receipt.EffectiveGasPrice, baseFee)
+	effectiveTipGwei, _ := new(big.Float).Quo(new(big.Float).SetInt(effectiveTip), big.NewFloat(params.GWei)).Float64()
+	w.metrics.GasUsed.Observe(float64(receipt.GasUsed))
+	w.metrics.EffectiveTip.Observe(effectiveTipGwei)
+	return nil
+}
+
+func (w *txCostWorker) Forget(txHash common.Hash) {
+	txs.Forget(w.Worker, txHash)
+}
+
+// baseFee returns the base fee of block [number].
+func (w *txCostWorker) baseFee(ctx context.Context, number *big.Int) (*big.Int, error) {
+	w.lock.Lock()
+	baseFee, ok := w.baseFees[number.Uint64()]
+	w.lock.Unlock()
+	if ok {
+		return baseFee, nil
+	}
+	header, err := w.client.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch header %d: %w", number, err)
+	}
+	baseFee = header.BaseFee
+	if baseFee == nil {
+		baseFee = new(big.Int)
+	}
+	w.lock.Lock()
+	w.baseFees[number.Uint64()] = baseFee
+	w.lock.Unlock()
+	return baseFee, nil
+}
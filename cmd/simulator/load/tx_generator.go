@@ -0,0 +1,128 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+var _ txs.TxGenerator = (*transferTxGenerator)(nil)
+
+// transferTxGenerator generates txs that transfer no value from each sender to itself, carrying
+// a random payload and, for blob txs, blobs of random data.
+type transferTxGenerator struct {
+	config         config.Config
+	client         ethclient.Client
+	senderFeeTiers map[common.Address]feeTier
+	senderTxTypes  map[common.Address]string
+	blobFeeCap     *big.Int
+	seed           int64
+
+	// Guards senderRands, since txs of different senders may be generated concurrently.
+	lock sync.Mutex
+	// Maps each sender to the source of randomness of its txs, seeded by [seed] and the sender, so
+	// that the txs of each sender are reproducible regardless of the order senders generate txs in.
+	senderRands map[common.Address]*rand.Rand
+
+	// Set in Setup
+	chainID  *big.Int
+	txSigner txs.TxSigner
+}
+
+// newTransferTxGenerator returns a transferTxGenerator for the txs specified by [c], paying the fees
+// of the tier assigned to each sender in [senderFeeTiers] and of the tx type assigned to each sender
+// in [senderTxTypes]. The randomness of the txs is seeded by [c.Seed].
+func newTransferTxGenerator(c config.Config, client ethclient.Client, senderFeeTiers map[common.Address]feeTier, senderTxTypes map[common.Address]string) *transferTxGenerator {
+	return &transferTxGenerator{
+		config:         c,
+		client:         client,
+		senderFeeTiers: senderFeeTiers,
+		senderTxTypes:  senderTxTypes,
+		blobFeeCap:     new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(c.MaxBlobFeeCap)),
+		seed:           c.Seed,
+		senderRands:    make(map[common.Address]*rand.Rand),
+	}
+}
+
+// senderRand returns the source of randomness of the txs of [addr]. The returned source must only be
+// used by the goroutine generating the txs of [addr].
+func (g *transferTxGenerator) senderRand(addr common.Address) *rand.Rand {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	rng, ok := g.senderRands[addr]
+	if !ok {
+		rng = rand.New(rand.NewSource(g.seed ^ int64(binary.BigEndian.Uint64(addr[:8]))))
+		g.senderRands[addr] = rng
+	}
+	return rng
+}
+
+// Setup fetches the chainID and creates the signer of the txs.
+func (g *transferTxGenerator) Setup(ctx context.Context) error {
+	chainID, err := g.client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch chainID: %w", err)
+	}
+	txSigner, err := newTxSigner(g.config, chainID)
+	if err != nil {
+		return err
+	}
+	g.chainID = chainID
+	g.txSigner = txSigner
+	return nil
+}
+
+func (g *transferTxGenerator) GenerateTx(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+	addr := ethcrypto.PubkeyToAddress(key.PublicKey)
+	tier := g.senderFeeTiers[addr]
+	rng := g.senderRand(addr)
+	data, err := randomPayload(g.config, rng)
+	if err != nil {
+		return nil, err
+	}
+	if isBlobTxType(g.senderTxTypes[addr]) {
+		sidecar, err := newBlobSidecar(g.config.BlobsPerTx, rng)
+		if err != nil {
+			return nil, err
+		}
+		return g.txSigner.SignTx(key, types.NewTx(&types.BlobTx{
+			ChainID:    uint256.MustFromBig(g.chainID),
+			Nonce:      nonce,
+			GasTipCap:  uint256.MustFromBig(tier.gasTipCap),
+			GasFeeCap:  uint256.MustFromBig(tier.gasFeeCap),
+			Gas:        g.config.TxGasLimit(uint64(len(data))),
+			To:         addr,
+			Data:       data,
+			Value:      new(uint256.Int),
+			BlobFeeCap: uint256.MustFromBig(g.blobFeeCap),
+			BlobHashes: sidecar.BlobHashes(),
+			Sidecar:    sidecar,
+		}))
+	}
+	return g.txSigner.SignTx(key, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   g.chainID,
+		Nonce:     nonce,
+		GasTipCap: tier.gasTipCap,
+		GasFeeCap: tier.gasFeeCap,
+		Gas:       g.config.TxGasLimit(uint64(len(data))),
+		To:        &addr,
+		Data:      data,
+		Value:     common.Big0,
+	}))
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var _ txs.Worker[*types.Transaction] = (*txTypeWorker)(nil)
+
+// assignTxTypes assigns each of [senders] the tx type it issues under [c]. Without a tx mix, every sender
+// issues [c.TxType]. Otherwise, each tx type of the mix is assigned its percentage of [senders], rounded
+// by largest remainder, and the tx types are interleaved across [senders] in proportion to their shares.
+func assignTxTypes(c config.Config, senders []common.Address) (map[common.Address]string, error) {
+	assigned := make(map[common.Address]string, len(senders))
+	shares := c.TxMixShares()
+	if len(shares) == 0 {
+		for _, sender := range senders {
+			assigned[sender] = c.TxType
+		}
+		return assigned, nil
+	}
+
+	counts := make([]int, len(shares))
+	remainders := make([]int, len(shares))
+	numAssigned := 0
+	for i, share := range shares {
+		counts[i] = len(senders) * share.Percent / 100
+		remainders[i] = len(senders) * share.Percent % 100
+		numAssigned += counts[i]
+	}
+	// Assign the senders left by rounding down to the tx types with the largest remainders.
+	byRemainder := make([]int, len(shares))
+	for i := range byRemainder {
+		byRemainder[i] = i
+	}
+	sort.SliceStable(byRemainder, func(i, j int) bool {
+		return remainders[byRemainder[i]] > remainders[byRemainder[j]]
+	})
+	for _, i := range byRemainder[:len(senders)-numAssigned] {
+		counts[i]++
+	}
+	for i, share := range shares {
+		if counts[i] == 0 {
+			return nil, fmt.Errorf("too few workers (%d) to assign %d%% of them to tx type %q", len(senders), share.Percent, share.TxType)
+		}
+	}
+
+	// Interleave the tx types by smooth weighted round robin, which picks each tx type exactly
+	// counts[i] times over len(senders) picks.
+	current := make([]int, len(shares))
+	for _, sender := range senders {
+		next := 0
+		for i := range shares {
+			current[i] += counts[i]
+			if current[i] > current[next] {
+				next = i
+			}
+		}
+		current[next] -= len(senders)
+		assigned[sender] = shares[next].TxType
+	}
+	return assigned, nil
+}
+
+// txTypeWorker wraps a Worker to record the issuance to confirmation time of every tx by the tx type
+// of a tx mix.
+type txTypeWorker struct {
+	txs.Worker[*types.Transaction]
+	txType  string
+	metrics *metrics.Metrics
+
+	// Guards issuedAt, since ConfirmTx may be called concurrently.
+	lock     sync.Mutex
+	issuedAt map[common.Hash]time.Time
+}
+
+func newTxTypeWorker(worker txs.Worker[*types.Transaction], txType string, metrics *metrics.Metrics) *txTypeWorker {
+	return &txTypeWorker{
+		Worker:   worker,
+		txType:   txType,
+		metrics:  metrics,
+		issuedAt: make(map[common.Hash]time.Time),
+	}
+}
+
+func (w *txTypeWorker) IssueTx(ctx context.Context, tx *types.Transaction) error {
+	w.lock.Lock()
+	w.issuedAt[tx.Hash()] = time.Now()
+	w.lock.Unlock()
+	return w.Worker.IssueTx(ctx, tx)
+}
+
+func (w *txTypeWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	err := w.Worker.ConfirmTx(ctx, tx)
+	w.lock.Lock()
+	issuedAt, ok := w.issuedAt[tx.Hash()]
+	delete(w.issuedAt, tx.Hash())
+	w.lock.Unlock()
+	if ok && err == nil {
+		w.metrics.TxTypeIssuanceToConfirmationTxTimes.WithLabelValues(w.txType).Observe(time.Since(issuedAt).Seconds())
+	}
+	return err
+}
+
+func (w *txTypeWorker) Forget(txHash common.Hash) {
+	w.lock.Lock()
+	delete(w.issuedAt, txHash)
+	w.lock.Unlock()
+	txs.Forget(w.Worker, txHash)
+}
+
+// addTxTypeResults adds the outcome of the txs of each tx type to [result], where the worker at each index
+// of [result.Workers] issued txs of the tx type at the same index of [workerTxTypes], and logs a summary of
+// each tx type.
+func addTxTypeResults(result *LoadResult, workerTxTypes []string, m *metrics.Metrics) error {
+	quantiles, err := m.TxTypeIssuanceToConfirmationQuantiles()
+	if err != nil {
+		return err
+	}
+	result.TxTypes = make(map[string]*TxTypeResult)
+	for i, workerResult := range result.Workers {
+		txType := workerTxTypes[i]
+		txTypeResult, ok := result.TxTypes[txType]
+		if !ok {
+			txTypeResult = &TxTypeResult{LatencyQuantiles: make(map[float64]time.Duration)}
+			for quantile, seconds := range quantiles[txType] {
+				txTypeResult.LatencyQuantiles[quantile] = time.Duration(seconds * float64(time.Second))
+			}
+			result.TxTypes[txType] = txTypeResult
+		}
+		txTypeResult.Workers++
+		txTypeResult.ConfirmedTxs += workerResult.ConfirmedTxs
+		txTypeResult.IssuanceFailures += workerResult.IssuanceFailures
+		txTypeResult.ConfirmationFailures += workerResult.ConfirmationFailures
+	}
+	for txType, txTypeResult := range result.TxTypes {
+		if result.Duration > 0 {
+			txTypeResult.TPS = float64(txTypeResult.ConfirmedTxs) / result.Duration.Seconds()
+		}
+		log.Info("Tx type summary", "txType", txType, "workers", txTypeResult.Workers, "txs", txTypeResult.ConfirmedTxs,
+			"TPS", txTypeResult.TPS, "p50Latency", txTypeResult.LatencyQuantiles[0.5], "p99Latency", txTypeResult.LatencyQuantiles[0.99])
+	}
+	return nil
+}
@@ -0,0 +1,382 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// Gas limits of each user operation, which executes a call with no value from the account to itself.
+	userOpCallGasLimit         = 50_000
+	userOpVerificationGasLimit = 100_000
+	userOpDeployGasLimit       = 400_000 // Additional verification gas of the user operation deploying the account
+	userOpPreVerificationGas   = 50_000
+	userOpReceiptPollFrequency = time.Second
+)
+
+var (
+	ErrNoEntryPoint     = errors.New("target chain has no EntryPoint contract")
+	ErrNoAccountFactory = errors.New("target chain has no account factory contract")
+	errUserOpReverted   = errors.New("user operation reverted")
+)
+
+// userOpABI defines the methods of the ERC-4337 (v0.6) EntryPoint, SimpleAccountFactory, and SimpleAccount
+// contracts called by the simulator.
+var userOpABI = mustParseABI(`[
+	{"type":"function","name":"getNonce","stateMutability":"view","inputs":[{"name":"sender","type":"address"},{"name":"key","type":"uint192"}],"outputs":[{"name":"nonce","type":"uint256"}]},
+	{"type":"function","name":"getAddress","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"salt","type":"uint256"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"createAccount","stateMutability":"nonpayable","inputs":[{"name":"owner","type":"address"},{"name":"salt","type":"uint256"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"execute","stateMutability":"nonpayable","inputs":[{"name":"dest","type":"address"},{"name":"value","type":"uint256"},{"name":"func","type":"bytes"}],"outputs":[]}
+]`)
+
+// userOpHashArgs are the arguments hashed to compute the hash of a user operation.
+var userOpHashArgs = mustParseArguments(
+	"address", "uint256", "bytes32", "bytes32", "uint256", "uint256", "uint256", "uint256", "uint256", "bytes32",
+)
+
+// userOpHashDomainArgs are the arguments hashed with the hash of a user operation to bind it to an
+// EntryPoint and chain.
+var userOpHashDomainArgs = mustParseArguments("bytes32", "address", "uint256")
+
+// userOps returns true if [c] specifies that user operations should be submitted to a bundler.
+func userOps(c config.Config) bool {
+	return c.TxType == config.UserOpTxType
+}
+
+func mustParseABI(rawABI string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(rawABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func mustParseArguments(types ...string) abi.Arguments {
+	args := make(abi.Arguments, 0, len(types))
+	for _, typ := range types {
+		abiType, err := abi.NewType(typ, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		args = append(args, abi.Argument{Type: abiType})
+	}
+	return args
+}
+
+// UserOperation is an ERC-4337 (v0.6) user operation, encoded as expected by the eth_sendUserOperation
+// method of a bundler.
+type UserOperation struct {
+	Sender               common.Address `json:"sender"`
+	Nonce                *hexutil.Big   `json:"nonce"`
+	InitCode             hexutil.Bytes  `json:"initCode"`
+	CallData             hexutil.Bytes  `json:"callData"`
+	CallGasLimit         *hexutil.Big   `json:"callGasLimit"`
+	VerificationGasLimit *hexutil.Big   `json:"verificationGasLimit"`
+	PreVerificationGas   *hexutil.Big   `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     hexutil.Bytes  `json:"paymasterAndData"`
+	Signature            hexutil.Bytes  `json:"signature"`
+
+	hash common.Hash
+}
+
+// Hash returns the hash of the user operation, which identifies it to the bundler.
+func (op *UserOperation) Hash() common.Hash {
+	return op.hash
+}
+
+// sign sets the hash of [op] for [entryPoint] and [chainID] and signs it with [key] as expected by
+// SimpleAccount: an EIP-191 signature of the hash.
+func (op *UserOperation) sign(key *ecdsa.PrivateKey, entryPoint common.Address, chainID *big.Int) error {
+	packedOp, err := userOpHashArgs.Pack(
+		op.Sender,
+		op.Nonce.ToInt(),
+		ethcrypto.Keccak256Hash(op.InitCode),
+		ethcrypto.Keccak256Hash(op.CallData),
+		op.CallGasLimit.ToInt(),
+		op.VerificationGasLimit.ToInt(),
+		op.PreVerificationGas.ToInt(),
+		op.MaxFeePerGas.ToInt(),
+		op.MaxPriorityFeePerGas.ToInt(),
+		ethcrypto.Keccak256Hash(op.PaymasterAndData),
+	)
+	if err != nil {
+		return err
+	}
+	packedDomain, err := userOpHashDomainArgs.Pack(ethcrypto.Keccak256Hash(packedOp), entryPoint, chainID)
+	if err != nil {
+		return err
+	}
+	op.hash = ethcrypto.Keccak256Hash(packedDomain)
+
+	prefixedHash := ethcrypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), op.hash[:])
+	signature, err := ethcrypto.Sign(prefixedHash, key)
+	if err != nil {
+		return err
+	}
+	signature[ethcrypto.RecoveryIDOffset] += 27 // SimpleAccount expects v in {27, 28}
+	op.Signature = signature
+	return nil
+}
+
+var _ txs.TxSequence[*UserOperation] = (*userOpSequence)(nil)
+
+type userOpSequence struct {
+	opChan chan *UserOperation
+}
+
+func (s *userOpSequence) Chan() <-chan *UserOperation {
+	return s.opChan
+}
+
+// userOpGenerator generates user operations executing a call with no value from SimpleAccounts to
+// themselves. The account of each key is deployed by its first user operation if it does not exist yet.
+type userOpGenerator struct {
+	client         ethclient.Client
+	entryPoint     common.Address
+	accountFactory common.Address
+	chainID        *big.Int
+	maxFeePerGas   *big.Int
+	maxTipPerGas   *big.Int
+}
+
+// call returns the result of calling [method] on [contract] with [args].
+func (g *userOpGenerator) call(ctx context.Context, contract common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	input, err := userOpABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	output, err := g.client.CallContract(ctx, interfaces.CallMsg{To: &contract, Data: input}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s on %s: %w", method, contract, err)
+	}
+	return userOpABI.Unpack(method, output)
+}
+
+// generateSequence generates a sequence of [numOps] user operations from the account owned by [key].
+func (g *userOpGenerator) generateSequence(ctx context.Context, key *ecdsa.PrivateKey, numOps uint64) (*userOpSequence, error) {
+	owner := ethcrypto.PubkeyToAddress(key.PublicKey)
+	salt := common.Big0
+	res, err := g.call(ctx, g.accountFactory, "getAddress", owner, salt)
+	if err != nil {
+		return nil, err
+	}
+	account := res[0].(common.Address)
+	res, err = g.call(ctx, g.entryPoint, "getNonce", account, common.Big0)
+	if err != nil {
+		return nil, err
+	}
+	startingNonce := res[0].(*big.Int)
+	code, err := g.client.CodeAt(ctx, account, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch code of account %s: %w", account, err)
+	}
+	var initCode []byte
+	if len(code) == 0 {
+		createAccount, err := userOpABI.Pack("createAccount", owner, salt)
+		if err != nil {
+			return nil, err
+		}
+		initCode = append(g.accountFactory.Bytes(), createAccount...)
+	}
+	callData, err := userOpABI.Pack("execute", account, common.Big0, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	sequence := &userOpSequence{opChan: make(chan *UserOperation, numOps)}
+	for i := uint64(0); i < numOps; i++ {
+		verificationGasLimit := uint64(userOpVerificationGasLimit)
+		if i == 0 && len(initCode) > 0 {
+			verificationGasLimit += userOpDeployGasLimit
+		}
+		op := &UserOperation{
+			Sender:               account,
+			Nonce:                (*hexutil.Big)(new(big.Int).Add(startingNonce, new(big.Int).SetUint64(i))),
+			CallData:             callData,
+			CallGasLimit:         (*hexutil.Big)(big.NewInt(userOpCallGasLimit)),
+			VerificationGasLimit: (*hexutil.Big)(new(big.Int).SetUint64(verificationGasLimit)),
+			PreVerificationGas:   (*hexutil.Big)(big.NewInt(userOpPreVerificationGas)),
+			MaxFeePerGas:         (*hexutil.Big)(g.maxFeePerGas),
+			MaxPriorityFeePerGas: (*hexutil.Big)(g.maxTipPerGas),
+			PaymasterAndData:     []byte{},
+		}
+		if i == 0 {
+			op.InitCode = initCode
+		}
+		if err := op.sign(key, g.entryPoint, g.chainID); err != nil {
+			return nil, fmt.Errorf("failed to sign user operation: %w", err)
+		}
+		sequence.opChan <- op
+	}
+	close(sequence.opChan)
+	log.Debug("Generated user operations", "owner", owner, "account", account, "startingNonce", startingNonce, "deploy", len(initCode) > 0)
+	return sequence, nil
+}
+
+var _ txs.Worker[*UserOperation] = (*userOpWorker)(nil)
+
+// userOpWorker submits user operations to a bundler, which bundles them into txs calling the EntryPoint,
+// and confirms them by their user operation receipts.
+type userOpWorker struct {
+	client     ethclient.Client
+	bundler    *rpc.Client
+	entryPoint common.Address
+}
+
+func (w *userOpWorker) IssueTx(ctx context.Context, op *UserOperation) error {
+	var opHash common.Hash
+	if err := w.bundler.CallContext(ctx, &opHash, "eth_sendUserOperation", op, w.entryPoint); err != nil {
+		return err
+	}
+	if opHash != op.Hash() {
+		return fmt.Errorf("bundler returned user operation hash %s, expected %s", opHash, op.Hash())
+	}
+	return nil
+}
+
+func (w *userOpWorker) ConfirmTx(ctx context.Context, op *UserOperation) error {
+	for {
+		var receipt *struct {
+			Success bool `json:"success"`
+		}
+		if err := w.bundler.CallContext(ctx, &receipt, "eth_getUserOperationReceipt", op.Hash()); err != nil {
+			return err
+		}
+		if receipt != nil {
+			if !receipt.Success {
+				return fmt.Errorf("%w: %s", errUserOpReverted, op.Hash())
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to await user operation %s: %w", op.Hash(), ctx.Err())
+		case <-time.After(userOpReceiptPollFrequency):
+		}
+	}
+}
+
+func (w *userOpWorker) LatestHeight(ctx context.Context) (uint64, error) {
+	return w.client.BlockNumber(ctx)
+}
+
+// checkContract returns [errMissing] if there is no contract at [addr] on the chain served by [client].
+func checkContract(ctx context.Context, client ethclient.Client, addr common.Address, errMissing error) error {
+	code, err := client.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch code at %s: %w", addr, err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("%w: %s", errMissing, addr)
+	}
+	return nil
+}
+
+// executeUserOps submits [txCounts[i]] user operations from the SimpleAccount owned by [keys[i]] to
+// the bundler specified by [c], confirming them by their receipts with [clients[i]] used to track
+// the height of the chain.
+//
+// The simulator does not fund the accounts, which must hold enough funds or EntryPoint deposit to pay
+// for their user operations.
+func executeUserOps(ctx context.Context, c config.Config, clients []ethclient.Client, keys []*ecdsa.PrivateKey, txCounts []uint64, m *metrics.Metrics) (*LoadResult, error) {
+	entryPoint := common.HexToAddress(c.EntryPoint)
+	accountFactory := common.HexToAddress(c.AccountFactory)
+	if err := checkContract(ctx, clients[0], entryPoint, ErrNoEntryPoint); err != nil {
+		return nil, err
+	}
+	if err := checkContract(ctx, clients[0], accountFactory, ErrNoAccountFactory); err != nil {
+		return nil, err
+	}
+	chainID, err := clients[0].ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chainID: %w", err)
+	}
+	bundler, err := rpc.DialContext(ctx, c.BundlerEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial bundler at %s: %w", c.BundlerEndpoint, err)
+	}
+	defer bundler.Close()
+
+	generator := &userOpGenerator{
+		client:         clients[0],
+		entryPoint:     entryPoint,
+		accountFactory: accountFactory,
+		chainID:        chainID,
+		maxFeePerGas:   new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(c.MaxFeeCap)),
+		maxTipPerGas:   new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(c.MaxTipCap)),
+	}
+	log.Info("Creating user operation sequences...", "entryPoint", entryPoint, "accountFactory", accountFactory)
+	sequences := make([]txs.TxSequence[*UserOperation], 0, len(keys))
+	for i, key := range keys {
+		sequence, err := generator.generateSequence(ctx, key, txCounts[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate user operation sequence at index %d: %w", i, err)
+		}
+		sequences = append(sequences, sequence)
+	}
+
+	workers := make([]txs.Worker[*UserOperation], 0, len(clients))
+	for _, client := range clients {
+		workers = append(workers, injectLatency(c, txs.Worker[*UserOperation](&userOpWorker{
+			client:     client,
+			bundler:    bundler,
+			entryPoint: entryPoint,
+		})))
+	}
+	workers, resultWorkers := trackResults(workers)
+	loader := New(workers, sequences, c.BatchSize, m, loaderOptions(c))
+	blocks, err := newBlockStatsCollector(ctx, c, clients[0])
+	if err != nil {
+		return nil, err
+	}
+	stopReports := reportProgress(c, m)
+	err = loader.Execute(ctx)
+	stopReports()
+	executeDuration := time.Since(loader.StartTime())
+	if err == nil {
+		if lerr := m.LogTPSBreakdown(); lerr != nil {
+			log.Warn("Failed to log TPS breakdown", "error", lerr)
+		}
+	}
+	if lerr := m.LogFailures(); lerr != nil {
+		log.Warn("Failed to log failed txs", "error", lerr)
+	}
+	blockStats, berr := blocks.collect(ctx, m) // Collect regardless of execution error
+	if berr != nil {
+		log.Warn("Failed to collect block stats", "error", berr)
+	}
+	if prerr := m.Print(c.MetricsOutput); prerr != nil { // Print regardless of execution error
+		log.Warn("Failed to print metrics", "error", prerr)
+	}
+	result, rerr := newLoadResult(executeDuration, resultWorkers, loader.FinalBatchSizes(), m)
+	if rerr != nil {
+		log.Warn("Failed to compute load result", "error", rerr)
+	}
+	if result != nil {
+		result.Blocks = blockStats
+	}
+	return result, err
+}
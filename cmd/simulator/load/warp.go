@@ -0,0 +1,644 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/config"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/predicate"
+	warpBackend "github.com/ava-labs/subnet-evm/warp"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	warpSendGasLimit = 200_000
+	// warpDeliverGasLimit covers the predicate gas of verifying the aggregate signature of a warp message
+	// signed by over a thousand validators.
+	warpDeliverGasLimit = 1_000_000
+
+	// warpSignatureRetries is the maximum number of times the aggregate signature of a warp message is
+	// re-fetched, since the validators of the source subnet may not all have accepted the message yet.
+	warpSignatureRetries = 5
+	// warpSignatureInitialBackoff is the time to wait before re-fetching an aggregate signature for the
+	// first time, which doubles after each retry.
+	warpSignatureInitialBackoff = 500 * time.Millisecond
+)
+
+var (
+	ErrNoWarpPrecompile     = errors.New("blockchain does not have the warp precompile enabled")
+	ErrBlockchainIDMismatch = errors.New("endpoint serves a different blockchain")
+	errWarpSubscriptionDone = errors.New("unsubscribed")
+
+	_ txs.TxGenerator      = (*warpSendTxGenerator)(nil)
+	_ expectedLogGenerator = (*warpSendTxGenerator)(nil)
+	_ txs.TxGenerator      = (*warpDeliverTxGenerator)(nil)
+
+	_ txs.Worker[*types.Transaction] = (*warpSendWorker)(nil)
+	_ txs.Worker[*types.Transaction] = (*warpDeliverWorker)(nil)
+)
+
+// warpBlockchain is the blockchain of a subnet of a topology, with a client for each of its endpoints.
+type warpBlockchain struct {
+	*config.TopologySubnet
+	clients []ethclient.Client
+
+	// Set by setup
+	chainID  *big.Int
+	txSigner txs.TxSigner
+	// The funded keys of the blockchain that are not yet assigned to a worker.
+	keys []*ecdsa.PrivateKey
+}
+
+// dialWarpBlockchain dials each endpoint of [subnet].
+func dialWarpBlockchain(subnet *config.TopologySubnet) (*warpBlockchain, error) {
+	clients := make([]ethclient.Client, 0, len(subnet.Endpoints))
+	for _, endpoint := range subnet.Endpoints {
+		client, err := ethclient.Dial(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial %s client at %s: %w", subnet.Name, endpoint, err)
+		}
+		clients = append(clients, client)
+	}
+	return &warpBlockchain{
+		TopologySubnet: subnet,
+		clients:        clients,
+	}, nil
+}
+
+// setup verifies that every endpoint of [b] serves its blockchain, and fetches the chain ID of [b]
+// to create the signer of its txs specified by [c].
+func (b *warpBlockchain) setup(ctx context.Context, c config.Config) error {
+	input, err := warp.PackGetBlockchainID()
+	if err != nil {
+		return err
+	}
+	for i, client := range b.clients {
+		output, err := client.CallContract(ctx, interfaces.CallMsg{To: &warp.Module.Address, Data: input}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch blockchain ID of %s endpoint %s: %w", b.Name, b.Endpoints[i], err)
+		}
+		if len(output) != common.HashLength {
+			return fmt.Errorf("%w: %s endpoint %s", ErrNoWarpPrecompile, b.Name, b.Endpoints[i])
+		}
+		if blockchainID := ids.ID(common.BytesToHash(output)); blockchainID != b.BlockchainID {
+			return fmt.Errorf("%w: %s endpoint %s serves blockchain %s, expected %s",
+				ErrBlockchainIDMismatch, b.Name, b.Endpoints[i], blockchainID, b.BlockchainID)
+		}
+	}
+	chainID, err := b.clients[0].ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch chain ID of %s: %w", b.Name, err)
+	}
+	txSigner, err := newTxSigner(c, chainID)
+	if err != nil {
+		return err
+	}
+	b.chainID = chainID
+	b.txSigner = txSigner
+	log.Info("Verified blockchain", "subnet", b.Name, "blockchainID", b.BlockchainID, "chainID", chainID, "endpoints", len(b.Endpoints))
+	return nil
+}
+
+// takeKeys assigns the next [numKeys] funded keys of [b] to workers and returns them.
+func (b *warpBlockchain) takeKeys(numKeys int) []*ecdsa.PrivateKey {
+	keys := b.keys[:numKeys]
+	b.keys = b.keys[numKeys:]
+	return keys
+}
+
+// warpSendTxGenerator generates txs that send a warp message with a payload unique to the key and nonce.
+type warpSendTxGenerator struct {
+	chainID   *big.Int
+	txSigner  txs.TxSigner
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+}
+
+func (*warpSendTxGenerator) Setup(context.Context) error {
+	return nil
+}
+
+// expectedLog returns the SendWarpMessage event of the warp precompile, which every send tx must emit
+// for its warp message to be delivered.
+func (*warpSendTxGenerator) expectedLog() expectedLog {
+	return expectedLog{
+		address: warp.Module.Address,
+		topic:   warp.WarpABI.Events["SendWarpMessage"].ID,
+	}
+}
+
+func (g *warpSendTxGenerator) GenerateTx(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+	payload := binary.BigEndian.AppendUint64(ethcrypto.PubkeyToAddress(key.PublicKey).Bytes(), nonce)
+	data, err := warp.PackSendWarpMessage(payload)
+	if err != nil {
+		return nil, err
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   g.chainID,
+		Nonce:     nonce,
+		To:        &warp.Module.Address,
+		Gas:       warpSendGasLimit,
+		GasFeeCap: g.gasFeeCap,
+		GasTipCap: g.gasTipCap,
+		Value:     common.Big0,
+		Data:      data,
+	})
+	return g.txSigner.SignTx(key, tx)
+}
+
+// warpDeliverTxGenerator generates txs that deliver the next warp message sent in [logs] by calling
+// getVerifiedWarpMessage, with its aggregate signature fetched from the source node by [warpClient].
+//
+// Since delivery txs are generated in the background, a failure to generate one cancels the generation
+// of every delivery tx with [cancel], which records the failure as the cause of the cancellation.
+type warpDeliverTxGenerator struct {
+	ctx             context.Context
+	cancel          context.CancelCauseFunc
+	logs            <-chan types.Log
+	subErr          <-chan error
+	warpClient      warpBackend.Client
+	signingSubnetID string
+	input           []byte
+	chainID         *big.Int
+	txSigner        txs.TxSigner
+	gasFeeCap       *big.Int
+	gasTipCap       *big.Int
+	tracker         *warpTracker
+}
+
+func (*warpDeliverTxGenerator) Setup(context.Context) error {
+	return nil
+}
+
+func (g *warpDeliverTxGenerator) GenerateTx(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+	tx, err := g.generateTx(key, nonce)
+	if err != nil {
+		g.cancel(err)
+	}
+	return tx, err
+}
+
+func (g *warpDeliverTxGenerator) generateTx(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+	unsignedMessage, sendTxHash, err := g.nextMessage()
+	if err != nil {
+		return nil, err
+	}
+	g.tracker.sentMessage(sendTxHash, unsignedMessage.ID())
+	signedMessage, err := g.fetchAggregateSignature(unsignedMessage.ID())
+	if err != nil {
+		return nil, err
+	}
+	tx := predicate.NewPredicateTx(
+		g.chainID,
+		nonce,
+		&warp.Module.Address,
+		warpDeliverGasLimit,
+		g.gasFeeCap,
+		g.gasTipCap,
+		common.Big0,
+		g.input,
+		types.AccessList{},
+		warp.ContractAddress,
+		signedMessage,
+	)
+	signedTx, err := g.txSigner.SignTx(key, tx)
+	if err != nil {
+		return nil, err
+	}
+	g.tracker.delivering(signedTx.Hash(), unsignedMessage.ID())
+	return signedTx, nil
+}
+
+// nextMessage waits for the next warp message sent in [g.logs], skipping logs removed by a reorg, and
+// returns it with the hash of the tx that sent it.
+func (g *warpDeliverTxGenerator) nextMessage() (*avalancheWarp.UnsignedMessage, common.Hash, error) {
+	for {
+		select {
+		case <-g.ctx.Done():
+			return nil, common.Hash{}, context.Cause(g.ctx)
+		case err := <-g.subErr:
+			// The error channel is closed once the subscription is unsubscribed, yielding a nil error.
+			if err == nil {
+				err = errWarpSubscriptionDone
+			}
+			return nil, common.Hash{}, fmt.Errorf("warp message subscription ended: %w", err)
+		case warpLog := <-g.logs:
+			if warpLog.Removed {
+				continue
+			}
+			unsignedMessage, err := warp.UnpackSendWarpEventDataToMessage(warpLog.Data)
+			return unsignedMessage, warpLog.TxHash, err
+		}
+	}
+}
+
+// fetchAggregateSignature returns the signed warp message with [messageID], re-fetching its aggregate
+// signature with exponential backoff up to warpSignatureRetries times.
+func (g *warpDeliverTxGenerator) fetchAggregateSignature(messageID ids.ID) ([]byte, error) {
+	backoff := warpSignatureInitialBackoff
+	for retry := 0; ; retry++ {
+		signedMessage, err := g.warpClient.GetMessageAggregateSignature(g.ctx, messageID, warp.WarpDefaultQuorumNumerator, g.signingSubnetID)
+		if err == nil {
+			return signedMessage, nil
+		}
+		if retry == warpSignatureRetries {
+			return nil, fmt.Errorf("failed to fetch aggregate signature of warp message %s after %d retries: %w", messageID, retry, err)
+		}
+		log.Debug("Failed to fetch aggregate signature of warp message, retrying", "messageID", messageID, "retry", retry+1, "backoff", backoff, "err", err)
+		select {
+		case <-g.ctx.Done():
+			return nil, fmt.Errorf("failed to fetch aggregate signature of warp message %s: %w", messageID, context.Cause(g.ctx))
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// warpTracker correlates each warp message of a warp pair from the issuance of the tx sending it to the
+// confirmation of the tx delivering it, and records the time between them as its delivery latency.
+type warpTracker struct {
+	latency prometheus.Observer
+
+	// Guards the maps below, since txs are issued, generated, and confirmed concurrently.
+	lock sync.Mutex
+	// Issuance time of each send tx whose message has not been seen yet
+	sendIssuedAt map[common.Hash]time.Time
+	// Issuance time of the send tx of each message whose delivery has not been confirmed yet
+	messageSentAt map[ids.ID]time.Time
+	// ID of the message delivered by each delivery tx
+	deliveries map[common.Hash]ids.ID
+}
+
+func newWarpTracker(latency prometheus.Observer) *warpTracker {
+	return &warpTracker{
+		latency:       latency,
+		sendIssuedAt:  make(map[common.Hash]time.Time),
+		messageSentAt: make(map[ids.ID]time.Time),
+		deliveries:    make(map[common.Hash]ids.ID),
+	}
+}
+
+// issuingSend records that the send tx [txHash] is being issued now.
+func (t *warpTracker) issuingSend(txHash common.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.sendIssuedAt[txHash] = time.Now()
+}
+
+// sentMessage records that the send tx [txHash] sent the message [messageID].
+func (t *warpTracker) sentMessage(txHash common.Hash, messageID ids.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if issuedAt, ok := t.sendIssuedAt[txHash]; ok {
+		delete(t.sendIssuedAt, txHash)
+		t.messageSentAt[messageID] = issuedAt
+	}
+}
+
+// delivering records that the delivery tx [txHash] delivers the message [messageID].
+func (t *warpTracker) delivering(txHash common.Hash, messageID ids.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.deliveries[txHash] = messageID
+}
+
+// confirmedDelivery observes the delivery latency of the message delivered by the delivery tx [txHash],
+// which was just confirmed.
+func (t *warpTracker) confirmedDelivery(txHash common.Hash) {
+	t.lock.Lock()
+	messageID, ok := t.deliveries[txHash]
+	delete(t.deliveries, txHash)
+	sentAt, sent := t.messageSentAt[messageID]
+	delete(t.messageSentAt, messageID)
+	t.lock.Unlock()
+	if ok && sent {
+		t.latency.Observe(time.Since(sentAt).Seconds())
+	}
+}
+
+// warpSendWorker wraps a Worker issuing send txs to record their issuance in a warpTracker.
+type warpSendWorker struct {
+	txs.Worker[*types.Transaction]
+	tracker *warpTracker
+}
+
+func (w *warpSendWorker) IssueTx(ctx context.Context, tx *types.Transaction) error {
+	w.tracker.issuingSend(tx.Hash())
+	return w.Worker.IssueTx(ctx, tx)
+}
+
+func (w *warpSendWorker) Forget(txHash common.Hash) {
+	txs.Forget(w.Worker, txHash)
+}
+
+// warpDeliverWorker wraps a Worker confirming delivery txs to record their confirmation in a warpTracker.
+type warpDeliverWorker struct {
+	txs.Worker[*types.Transaction]
+	tracker *warpTracker
+}
+
+func (w *warpDeliverWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	if err := w.Worker.ConfirmTx(ctx, tx); err != nil {
+		return err
+	}
+	w.tracker.confirmedDelivery(tx.Hash())
+	return nil
+}
+
+func (w *warpDeliverWorker) Forget(txHash common.Hash) {
+	txs.Forget(w.Worker, txHash)
+}
+
+// warpPairWorkers holds the workers sending and delivering the warp messages of a warp pair.
+type warpPairWorkers struct {
+	pair       config.WarpPair
+	senders    []int // Indices of the workers sending warp messages on the source blockchain
+	deliverers []int // Indices of the workers delivering warp messages on the destination blockchain
+}
+
+// executeWarpPairs sends warp messages between each warp pair of the topology in [c.TopologyFile]. For
+// each warp pair, [c.Workers] workers send [workerTxCounts(c)] warp messages each on the source blockchain,
+// and as many workers deliver each sent message on the destination blockchain, concurrently with sending.
+//
+// Every blockchain of a warp pair must be reachable and serve its configured blockchain ID on every
+// endpoint before any key is funded.
+func executeWarpPairs(ctx context.Context, c config.Config, m *metrics.Metrics) (*LoadResult, error) {
+	topology, err := config.LoadTopology(c.TopologyFile)
+	if err != nil {
+		return nil, err
+	}
+	subnets := topology.PairedSubnets()
+	blockchains := make(map[string]*warpBlockchain, len(subnets))
+	var (
+		readyClients []ethclient.Client
+		healthURIs   = slices.Clone(c.HealthEndpoints)
+	)
+	for _, subnet := range subnets {
+		blockchain, err := dialWarpBlockchain(subnet)
+		if err != nil {
+			return nil, err
+		}
+		blockchains[subnet.Name] = blockchain
+		readyClients = append(readyClients, blockchain.clients...)
+		if subnet.NodeURI != "" {
+			healthURIs = append(healthURIs, subnet.NodeURI)
+		}
+	}
+	if c.ReadinessTimeout > 0 {
+		log.Info("Waiting for blockchains to be ready", "blockchains", len(subnets), "timeout", c.ReadinessTimeout)
+		if err := AwaitReady(ctx, readyClients, healthURIs, c.ReadinessTimeout); err != nil {
+			return nil, err
+		}
+	}
+	for _, subnet := range subnets {
+		if err := blockchains[subnet.Name].setup(ctx, c); err != nil {
+			return nil, err
+		}
+	}
+
+	// Each warp pair needs [c.Workers] keys on its source and destination blockchains, which must be
+	// distinct on each blockchain so that the workers do not share nonces.
+	numKeys := make(map[string]int, len(subnets))
+	maxNumKeys := 0
+	for _, pair := range topology.WarpPairs {
+		numKeys[pair.Source] += c.Workers
+		numKeys[pair.Destination] += c.Workers
+		maxNumKeys = max(maxNumKeys, numKeys[pair.Source], numKeys[pair.Destination])
+	}
+	keys, err := loadOrGenerateKeys(ctx, c.KeyDir, c.KeyPassphrase, maxNumKeys)
+	if err != nil {
+		return nil, err
+	}
+	txCounts := workerTxCounts(c)
+	gasFeeCap := new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(c.MaxFeeCap))
+	gasTipCap := new(big.Int).Mul(big.NewInt(params.GWei), big.NewInt(c.MaxTipCap))
+	for _, subnet := range subnets {
+		blockchain := blockchains[subnet.Name]
+		fundedKeys := keys[:numKeys[subnet.Name]]
+		if c.SkipFunding {
+			log.Info("Skipping fund distribution", "subnet", subnet.Name, "numKeys", len(fundedKeys))
+		} else {
+			// Fund every key for delivery txs, which have the highest gas limit.
+			maxTxFee := new(big.Int).Mul(gasFeeCap, big.NewInt(warpDeliverGasLimit))
+			minFundsPerAddr := new(big.Int).Mul(maxTxFee, new(big.Int).SetUint64(txCounts[0]))
+			fundStart := time.Now()
+			log.Info("Distributing funds", "subnet", subnet.Name, "numKeys", numKeys[subnet.Name], "minFunds", minFundsPerAddr)
+			fundedKeys, err = DistributeFunds(ctx, blockchain.clients[0], keys, numKeys[subnet.Name], minFundsPerAddr, m, fundingOptions(c))
+			if err != nil {
+				return nil, fmt.Errorf("failed to distribute funds on %s: %w", subnet.Name, err)
+			}
+			log.Info("Distributed funds successfully", "subnet", subnet.Name, "time", time.Since(fundStart))
+		}
+		for _, key := range fundedKeys[:numKeys[subnet.Name]] {
+			blockchain.keys = append(blockchain.keys, key.PrivKey)
+		}
+	}
+
+	// Cancelled once the execution completes, or with the cause of a failure to generate a delivery tx.
+	generateCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		workers     []txs.Worker[*types.Transaction]
+		txSequences []txs.TxSequence[*types.Transaction]
+		pairWorkers = make([]warpPairWorkers, 0, len(topology.WarpPairs))
+	)
+	newWorker := func(client ethclient.Client, key *ecdsa.PrivateKey) txs.Worker[*types.Transaction] {
+		address := ethcrypto.PubkeyToAddress(key.PublicKey)
+		if c.ConfirmByReceipt {
+			address = common.Address{}
+		}
+		return newMempoolWorker(injectLatency(c, txs.Worker[*types.Transaction](newEthereumTxWorker(ctx, client, client, address))), m)
+	}
+	for _, pair := range topology.WarpPairs {
+		source := blockchains[pair.Source]
+		destination := blockchains[pair.Destination]
+		senderKeys := source.takeKeys(c.Workers)
+		delivererKeys := destination.takeKeys(c.Workers)
+		pw := warpPairWorkers{pair: pair}
+		tracker := newWarpTracker(m.WarpDeliveryLatency.WithLabelValues(pair.String()))
+
+		// Subscribe to the warp messages of the senders of the pair before sending any of them.
+		senderTopics := make([]common.Hash, 0, len(senderKeys))
+		numMessages := uint64(0)
+		for i, key := range senderKeys {
+			senderTopics = append(senderTopics, common.BytesToHash(ethcrypto.PubkeyToAddress(key.PublicKey).Bytes()))
+			numMessages += txCounts[i]
+		}
+		logs := make(chan types.Log, numMessages)
+		sub, err := source.clients[0].SubscribeFilterLogs(ctx, interfaces.FilterQuery{
+			Addresses: []common.Address{warp.Module.Address},
+			Topics:    [][]common.Hash{{warp.WarpABI.Events["SendWarpMessage"].ID}, senderTopics},
+		}, logs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe to warp messages of %s: %w", pair, err)
+		}
+		defer sub.Unsubscribe()
+
+		log.Info("Creating warp message sequences...", "pair", pair)
+		sendGenerator := &warpSendTxGenerator{
+			chainID:   source.chainID,
+			txSigner:  source.txSigner,
+			gasFeeCap: gasFeeCap,
+			gasTipCap: gasTipCap,
+		}
+		sendTxSequences, err := generateTxSequences(ctx, sendGenerator, source.clients[0], senderKeys, txCounts, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate warp messages of %s: %w", pair, err)
+		}
+		for i, key := range senderKeys {
+			client := source.clients[i%len(source.clients)]
+			worker := newWorker(client, key)
+			if c.ConfirmByLog {
+				worker = newExpectedLogWorker(worker, client, sendGenerator.expectedLog(), m)
+			}
+			pw.senders = append(pw.senders, len(workers))
+			workers = append(workers, &warpSendWorker{
+				Worker:  worker,
+				tracker: tracker,
+			})
+			txSequences = append(txSequences, sendTxSequences[i])
+		}
+
+		warpClient, err := warpBackend.NewClient(source.NodeURI, source.BlockchainID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create warp client of %s at %s: %w", pair.Source, source.NodeURI, err)
+		}
+		// Messages sent from the Primary Network are signed by the validators of the destination subnet.
+		signingSubnetID := ""
+		if source.SubnetID == ids.Empty {
+			signingSubnetID = destination.SubnetID.String()
+		}
+		input, err := warp.PackGetVerifiedWarpMessage(0)
+		if err != nil {
+			return nil, err
+		}
+		deliverGenerator := &warpDeliverTxGenerator{
+			ctx:             generateCtx,
+			cancel:          cancel,
+			logs:            logs,
+			subErr:          sub.Err(),
+			warpClient:      warpClient,
+			signingSubnetID: signingSubnetID,
+			input:           input,
+			chainID:         destination.chainID,
+			txSigner:        destination.txSigner,
+			gasFeeCap:       gasFeeCap,
+			gasTipCap:       gasTipCap,
+			tracker:         tracker,
+		}
+		for i, key := range delivererKeys {
+			// Delivery txs are generated in the background as the messages they deliver are sent.
+			txSequence, err := txs.GenerateTxSequence(generateCtx, deliverGenerator.GenerateTx, destination.clients[0], key, txCounts[i], true)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate warp deliveries of %s: %w", pair, err)
+			}
+			pw.deliverers = append(pw.deliverers, len(workers))
+			workers = append(workers, &warpDeliverWorker{
+				Worker:  newWorker(destination.clients[i%len(destination.clients)], key),
+				tracker: tracker,
+			})
+			txSequences = append(txSequences, txSequence)
+		}
+		pairWorkers = append(pairWorkers, pw)
+	}
+
+	workers, resultWorkers := trackResults(workers)
+	// Every worker must execute concurrently, since delivery txs wait for the messages of the senders.
+	opts := loaderOptions(c)
+	opts.VerboseWorkers = func(worker int) bool {
+		return c.IsVerboseWorker(worker % c.Workers)
+	}
+	loader := New(workers, txSequences, c.BatchSize, m, opts)
+	log.Info("Sending warp messages", "pairs", len(topology.WarpPairs), "workersPerPair", 2*c.Workers)
+	stopReports := reportProgress(c, m)
+	err = loader.Execute(ctx)
+	stopReports()
+	executeDuration := time.Since(loader.StartTime())
+	if err == nil && generateCtx.Err() != nil {
+		// A failed delivery ends the sequence of its worker early without failing the execution.
+		err = context.Cause(generateCtx)
+	}
+	if err == nil {
+		if lerr := m.LogTPSBreakdown(); lerr != nil {
+			log.Warn("Failed to log TPS breakdown", "error", lerr)
+		}
+	}
+	if lerr := m.LogFailures(); lerr != nil {
+		log.Warn("Failed to log failed txs", "error", lerr)
+	}
+	if prerr := m.Print(c.MetricsOutput); prerr != nil { // Print regardless of execution error
+		log.Warn("Failed to print metrics", "error", prerr)
+	}
+	result, rerr := newLoadResult(executeDuration, resultWorkers, loader.FinalBatchSizes(), m)
+	if rerr != nil {
+		log.Warn("Failed to compute load result", "error", rerr)
+	}
+	if result != nil {
+		latencyQuantiles, qerr := m.WarpDeliveryLatencyQuantiles()
+		if qerr != nil {
+			log.Warn("Failed to compute warp delivery latencies", "error", qerr)
+		}
+		addWarpPairResults(result, pairWorkers, latencyQuantiles)
+	}
+	return result, err
+}
+
+// addWarpPairResults adds the outcome of the warp messages of each of [pairWorkers] to [result], with the
+// delivery latency quantiles in seconds of each warp pair in [latencyQuantiles], and logs a summary of
+// each warp pair.
+func addWarpPairResults(result *LoadResult, pairWorkers []warpPairWorkers, latencyQuantiles map[string]map[float64]float64) {
+	result.WarpPairs = make(map[string]*WarpPairResult, len(pairWorkers))
+	for _, pw := range pairWorkers {
+		pairResult := &WarpPairResult{
+			DeliveryLatencyQuantiles: make(map[float64]time.Duration, len(metrics.WarpDeliveryQuantiles)),
+		}
+		for quantile, seconds := range latencyQuantiles[pw.pair.String()] {
+			pairResult.DeliveryLatencyQuantiles[quantile] = time.Duration(seconds * float64(time.Second))
+		}
+		for _, i := range pw.senders {
+			pairResult.SentMessages += result.Workers[i].ConfirmedTxs
+			pairResult.IssuanceFailures += result.Workers[i].IssuanceFailures
+			pairResult.ConfirmationFailures += result.Workers[i].ConfirmationFailures
+		}
+		for _, i := range pw.deliverers {
+			pairResult.DeliveredMessages += result.Workers[i].ConfirmedTxs
+			pairResult.IssuanceFailures += result.Workers[i].IssuanceFailures
+			pairResult.ConfirmationFailures += result.Workers[i].ConfirmationFailures
+		}
+		if result.Duration > 0 {
+			pairResult.DeliveryTPS = float64(pairResult.DeliveredMessages) / result.Duration.Seconds()
+		}
+		result.WarpPairs[pw.pair.String()] = pairResult
+		log.Info("Warp pair summary", "pair", pw.pair, "sent", pairResult.SentMessages, "delivered", pairResult.DeliveredMessages,
+			"deliveryTPS", pairResult.DeliveryTPS,
+			"p50", pairResult.DeliveryLatencyQuantiles[0.5],
+			"p95", pairResult.DeliveryLatencyQuantiles[0.95],
+			"p99", pairResult.DeliveryLatencyQuantiles[0.99])
+	}
+}
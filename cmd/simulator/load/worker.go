@@ -6,20 +6,44 @@ package load
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/ethclient"
 	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 type ethereumTxWorker struct {
 	client ethclient.Client
-
-	acceptedNonce uint64
+	// The client txs are confirmed on, which may be a different node than [client] on the same chain.
+	confirmClient ethclient.Client
 	address       common.Address
+	// Holds the raw bytes of pre-signed txs, which are sent directly instead of encoding the txs, or nil.
+	rawTxs *txs.RawTxStore
+	// Bound the concurrent RPCs to the endpoints of [client] and [confirmClient] shared with other workers,
+	// or nil.
+	issueLimiter   *endpointLimiter
+	confirmLimiter *endpointLimiter
+
+	// Guards the fields below, since ConfirmTx may be called concurrently.
+	lock sync.Mutex
+	// Txs issued and not yet known to be confirmed when confirming by receipt, so that the receipts of
+	// the whole batch can be fetched in a single batch RPC call, and txs known to be confirmed that
+	// ConfirmTx has not been called for yet. Both only hold the txs the agent is still confirming,
+	// since it confirms or forgets every tx it issued.
+	pending   []common.Hash
+	confirmed set.Set[common.Hash]
+	// Set if the endpoint rejected a batch RPC call, in which case receipts are fetched individually.
+	batchUnsupported bool
+	// Number of txs confirmed by receipt and the number of RPC round trips it took to confirm them.
+	receiptConfirmedTxs uint64
+	receiptRoundTrips   uint64
 
 	sub      interfaces.Subscription
 	newHeads chan *types.Header
@@ -28,33 +52,33 @@ type ethereumTxWorker struct {
 // NewSingleAddressTxWorker creates and returns a new ethereumTxWorker that confirms transactions by checking the latest
 // nonce of [address] and assuming any transaction with a lower nonce was already accepted.
 func NewSingleAddressTxWorker(ctx context.Context, client ethclient.Client, address common.Address) *ethereumTxWorker {
-	newHeads := make(chan *types.Header)
-	tw := &ethereumTxWorker{
-		client:   client,
-		address:  address,
-		newHeads: newHeads,
-	}
-
-	sub, err := client.SubscribeNewHead(ctx, newHeads)
-	if err != nil {
-		log.Debug("failed to subscribe new heads, falling back to polling", "err", err)
-	} else {
-		tw.sub = sub
-	}
-
-	return tw
+	return newEthereumTxWorker(ctx, client, client, address)
 }
 
 // NewTxReceiptWorker creates and returns a new ethereumTxWorker that confirms transactions by checking for the
 // corresponding transaction receipt.
+// The receipts of the issued transactions still being confirmed are fetched with a single batch RPC call, falling
+// back to fetching each receipt individually if the endpoint does not support batch calls.
 func NewTxReceiptWorker(ctx context.Context, client ethclient.Client) *ethereumTxWorker {
+	return newEthereumTxWorker(ctx, client, client, common.Address{})
+}
+
+// newEthereumTxWorker creates and returns a new ethereumTxWorker that issues transactions to [client] and
+// confirms them on [confirmClient]. If [address] is empty, transactions are confirmed by their receipts as
+// NewTxReceiptWorker, and otherwise by the nonce of [address] as NewSingleAddressTxWorker.
+func newEthereumTxWorker(ctx context.Context, client ethclient.Client, confirmClient ethclient.Client, address common.Address) *ethereumTxWorker {
 	newHeads := make(chan *types.Header)
 	tw := &ethereumTxWorker{
-		client:   client,
-		newHeads: newHeads,
+		client:        client,
+		confirmClient: confirmClient,
+		address:       address,
+		newHeads:      newHeads,
+	}
+	if address == (common.Address{}) {
+		tw.confirmed = set.NewSet[common.Hash](0)
 	}
 
-	sub, err := client.SubscribeNewHead(ctx, newHeads)
+	sub, err := confirmClient.SubscribeNewHead(ctx, newHeads)
 	if err != nil {
 		log.Debug("failed to subscribe new heads, falling back to polling", "err", err)
 	} else {
@@ -65,6 +89,30 @@ func NewTxReceiptWorker(ctx context.Context, client ethclient.Client) *ethereumT
 }
 
 func (tw *ethereumTxWorker) IssueTx(ctx context.Context, tx *types.Transaction) error {
+	if err := tw.sendTx(ctx, tx); err != nil {
+		return err
+	}
+	if tw.address == (common.Address{}) {
+		tw.lock.Lock()
+		tw.pending = append(tw.pending, tx.Hash())
+		tw.lock.Unlock()
+	}
+	return nil
+}
+
+// sendTx sends [tx] to [tw.client], sending its raw bytes directly if it was pre-signed so that it
+// is not encoded while issuing it.
+func (tw *ethereumTxWorker) sendTx(ctx context.Context, tx *types.Transaction) error {
+	if err := tw.issueLimiter.acquire(ctx); err != nil {
+		return err
+	}
+	defer tw.issueLimiter.release()
+
+	if tw.rawTxs != nil {
+		if raw, ok := tw.rawTxs.Take(tx.Hash()); ok {
+			return tw.client.Client().CallContext(ctx, nil, "eth_sendRawTransaction", raw)
+		}
+	}
 	return tw.client.SendTransaction(ctx, tx)
 }
 
@@ -79,15 +127,14 @@ func (tw *ethereumTxWorker) confirmTxByNonce(ctx context.Context, tx *types.Tran
 	txNonce := tx.Nonce()
 
 	for {
-		acceptedNonce, err := tw.client.NonceAt(ctx, tw.address, nil)
+		acceptedNonce, err := tw.acceptedNonce(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to await tx %s nonce %d: %w", tx.Hash(), txNonce, err)
 		}
-		tw.acceptedNonce = acceptedNonce
 
-		log.Debug("confirming tx", "txHash", tx.Hash(), "txNonce", txNonce, "acceptedNonce", tw.acceptedNonce)
+		log.Debug("confirming tx", "txHash", tx.Hash(), "txNonce", txNonce, "acceptedNonce", acceptedNonce)
 		// If the is less than what has already been accepted, the transaction is confirmed
-		if txNonce < tw.acceptedNonce {
+		if txNonce < acceptedNonce {
 			return nil
 		}
 
@@ -100,13 +147,24 @@ func (tw *ethereumTxWorker) confirmTxByNonce(ctx context.Context, tx *types.Tran
 	}
 }
 
+// acceptedNonce returns the nonce of [tw.address] accepted on [tw.confirmClient].
+func (tw *ethereumTxWorker) acceptedNonce(ctx context.Context) (uint64, error) {
+	if err := tw.confirmLimiter.acquire(ctx); err != nil {
+		return 0, err
+	}
+	defer tw.confirmLimiter.release()
+
+	return tw.confirmClient.NonceAt(ctx, tw.address, nil)
+}
+
 func (tw *ethereumTxWorker) confirmTxByReceipt(ctx context.Context, tx *types.Transaction) error {
+	txHash := tx.Hash()
 	for {
-		_, err := tw.client.TransactionReceipt(ctx, tx.Hash())
-		if err == nil {
+		confirmed, err := tw.tryConfirmTxByReceipt(ctx, txHash)
+		if confirmed {
 			return nil
 		}
-		log.Debug("no tx receipt", "txHash", tx.Hash(), "nonce", tx.Nonce(), "err", err)
+		log.Debug("no tx receipt", "txHash", txHash, "nonce", tx.Nonce(), "err", err)
 
 		select {
 		case <-tw.newHeads:
@@ -117,6 +175,107 @@ func (tw *ethereumTxWorker) confirmTxByReceipt(ctx context.Context, tx *types.Tr
 	}
 }
 
+// tryConfirmTxByReceipt returns true if [txHash] is confirmed, fetching the receipts of the pending
+// txs if it is not known to be confirmed yet.
+func (tw *ethereumTxWorker) tryConfirmTxByReceipt(ctx context.Context, txHash common.Hash) (bool, error) {
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+
+	if !tw.confirmed.Contains(txHash) {
+		if err := tw.fetchReceipts(ctx, txHash); err != nil || !tw.confirmed.Contains(txHash) {
+			return false, err
+		}
+	}
+	tw.confirmed.Remove(txHash)
+	tw.receiptConfirmedTxs++
+	return true, nil
+}
+
+// Forget drops [txHash] from the txs whose receipts are fetched, so that the receipt of a tx the agent
+// will not confirm is not fetched with the receipts of every later batch.
+func (tw *ethereumTxWorker) Forget(txHash common.Hash) {
+	if tw.address != (common.Address{}) {
+		return
+	}
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+
+	tw.confirmed.Remove(txHash)
+	tw.removePending(txHash)
+}
+
+// fetchReceipts marks every pending tx with a receipt as confirmed. The receipts of all pending txs
+// are fetched with a single batch RPC call unless batch calls are unsupported, in which case only
+// the receipt of [txHash] is fetched. Assumes [tw.lock] is held.
+func (tw *ethereumTxWorker) fetchReceipts(ctx context.Context, txHash common.Hash) error {
+	if err := tw.confirmLimiter.acquire(ctx); err != nil {
+		return err
+	}
+	defer tw.confirmLimiter.release()
+
+	if !tw.batchUnsupported && len(tw.pending) > 1 {
+		receipts := make([]*types.Receipt, len(tw.pending))
+		reqs := make([]rpc.BatchElem, len(tw.pending))
+		for i, pendingHash := range tw.pending {
+			reqs[i] = rpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []interface{}{pendingHash},
+				Result: &receipts[i],
+			}
+		}
+		tw.receiptRoundTrips++
+		err := tw.confirmClient.Client().BatchCallContext(ctx, reqs)
+		if err == nil {
+			stillPending := tw.pending[:0]
+			for i, pendingHash := range tw.pending {
+				if reqs[i].Error == nil && receipts[i] != nil {
+					tw.confirmed.Add(pendingHash)
+				} else {
+					stillPending = append(stillPending, pendingHash)
+				}
+			}
+			tw.pending = stillPending
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		log.Debug("batch receipt request failed, falling back to individual requests", "err", err)
+		tw.batchUnsupported = true
+	}
+
+	tw.receiptRoundTrips++
+	if _, err := tw.confirmClient.TransactionReceipt(ctx, txHash); err != nil {
+		return err
+	}
+	tw.confirmed.Add(txHash)
+	tw.removePending(txHash)
+	return nil
+}
+
+// removePending removes [txHash] from [tw.pending]. Assumes [tw.lock] is held.
+func (tw *ethereumTxWorker) removePending(txHash common.Hash) {
+	for i, pendingHash := range tw.pending {
+		if pendingHash == txHash {
+			tw.pending = append(tw.pending[:i], tw.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// ReceiptRoundTrips returns the number of txs confirmed by receipt and the number of RPC round trips
+// made to fetch their receipts.
+func (tw *ethereumTxWorker) ReceiptRoundTrips() (uint64, uint64) {
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+	return tw.receiptConfirmedTxs, tw.receiptRoundTrips
+}
+
 func (tw *ethereumTxWorker) LatestHeight(ctx context.Context) (uint64, error) {
-	return tw.client.BlockNumber(ctx)
+	if err := tw.confirmLimiter.acquire(ctx); err != nil {
+		return 0, err
+	}
+	defer tw.confirmLimiter.release()
+
+	return tw.confirmClient.BlockNumber(ctx)
 }
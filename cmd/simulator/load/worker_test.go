@@ -0,0 +1,103 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/txs"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// receiptService serves a receipt for every tx sent to it, counting the receipts requested.
+type receiptService struct {
+	lock      sync.Mutex
+	sent      map[common.Hash]bool
+	requested int
+}
+
+func (s *receiptService) SendRawTransaction(input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sent[tx.Hash()] = true
+	return tx.Hash(), nil
+}
+
+func (s *receiptService) GetTransactionReceipt(txHash common.Hash) (*types.Receipt, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requested++
+	if !s.sent[txHash] {
+		return nil, nil
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: txHash, Logs: []*types.Log{}}, nil
+}
+
+func TestReceiptWorkerForget(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	service := &receiptService{sent: make(map[common.Hash]bool)}
+	server := rpc.NewServer(0)
+	require.NoError(server.RegisterName("eth", service))
+	defer server.Stop()
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	worker := NewTxReceiptWorker(ctx, client)
+
+	const numTxs = 10
+	issued := make([]*types.Transaction, 0, numTxs)
+	for i := uint64(0); i < numTxs; i++ {
+		tx := types.NewTx(&types.LegacyTx{Nonce: i})
+		require.NoError(worker.IssueTx(ctx, tx))
+		issued = append(issued, tx)
+	}
+	require.Len(worker.pending, numTxs)
+
+	// Confirm only the first half of the txs, fetching the receipts of every pending tx in one batch.
+	for _, tx := range issued[:numTxs/2] {
+		require.NoError(worker.ConfirmTx(ctx, tx))
+	}
+	require.Empty(worker.pending)
+	require.Equal(numTxs/2, worker.confirmed.Len())
+
+	// Forget the txs that are not confirmed, as the agent does for unsampled or abandoned txs.
+	for _, tx := range issued[numTxs/2:] {
+		txs.Forget[*types.Transaction](worker, tx.Hash())
+	}
+	require.Empty(worker.pending)
+	require.Zero(worker.confirmed.Len())
+
+	// A forgotten tx whose receipt was not fetched yet is dropped from the pending txs.
+	forgotten := types.NewTx(&types.LegacyTx{Nonce: numTxs})
+	require.NoError(worker.IssueTx(ctx, forgotten))
+	txs.Forget[*types.Transaction](worker, forgotten.Hash())
+	require.Empty(worker.pending)
+
+	// The receipts of the next batch do not include those of the forgotten txs.
+	requested := service.requested
+	next := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: numTxs + 1}),
+		types.NewTx(&types.LegacyTx{Nonce: numTxs + 2}),
+	}
+	for _, tx := range next {
+		require.NoError(worker.IssueTx(ctx, tx))
+	}
+	for _, tx := range next {
+		require.NoError(worker.ConfirmTx(ctx, tx))
+	}
+	require.Equal(requested+len(next), service.requested)
+	require.Empty(worker.pending)
+	require.Zero(worker.confirmed.Len())
+}
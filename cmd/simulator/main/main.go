@@ -16,8 +16,14 @@ import (
 )
 
 func main() {
+	args := os.Args[1:]
+	fundKeys := len(args) > 0 && args[0] == config.FundKeysCommand
+	if fundKeys {
+		args = args[1:]
+	}
+
 	fs := config.BuildFlagSet()
-	v, err := config.BuildViper(fs, os.Args[1:])
+	v, err := config.BuildViper(fs, args)
 	if errors.Is(err, pflag.ErrHelp) {
 		os.Exit(0)
 	}
@@ -42,13 +48,30 @@ func main() {
 		fmt.Printf("couldn't parse log level: %s\n", err)
 		os.Exit(1)
 	}
-	log.Root().SetHandler(log.LvlFilterHandler(logLevel, log.StreamHandler(os.Stderr, log.TerminalFormat(true))))
+	var logFormat log.Format
+	switch format := v.GetString(config.LogFormatKey); format {
+	case config.TextLogFormat:
+		logFormat = log.TerminalFormat(true)
+	case config.JSONLogFormat:
+		logFormat = log.JSONFormat()
+	default:
+		fmt.Printf("invalid log format %q, must be %q or %q\n", format, config.TextLogFormat, config.JSONLogFormat)
+		os.Exit(1)
+	}
+	log.Root().SetHandler(log.LvlFilterHandler(logLevel, log.StreamHandler(os.Stderr, logFormat)))
 
 	config, err := config.BuildConfig(v)
 	if err != nil {
 		fmt.Printf("%s\n", err)
 		os.Exit(1)
 	}
+	if fundKeys {
+		if err := load.ExecuteFundKeys(context.Background(), config); err != nil {
+			fmt.Printf("fund keys failed: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 	if err := load.ExecuteLoader(context.Background(), config); err != nil {
 		fmt.Printf("load execution failed: %s\n", err)
 		os.Exit(1)
@@ -8,12 +8,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
 )
 
 type Metrics struct {
@@ -24,8 +28,62 @@ type Metrics struct {
 	ConfirmationTxTimes prometheus.Summary
 	// Summary of the quantiles of Individual Issuance To Confirmation Tx Times
 	IssuanceToConfirmationTxTimes prometheus.Summary
+	// Sum over all agents of the TPS each agent would achieve if it were only limited by issuance
+	IssuanceLimitedTPS prometheus.Gauge
+	// Sum over all agents of the TPS each agent would achieve if it were only limited by confirmation
+	ConfirmationLimitedTPS prometheus.Gauge
+	// Total number of blobs carried by confirmed txs
+	BlobsConfirmed prometheus.Counter
+	// Total blob gas used by confirmed txs
+	BlobGasUsed prometheus.Counter
+	// Blobs confirmed per second over the execution of a load test
+	BlobsPerSecond prometheus.Gauge
+	// Number of txs that failed to issue
+	IssuanceFailures prometheus.Counter
+	// Number of txs that failed to confirm
+	ConfirmationFailures prometheus.Counter
+	// Number of txs left unconfirmed once the max confirm wait elapsed after the last tx was issued
+	UnconfirmedTxs prometheus.Counter
+	// Number of issued txs that were not confirmed since they were not sampled by the confirm sample rate
+	UnsampledTxs prometheus.Counter
+	// Number of confirmed txs whose receipt did not contain the log expected by their workload
+	ExpectedLogMismatches prometheus.Counter
+	// Number of txs rejected by the mempool by reason
+	MempoolRejections *prometheus.CounterVec
+	// Summary of the quantiles of Individual Tx Times from acceptance into the mempool to confirmation
+	MempoolToConfirmationTxTimes prometheus.Summary
+	// Number of funding txs that were re-issued after failing to fund their address
+	FundingRetries prometheus.Counter
+	// Summary of the quantiles of Individual Issuance To Confirmation Tx Times by fee tier
+	FeeTierIssuanceToConfirmationTxTimes *prometheus.SummaryVec
+	// Summary of the quantiles of Individual Issuance To Confirmation Tx Times by tx type of a tx mix
+	TxTypeIssuanceToConfirmationTxTimes *prometheus.SummaryVec
+	// Number of times the fees of a tx were bumped after it was rejected as underpriced
+	FeeBumps prometheus.Counter
+	// Number of times a confirmed tx was reorged out of its block before reaching the required depth
+	ReorgedTxs prometheus.Counter
+	// Histogram of the gas used by Individual Confirmed Txs
+	GasUsed prometheus.Histogram
+	// Histogram of the effective tip in GWei paid by Individual Confirmed Txs
+	EffectiveTip prometheus.Histogram
+	// Histogram of the time from the issuance of the tx sending each warp message to the confirmation of the
+	// tx delivering it, by warp pair
+	WarpDeliveryLatency *prometheus.HistogramVec
+	// Number of RPCs that waited for the concurrency limit of their endpoint, by endpoint
+	EndpointLimitWaits *prometheus.CounterVec
+	// Total time RPCs waited for the concurrency limit of their endpoint in seconds, by endpoint
+	EndpointLimitWaitTime *prometheus.CounterVec
+	// Histogram of the ratio of gas used to gas limit of the blocks produced during a load test
+	BlockGasUtilization prometheus.Histogram
+	// Histogram of the number of txs of the blocks produced during a load test
+	BlockTxs prometheus.Histogram
+	// Histogram of the time between the blocks produced during a load test
+	BlockTime prometheus.Histogram
 }
 
+// WarpDeliveryQuantiles are the quantiles of the warp delivery latency reported in the summary of a load test.
+var WarpDeliveryQuantiles = []float64{0.5, 0.95, 0.99}
+
 func NewDefaultMetrics() *Metrics {
 	registry := prometheus.NewRegistry()
 	return NewMetrics(registry)
@@ -50,26 +108,384 @@ func NewMetrics(reg *prometheus.Registry) *Metrics {
 			Help:       "Individual Tx Issuance To Confirmation Times for a Load Test",
 			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 		}),
+		IssuanceLimitedTPS: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "tps_issuance_limited",
+			Help: "Issuance-Limited TPS (Confirmed Txs / Total Issuance Time) Summed Across Agents for a Load Test",
+		}),
+		ConfirmationLimitedTPS: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "tps_confirmation_limited",
+			Help: "Confirmation-Limited TPS (Confirmed Txs / Total Confirmation Time) Summed Across Agents for a Load Test",
+		}),
+		BlobsConfirmed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "blobs_confirmed",
+			Help: "Number of Blobs Carried by Confirmed Txs for a Load Test",
+		}),
+		BlobGasUsed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "blob_gas_used",
+			Help: "Blob Gas Used by Confirmed Txs for a Load Test",
+		}),
+		BlobsPerSecond: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "blobs_per_second",
+			Help: "Blobs Confirmed per Second for a Load Test",
+		}),
+		IssuanceFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tx_issuance_failures",
+			Help: "Number of Txs that Failed to Issue for a Load Test",
+		}),
+		ConfirmationFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tx_confirmation_failures",
+			Help: "Number of Txs that Failed to Confirm for a Load Test",
+		}),
+		UnconfirmedTxs: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tx_unconfirmed",
+			Help: "Number of Txs Left Unconfirmed at the Max Confirm Wait for a Load Test",
+		}),
+		UnsampledTxs: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tx_unsampled",
+			Help: "Number of Issued Txs Not Sampled for Confirmation for a Load Test",
+		}),
+		ExpectedLogMismatches: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tx_expected_log_mismatches",
+			Help: "Number of Confirmed Txs Missing the Log Expected by their Workload for a Load Test",
+		}),
+		MempoolRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tx_mempool_rejections",
+			Help: "Number of Txs Rejected by the Mempool by Reason for a Load Test",
+		}, []string{"reason"}),
+		MempoolToConfirmationTxTimes: prometheus.NewSummary(prometheus.SummaryOpts{
+			Name:       "tx_mempool_to_confirmation_time",
+			Help:       "Individual Tx Mempool Acceptance To Confirmation Times for a Load Test",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}),
+		FundingRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "funding_tx_retries",
+			Help: "Number of Funding Txs Re-Issued for a Load Test",
+		}),
+		FeeTierIssuanceToConfirmationTxTimes: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Name:       "tx_fee_tier_issuance_to_confirmation_time",
+			Help:       "Individual Tx Issuance To Confirmation Times by Fee Tier for a Load Test",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}, []string{"tier"}),
+		TxTypeIssuanceToConfirmationTxTimes: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Name:       "tx_type_issuance_to_confirmation_time",
+			Help:       "Individual Tx Issuance To Confirmation Times by Tx Type for a Load Test",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}, []string{"type"}),
+		FeeBumps: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tx_fee_bumps",
+			Help: "Number of Txs Re-Issued with Bumped Fees after being Rejected as Underpriced for a Load Test",
+		}),
+		ReorgedTxs: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tx_reorgs",
+			Help: "Number of Times Confirmed Txs were Reorged Out of their Block for a Load Test",
+		}),
+		GasUsed: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "tx_gas_used",
+			Help:    "Gas Used by Individual Confirmed Txs for a Load Test",
+			Buckets: prometheus.ExponentialBuckets(21_000, 2, 10),
+		}),
+		EffectiveTip: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "tx_effective_tip",
+			Help:    "Effective Tip in GWei Paid by Individual Confirmed Txs for a Load Test",
+			Buckets: prometheus.ExponentialBuckets(0.01, 4, 10),
+		}),
+		WarpDeliveryLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "warp_delivery_latency",
+			Help:    "Individual Warp Message Send Issuance To Delivery Confirmation Times in Seconds by Warp Pair for a Load Test",
+			Buckets: prometheus.ExponentialBuckets(0.25, 1.5, 20),
+		}, []string{"pair"}),
+		EndpointLimitWaits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "endpoint_limit_waits",
+			Help: "Number of RPCs that Waited for the Concurrency Limit of their Endpoint by Endpoint for a Load Test",
+		}, []string{"endpoint"}),
+		EndpointLimitWaitTime: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "endpoint_limit_wait_seconds",
+			Help: "Total Time RPCs Waited for the Concurrency Limit of their Endpoint in Seconds by Endpoint for a Load Test",
+		}, []string{"endpoint"}),
+		BlockGasUtilization: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "block_gas_utilization",
+			Help:    "Ratio of Gas Used to Gas Limit of Individual Blocks Produced during a Load Test",
+			Buckets: prometheus.LinearBuckets(0.1, 0.1, 10),
+		}),
+		BlockTxs: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "block_txs",
+			Help:    "Number of Txs of Individual Blocks Produced during a Load Test",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+		}),
+		BlockTime: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "block_time",
+			Help:    "Time in Seconds between Individual Blocks Produced during a Load Test",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 8),
+		}),
 	}
 	reg.MustRegister(m.IssuanceTxTimes)
 	reg.MustRegister(m.ConfirmationTxTimes)
 	reg.MustRegister(m.IssuanceToConfirmationTxTimes)
+	reg.MustRegister(m.IssuanceLimitedTPS)
+	reg.MustRegister(m.ConfirmationLimitedTPS)
+	reg.MustRegister(m.BlobsConfirmed)
+	reg.MustRegister(m.BlobGasUsed)
+	reg.MustRegister(m.BlobsPerSecond)
+	reg.MustRegister(m.IssuanceFailures)
+	reg.MustRegister(m.ConfirmationFailures)
+	reg.MustRegister(m.UnconfirmedTxs)
+	reg.MustRegister(m.UnsampledTxs)
+	reg.MustRegister(m.ExpectedLogMismatches)
+	reg.MustRegister(m.MempoolRejections)
+	reg.MustRegister(m.MempoolToConfirmationTxTimes)
+	reg.MustRegister(m.FundingRetries)
+	reg.MustRegister(m.FeeTierIssuanceToConfirmationTxTimes)
+	reg.MustRegister(m.TxTypeIssuanceToConfirmationTxTimes)
+	reg.MustRegister(m.FeeBumps)
+	reg.MustRegister(m.ReorgedTxs)
+	reg.MustRegister(m.GasUsed)
+	reg.MustRegister(m.EffectiveTip)
+	reg.MustRegister(m.WarpDeliveryLatency)
+	reg.MustRegister(m.EndpointLimitWaits)
+	reg.MustRegister(m.EndpointLimitWaitTime)
+	reg.MustRegister(m.BlockGasUtilization)
+	reg.MustRegister(m.BlockTxs)
+	reg.MustRegister(m.BlockTime)
 	return m
 }
 
+// Bottleneck returns the phase ("issuance" or "confirmation") with the lower limited TPS,
+// which is the phase that dominated the execution time.
+func Bottleneck(issuanceLimitedTPS, confirmationLimitedTPS float64) string {
+	if issuanceLimitedTPS < confirmationLimitedTPS {
+		return "issuance"
+	}
+	return "confirmation"
+}
+
+// LogTPSBreakdown logs the issuance-limited and confirmation-limited TPS recorded across all agents
+// and which of the two phases was the bottleneck.
+func (m *Metrics) LogTPSBreakdown() error {
+	metricFamilies, err := m.reg.Gather()
+	if err != nil {
+		return err
+	}
+	var issuanceLimitedTPS, confirmationLimitedTPS float64
+	for _, mf := range metricFamilies {
+		for _, metric := range mf.GetMetric() {
+			switch mf.GetName() {
+			case "tps_issuance_limited":
+				issuanceLimitedTPS = metric.GetGauge().GetValue()
+			case "tps_confirmation_limited":
+				confirmationLimitedTPS = metric.GetGauge().GetValue()
+			}
+		}
+	}
+	log.Info("TPS breakdown",
+		"issuanceLimitedTPS", issuanceLimitedTPS,
+		"confirmationLimitedTPS", confirmationLimitedTPS,
+		"bottleneck", Bottleneck(issuanceLimitedTPS, confirmationLimitedTPS),
+	)
+	return nil
+}
+
+// LogFailures logs a warning with the number of txs that failed to issue or confirm or were left
+// unconfirmed at the max confirm wait across all agents, if any were, along with the number of txs
+// rejected by the mempool for each reason, the number of confirmed txs missing the log expected by
+// their workload, and the number of times confirmed txs were reorged out of their block.
+func (m *Metrics) LogFailures() error {
+	metricFamilies, err := m.reg.Gather()
+	if err != nil {
+		return err
+	}
+	var issuanceFailures, confirmationFailures, unconfirmed, logMismatches, reorgs float64
+	mempoolRejections := make(map[string]float64)
+	for _, mf := range metricFamilies {
+		for _, metric := range mf.GetMetric() {
+			switch mf.GetName() {
+			case "tx_issuance_failures":
+				issuanceFailures = metric.GetCounter().GetValue()
+			case "tx_confirmation_failures":
+				confirmationFailures = metric.GetCounter().GetValue()
+			case "tx_unconfirmed":
+				unconfirmed = metric.GetCounter().GetValue()
+			case "tx_expected_log_mismatches":
+				logMismatches = metric.GetCounter().GetValue()
+			case "tx_reorgs":
+				reorgs = metric.GetCounter().GetValue()
+			case "tx_mempool_rejections":
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == "reason" {
+						mempoolRejections[label.GetValue()] = metric.GetCounter().GetValue()
+					}
+				}
+			}
+		}
+	}
+	if issuanceFailures > 0 || confirmationFailures > 0 || unconfirmed > 0 || reorgs > 0 {
+		log.Warn("Load test completed with failed txs",
+			"issuanceFailures", issuanceFailures,
+			"confirmationFailures", confirmationFailures,
+			"unconfirmedTxs", unconfirmed,
+			"mempoolRejections", mempoolRejections,
+			"expectedLogMismatches", logMismatches,
+			"reorgs", reorgs,
+		)
+	}
+	return nil
+}
+
+// IssuanceToConfirmationQuantiles returns the quantiles of the issuance to confirmation times of
+// individual txs in seconds, keyed by quantile.
+func (m *Metrics) IssuanceToConfirmationQuantiles() (map[float64]float64, error) {
+	metricFamilies, err := m.reg.Gather()
+	if err != nil {
+		return nil, err
+	}
+	quantiles := make(map[float64]float64)
+	for _, mf := range metricFamilies {
+		if mf.GetName() != "tx_issuance_to_confirmation_time" {
+			continue
+		}
+		for _, metric := range mf.GetMetric() {
+			for _, quantile := range metric.GetSummary().GetQuantile() {
+				quantiles[quantile.GetQuantile()] = quantile.GetValue()
+			}
+		}
+	}
+	return quantiles, nil
+}
+
+// TxTypeIssuanceToConfirmationQuantiles returns the quantiles of the issuance to confirmation times of
+// individual txs in seconds by tx type, keyed by tx type and then by quantile.
+func (m *Metrics) TxTypeIssuanceToConfirmationQuantiles() (map[string]map[float64]float64, error) {
+	metricFamilies, err := m.reg.Gather()
+	if err != nil {
+		return nil, err
+	}
+	quantiles := make(map[string]map[float64]float64)
+	for _, mf := range metricFamilies {
+		if mf.GetName() != "tx_type_issuance_to_confirmation_time" {
+			continue
+		}
+		for _, metric := range mf.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() != "type" {
+					continue
+				}
+				txTypeQuantiles := make(map[float64]float64)
+				for _, quantile := range metric.GetSummary().GetQuantile() {
+					txTypeQuantiles[quantile.GetQuantile()] = quantile.GetValue()
+				}
+				quantiles[label.GetValue()] = txTypeQuantiles
+			}
+		}
+	}
+	return quantiles, nil
+}
+
+// WarpDeliveryLatencyQuantiles returns the WarpDeliveryQuantiles of the warp delivery latencies in seconds
+// by warp pair, keyed by warp pair and then by quantile. Since the latencies are recorded in a histogram,
+// each quantile is estimated by linear interpolation within the bucket it falls in.
+func (m *Metrics) WarpDeliveryLatencyQuantiles() (map[string]map[float64]float64, error) {
+	metricFamilies, err := m.reg.Gather()
+	if err != nil {
+		return nil, err
+	}
+	quantiles := make(map[string]map[float64]float64)
+	for _, mf := range metricFamilies {
+		if mf.GetName() != "warp_delivery_latency" {
+			continue
+		}
+		for _, metric := range mf.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() != "pair" {
+					continue
+				}
+				pairQuantiles := make(map[float64]float64, len(WarpDeliveryQuantiles))
+				for _, quantile := range WarpDeliveryQuantiles {
+					pairQuantiles[quantile] = histogramQuantile(quantile, metric.GetHistogram())
+				}
+				quantiles[label.GetValue()] = pairQuantiles
+			}
+		}
+	}
+	return quantiles, nil
+}
+
+// histogramQuantile estimates the [quantile] of the observations of [histogram] by linear interpolation
+// within the bucket it falls in, as histogram_quantile does in PromQL. Returns 0 if there are no
+// observations, and the upper bound of the highest bucket if the quantile exceeds it.
+func histogramQuantile(quantile float64, histogram *dto.Histogram) float64 {
+	rank := quantile * float64(histogram.GetSampleCount())
+	var (
+		lowerBound float64
+		lowerCount uint64
+	)
+	for _, bucket := range histogram.GetBucket() {
+		count := bucket.GetCumulativeCount()
+		if float64(count) >= rank && count > lowerCount {
+			return lowerBound + (bucket.GetUpperBound()-lowerBound)*(rank-float64(lowerCount))/float64(count-lowerCount)
+		}
+		lowerBound, lowerCount = bucket.GetUpperBound(), count
+	}
+	if lowerCount == 0 {
+		return 0
+	}
+	return lowerBound
+}
+
+// LogTxCosts logs the total gas used by the confirmed txs recorded in the GasUsed metric and
+// the average effective tip they paid.
+func (m *Metrics) LogTxCosts() error {
+	metricFamilies, err := m.reg.Gather()
+	if err != nil {
+		return err
+	}
+	var (
+		numTxs        uint64
+		totalGasUsed  float64
+		totalTipsGwei float64
+	)
+	for _, mf := range metricFamilies {
+		for _, metric := range mf.GetMetric() {
+			switch mf.GetName() {
+			case "tx_gas_used":
+				numTxs = metric.GetHistogram().GetSampleCount()
+				totalGasUsed = metric.GetHistogram().GetSampleSum()
+			case "tx_effective_tip":
+				totalTipsGwei = metric.GetHistogram().GetSampleSum()
+			}
+		}
+	}
+	if numTxs == 0 {
+		return nil
+	}
+	log.Info("Tx costs",
+		"txs", numTxs,
+		"totalGasUsed", totalGasUsed,
+		"avgEffectiveTipGwei", totalTipsGwei/float64(numTxs),
+	)
+	return nil
+}
+
 type MetricsServer struct {
-	metricsPort     string
+	addr            string
 	metricsEndpoint string
 
 	cancel context.CancelFunc
 	stopCh chan struct{}
 }
 
-func (m *Metrics) Serve(ctx context.Context, metricsPort string, metricsEndpoint string) *MetricsServer {
+// Serve starts a prometheus server exposing [m] at [metricsEndpoint] on [metricsPort].
+// If [metricsPort] is "0", an ephemeral port is chosen, which can be retrieved with Addr.
+func (m *Metrics) Serve(ctx context.Context, metricsPort string, metricsEndpoint string) (*MetricsServer, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", metricsPort))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on metrics port %s: %w", metricsPort, err)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	// Create a prometheus server to expose individual tx metrics
+	mux := http.NewServeMux()
+	mux.Handle(metricsEndpoint, promhttp.HandlerFor(m.reg, promhttp.HandlerOpts{Registry: m.reg}))
 	server := &http.Server{
-		Addr: fmt.Sprintf(":%s", metricsPort),
+		Handler: mux,
 	}
 
 	// Start up go routine to listen for SIGINT notifications to gracefully shut down server
@@ -85,7 +501,7 @@ func (m *Metrics) Serve(ctx context.Context, metricsPort string, metricsEndpoint
 
 	// Start metrics server
 	ms := &MetricsServer{
-		metricsPort:     metricsPort,
+		addr:            listener.Addr().String(),
 		metricsEndpoint: metricsEndpoint,
 		stopCh:          make(chan struct{}),
 		cancel:          cancel,
@@ -93,14 +509,18 @@ func (m *Metrics) Serve(ctx context.Context, metricsPort string, metricsEndpoint
 	go func() {
 		defer close(ms.stopCh)
 
-		http.Handle(metricsEndpoint, promhttp.HandlerFor(m.reg, promhttp.HandlerOpts{Registry: m.reg}))
-		log.Info(fmt.Sprintf("Metrics Server: localhost:%s%s", metricsPort, metricsEndpoint))
-		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Info(fmt.Sprintf("Metrics Server: %s%s", ms.addr, metricsEndpoint))
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
 			log.Error("Metrics server error: %v", err)
 		}
 	}()
 
-	return ms
+	return ms, nil
+}
+
+// Addr returns the address the metrics server is bound to.
+func (ms *MetricsServer) Addr() string {
+	return ms.addr
 }
 
 func (ms *MetricsServer) Shutdown() {
@@ -108,6 +528,172 @@ func (ms *MetricsServer) Shutdown() {
 	<-ms.stopCh
 }
 
+// finalPushTimeout bounds the final push of metrics, so that an unreachable Pushgateway does not
+// block the exit of a run.
+const finalPushTimeout = 10 * time.Second
+
+// MetricsPusher periodically pushes metrics to a Prometheus Pushgateway.
+type MetricsPusher struct {
+	pusher *push.Pusher
+
+	cancel context.CancelFunc
+	stopCh chan struct{}
+}
+
+// Push starts pushing [m] to the Pushgateway at [pushgatewayURL] under [job] every [interval], until
+// Shutdown is called.
+func (m *Metrics) Push(pushgatewayURL string, job string, interval time.Duration) *MetricsPusher {
+	ctx, cancel := context.WithCancel(context.Background())
+	mp := &MetricsPusher{
+		pusher: push.New(pushgatewayURL, job).Gatherer(m.reg),
+		cancel: cancel,
+		stopCh: make(chan struct{}),
+	}
+	go func() {
+		defer close(mp.stopCh)
+
+		log.Info("Pushing metrics", "pushgateway", pushgatewayURL, "job", job, "interval", interval)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := mp.pusher.PushContext(ctx); err != nil && ctx.Err() == nil {
+					log.Warn("Failed to push metrics", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return mp
+}
+
+// Shutdown stops the periodic pushes and pushes the metrics a final time, so that the
+// metrics of a run that ends between pushes are not lost.
+func (mp *MetricsPusher) Shutdown() {
+	mp.cancel()
+	<-mp.stopCh
+	ctx, cancel := context.WithTimeout(context.Background(), finalPushTimeout)
+	defer cancel()
+	if err := mp.pusher.PushContext(ctx); err != nil {
+		log.Warn("Failed to push final metrics", "err", err)
+	}
+}
+
+// Progress is a snapshot of the txs of a load test across all agents.
+type Progress struct {
+	// IssuedTxs is the number of txs issued successfully, including txs that later failed to confirm.
+	IssuedTxs            uint64
+	ConfirmedTxs         uint64
+	IssuanceFailures     uint64
+	ConfirmationFailures uint64
+	UnconfirmedTxs       uint64
+	// UnsampledTxs is the number of issued txs that were not confirmed since they were not sampled.
+	UnsampledTxs uint64
+}
+
+// Inflight returns the number of issued txs that have not yet been confirmed, failed to confirm,
+// been left unconfirmed, or been skipped as unsampled.
+func (p Progress) Inflight() uint64 {
+	settled := p.ConfirmedTxs + p.ConfirmationFailures + p.UnconfirmedTxs + p.UnsampledTxs
+	if settled > p.IssuedTxs {
+		return 0
+	}
+	return p.IssuedTxs - settled
+}
+
+// Progress returns a snapshot of the txs recorded in [m] so far.
+func (m *Metrics) Progress() (Progress, error) {
+	metricFamilies, err := m.reg.Gather()
+	if err != nil {
+		return Progress{}, err
+	}
+	var p Progress
+	for _, mf := range metricFamilies {
+		for _, metric := range mf.GetMetric() {
+			switch mf.GetName() {
+			case "tx_issuance_time":
+				p.IssuedTxs = metric.GetSummary().GetSampleCount()
+			case "tx_issuance_to_confirmation_time":
+				p.ConfirmedTxs = metric.GetSummary().GetSampleCount()
+			case "tx_issuance_failures":
+				p.IssuanceFailures = uint64(metric.GetCounter().GetValue())
+			case "tx_confirmation_failures":
+				p.ConfirmationFailures = uint64(metric.GetCounter().GetValue())
+			case "tx_unconfirmed":
+				p.UnconfirmedTxs = uint64(metric.GetCounter().GetValue())
+			case "tx_unsampled":
+				p.UnsampledTxs = uint64(metric.GetCounter().GetValue())
+			}
+		}
+	}
+	return p, nil
+}
+
+// ProgressReporter periodically logs the progress of a load test.
+type ProgressReporter struct {
+	cancel context.CancelFunc
+	stopCh chan struct{}
+}
+
+// Report starts logging the progress of the load test recorded in [m] every [interval], until Shutdown
+// is called. Each report holds the TPS and error rate over the last interval and the number of issued
+// txs that are not yet confirmed.
+func (m *Metrics) Report(interval time.Duration) *ProgressReporter {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &ProgressReporter{
+		cancel: cancel,
+		stopCh: make(chan struct{}),
+	}
+	go func() {
+		defer close(r.stopCh)
+
+		last, err := m.Progress()
+		if err != nil {
+			log.Warn("Failed to gather progress", "err", err)
+		}
+		lastTime := time.Now()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p, err := m.Progress()
+				if err != nil {
+					log.Warn("Failed to gather progress", "err", err)
+					continue
+				}
+				now := time.Now()
+				confirmed := p.ConfirmedTxs - last.ConfirmedTxs
+				attempted := p.IssuedTxs + p.IssuanceFailures - last.IssuedTxs - last.IssuanceFailures
+				failed := p.IssuanceFailures + p.ConfirmationFailures - last.IssuanceFailures - last.ConfirmationFailures
+				var errorRate float64
+				if attempted > 0 {
+					errorRate = float64(failed) / float64(attempted)
+				}
+				log.Info("Load test progress",
+					"tps", float64(confirmed)/now.Sub(lastTime).Seconds(),
+					"confirmedTxs", p.ConfirmedTxs,
+					"unsampledTxs", p.UnsampledTxs,
+					"inflightTxs", p.Inflight(),
+					"errorRate", errorRate,
+				)
+				last, lastTime = p, now
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return r
+}
+
+// Shutdown stops the periodic reports and waits for the reporter to exit.
+func (r *ProgressReporter) Shutdown() {
+	r.cancel()
+	<-r.stopCh
+}
+
 func (m *Metrics) Print(outputFile string) error {
 	metrics, err := m.reg.Gather()
 	if err != nil {
@@ -12,8 +12,18 @@ import (
 	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
 )
 
+// ErrConfirmWaitExceeded is the cause of the cancellation of the confirmations still pending once the
+// max confirm wait of an agent elapses after it issued its last tx.
+var ErrConfirmWaitExceeded = errors.New("max confirm wait exceeded")
+
+// ErrConfirmNotSampled is returned by ConfirmTx of a worker that confirms only a sample of its txs
+// for a tx it did not sample. The agent counts the tx as unsampled rather than failed, and does not
+// record a confirmation time for it.
+var ErrConfirmNotSampled = errors.New("tx not sampled for confirmation")
+
 type THash interface {
 	Hash() common.Hash
 }
@@ -28,32 +38,158 @@ type TxSequence[T THash] interface {
 // Worker defines the interface for issuance and confirmation of transactions.
 // The caller is responsible for calling Close to cleanup resources used by the
 // worker at the end of the simulation.
+//
+// An agent confirming txs with a confirm concurrency above 1 calls ConfirmTx concurrently
+// for the txs of a batch, so ConfirmTx must be safe for concurrent use. IssueTx is never
+// called concurrently, nor concurrently with ConfirmTx.
 type Worker[T THash] interface {
 	IssueTx(ctx context.Context, tx T) error
 	ConfirmTx(ctx context.Context, tx T) error
 	LatestHeight(ctx context.Context) (uint64, error)
 }
 
+// Forgetter is implemented by a Worker that keeps state for each tx it issues until ConfirmTx is called
+// for it. The agent calls Forget for each tx it issued and will not confirm, such as a tx that failed to
+// issue or confirm, was not sampled for confirmation, or was abandoned once the max confirm wait elapsed,
+// so that the worker releases its state for the tx. A Worker wrapping another Worker forwards Forget to
+// it with the Forget function.
+type Forgetter interface {
+	Forget(txHash common.Hash)
+}
+
+// Forget calls Forget of [worker] with [txHash] if it is a Forgetter.
+func Forget[T THash](worker Worker[T], txHash common.Hash) {
+	if f, ok := worker.(Forgetter); ok {
+		f.Forget(txHash)
+	}
+}
+
+// ErrorPolicy specifies how an agent handles a tx that fails to issue or confirm.
+type ErrorPolicy int
+
+const (
+	// AbortOnError stops the agent and returns the error of the first failed tx.
+	AbortOnError ErrorPolicy = iota
+	// ContinueOnError records the failure in the metrics and moves on to the next tx.
+	// Note: a failed tx leaves a nonce gap, so later txs from the same sender may not confirm.
+	ContinueOnError
+)
+
+// AdaptiveBatchPolicy specifies how an agent adapts the size of its batches to the time its batches
+// take to confirm. The zero value disables adaptation, so that every batch has the initial batch size.
+type AdaptiveBatchPolicy struct {
+	// TargetLatency is the confirmation time of a batch below which the next batch is made larger,
+	// and above which the next batch is made smaller. Zero disables adaptation.
+	TargetLatency time.Duration
+	// MinBatchSize and MaxBatchSize bound the size of each batch adapted from the previous batch.
+	MinBatchSize uint64
+	MaxBatchSize uint64
+	// OnBatchSize, if non-nil, is called with the size of the last batch once the agent completes.
+	OnBatchSize func(batchSize uint64)
+}
+
+// Enabled returns true if [p] adapts the batch size.
+func (p AdaptiveBatchPolicy) Enabled() bool {
+	return p.TargetLatency > 0
+}
+
+// next returns the size of the batch following a batch of [batchSize] txs that took [latency] to confirm.
+// The batch size grows additively by 10% while batches confirm within the target latency, and is halved
+// once they do not, so that it settles just below the largest batch the chain confirms in time.
+func (p AdaptiveBatchPolicy) next(batchSize uint64, latency time.Duration) uint64 {
+	switch {
+	case latency < p.TargetLatency:
+		batchSize += max(batchSize/10, 1)
+	case latency > p.TargetLatency:
+		batchSize /= 2
+	}
+	return min(max(batchSize, p.MinBatchSize), p.MaxBatchSize)
+}
+
 // Execute the work of the given agent.
 type Agent[T THash] interface {
 	Execute(ctx context.Context) error
 }
 
 // issueNAgent issues and confirms a batch of N transactions at a time.
+// Up to [confirmConcurrency] txs of a batch are confirmed concurrently.
+// If [maxInflight] is non-zero, txs are instead confirmed as they are issued,
+// so that at most [maxInflight] issued txs are unconfirmed at a time.
+// If [adaptiveBatch] is enabled, N is adapted after each batch to its confirmation time.
+// If [maxConfirmWait] is non-zero, the txs still unconfirmed [maxConfirmWait] after the
+// last tx is issued are abandoned.
+// If [startBarrier] is non-nil, the agent waits on it before issuing its first tx.
 type issueNAgent[T THash] struct {
-	sequence TxSequence[T]
-	worker   Worker[T]
-	n        uint64
-	metrics  *metrics.Metrics
+	sequence           TxSequence[T]
+	worker             Worker[T]
+	n                  uint64
+	confirmConcurrency int
+	maxInflight        int
+	maxConfirmWait     time.Duration
+	adaptiveBatch      AdaptiveBatchPolicy
+	onError            ErrorPolicy
+	startBarrier       *StartBarrier
+	metrics            *metrics.Metrics
+	log                log.Logger
 }
 
-// NewIssueNAgent creates a new issueNAgent
-func NewIssueNAgent[T THash](sequence TxSequence[T], worker Worker[T], n uint64, metrics *metrics.Metrics) Agent[T] {
+// IssueNAgentOptions configures an agent created by NewIssueNAgent. The zero value confirms the
+// txs of each batch one at a time, aborts on the first failed tx and logs to the root logger.
+type IssueNAgentOptions struct {
+	// ConfirmConcurrency, if greater than 1, is the number of txs of each batch confirmed concurrently,
+	// so that the confirmation time of a batch approaches the confirmation time of its slowest tx.
+	// Otherwise, the txs of each batch are confirmed one at a time.
+	ConfirmConcurrency int
+	// MaxInflight, if greater than 0, stops the agent from waiting for each batch to confirm. Instead,
+	// it confirms the oldest unconfirmed tx before issuing a tx that would exceed MaxInflight
+	// unconfirmed txs, and confirms the remaining txs once the sequence is exhausted.
+	MaxInflight int
+	// MaxConfirmWait, if greater than 0, stops the agent from confirming txs once MaxConfirmWait has
+	// elapsed since the sequence was exhausted, and completes without error, reporting the txs it did
+	// not confirm in time as unconfirmed rather than failed. This bounds the time a few lingering txs
+	// can hold up the agent independently of the deadline of the context passed to Execute.
+	MaxConfirmWait time.Duration
+	// AdaptiveBatch, if enabled, adapts the size of each batch after the first to the confirmation
+	// time of the previous batch. Since txs are then not confirmed by batch, it is ignored if
+	// MaxInflight is greater than 0.
+	AdaptiveBatch AdaptiveBatchPolicy
+	// OnError specifies whether a failed tx aborts the agent or is skipped.
+	OnError ErrorPolicy
+	// StartBarrier, if non-nil, is waited on by the agent before issuing its first tx, and the agent
+	// measures its execution from the time it is released, so that agents released together share the
+	// same start.
+	StartBarrier *StartBarrier
+	// Logger, if non-nil, is the logger the agent logs its progress to instead of the root logger.
+	Logger log.Logger
+}
+
+// NewIssueNAgent creates a new issueNAgent issuing batches of [n] txs, configured by [opts].
+func NewIssueNAgent[T THash](sequence TxSequence[T], worker Worker[T], n uint64, metrics *metrics.Metrics, opts IssueNAgentOptions) Agent[T] {
+	confirmConcurrency := max(opts.ConfirmConcurrency, 1)
+	maxInflight := max(opts.MaxInflight, 0)
+	maxConfirmWait := max(opts.MaxConfirmWait, 0)
+	adaptiveBatch := opts.AdaptiveBatch
+	if maxInflight > 0 {
+		adaptiveBatch = AdaptiveBatchPolicy{}
+	}
+	adaptiveBatch.MinBatchSize = max(adaptiveBatch.MinBatchSize, 1)
+	adaptiveBatch.MaxBatchSize = max(adaptiveBatch.MaxBatchSize, adaptiveBatch.MinBatchSize)
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.Root()
+	}
 	return &issueNAgent[T]{
-		sequence: sequence,
-		worker:   worker,
-		n:        n,
-		metrics:  metrics,
+		sequence:           sequence,
+		worker:             worker,
+		n:                  n,
+		confirmConcurrency: confirmConcurrency,
+		maxInflight:        maxInflight,
+		maxConfirmWait:     maxConfirmWait,
+		adaptiveBatch:      adaptiveBatch,
+		onError:            opts.OnError,
+		startBarrier:       opts.StartBarrier,
+		metrics:            metrics,
+		log:                logger,
 	}
 }
 
@@ -64,10 +200,21 @@ func (a issueNAgent[T]) Execute(ctx context.Context) error {
 	}
 
 	txChan := a.sequence.Chan()
+	batchSize := a.n
 	confirmedCount := 0
+	failedCount := 0
+	unconfirmedCount := 0
+	unsampledCount := 0
 	batchI := 0
 	m := a.metrics
 	txMap := make(map[common.Hash]time.Time)
+	// Issued txs that have not been confirmed yet, in order of issuance.
+	var pending []T
+	// The next tx of the sequence, if it was received to check whether the sequence was exhausted.
+	var (
+		next    T
+		hasNext bool
+	)
 
 	// Tracks the total amount of time waiting for issuing and confirming txs
 	var (
@@ -77,62 +224,141 @@ func (a issueNAgent[T]) Execute(ctx context.Context) error {
 
 	// Start time for execution
 	start := time.Now()
+	if a.startBarrier != nil {
+		var err error
+		if start, err = a.startBarrier.Wait(ctx); err != nil {
+			return err
+		}
+	}
 	for {
 		var (
-			txs     = make([]T, 0, a.n)
-			tx      T
-			moreTxs bool
+			numIssued     int
+			tx            T
+			moreTxs       bool
+			windowConfirm time.Duration
 		)
 		// Start issuance batch
 		issuedStart := time.Now()
 	L:
-		for i := uint64(0); i < a.n; i++ {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case tx, moreTxs = <-txChan:
-				if !moreTxs {
-					break L
+		for i := uint64(0); i < batchSize; i++ {
+			if hasNext {
+				// Issue the tx taken from the sequence to check whether it was exhausted first.
+				tx, moreTxs, hasNext = next, true, false
+			} else {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case tx, moreTxs = <-txChan:
 				}
-				issuanceIndividualStart := time.Now()
-				txMap[tx.Hash()] = issuanceIndividualStart
-				if err := a.worker.IssueTx(ctx, tx); err != nil {
-					return fmt.Errorf("failed to issue transaction %d: %w", len(txs), err)
+			}
+			if !moreTxs {
+				break L
+			}
+			if a.maxInflight > 0 && len(pending) >= a.maxInflight {
+				// Confirm the oldest tx to make room for [tx] in the window of unconfirmed txs.
+				confirmStart := time.Now()
+				confirmed, failed, _, unsampled, err := a.confirmTxs(ctx, batchI, pending[:1], txMap)
+				if err != nil {
+					return err
+				}
+				confirmedCount += confirmed
+				failedCount += failed
+				unsampledCount += unsampled
+				pending = pending[1:]
+				windowConfirm += time.Since(confirmStart)
+			}
+			issuanceIndividualStart := time.Now()
+			txMap[tx.Hash()] = issuanceIndividualStart
+			if err := a.worker.IssueTx(ctx, tx); err != nil {
+				m.IssuanceFailures.Inc()
+				if a.onError == AbortOnError || ctx.Err() != nil {
+					return fmt.Errorf("failed to issue transaction %d: %w", numIssued, err)
 				}
-				issuanceIndividualDuration := time.Since(issuanceIndividualStart)
-				m.IssuanceTxTimes.Observe(issuanceIndividualDuration.Seconds())
-				txs = append(txs, tx)
+				a.log.Warn("Failed to issue transaction", "batch", batchI, "txHash", tx.Hash(), "err", err)
+				delete(txMap, tx.Hash())
+				Forget(a.worker, tx.Hash())
+				failedCount++
+				continue
 			}
+			issuanceIndividualDuration := time.Since(issuanceIndividualStart)
+			m.IssuanceTxTimes.Observe(issuanceIndividualDuration.Seconds())
+			pending = append(pending, tx)
+			numIssued++
 		}
-		// Get the batch's issuance time and add it to totalIssuedTime
-		issuedDuration := time.Since(issuedStart)
-		log.Info("Issuance Batch Done", "batch", batchI, "time", issuedDuration.Seconds())
+		// If the batch is full, check whether the sequence is exhausted without waiting for its next tx,
+		// so that the confirmation of the last batch is bounded by the max confirm wait even if the
+		// sequence ends with a full batch. A sequence still generating txs is assumed to have more.
+		if moreTxs && a.maxConfirmWait > 0 {
+			select {
+			case next, hasNext = <-txChan:
+				moreTxs = hasNext
+			default:
+			}
+		}
+		// Get the batch's issuance time, excluding the time spent confirming txs to stay within
+		// the window of unconfirmed txs, and add it to totalIssuedTime
+		issuedDuration := time.Since(issuedStart) - windowConfirm
+		a.log.Info("Issuance Batch Done", "batch", batchI, "txs", numIssued, "time", issuedDuration.Seconds())
 		totalIssuedTime += issuedDuration
+		totalConfirmedTime += windowConfirm
 
-		// Wait for txs in this batch to confirm
-		confirmedStart := time.Now()
-		for i, tx := range txs {
-			confirmedIndividualStart := time.Now()
-			if err := a.worker.ConfirmTx(ctx, tx); err != nil {
-				return fmt.Errorf("failed to await transaction %d: %w", i, err)
+		// Wait for txs in this batch to confirm, unless txs are confirmed within a window
+		// spanning batches, in which case the remaining txs are confirmed after the last batch
+		if a.maxInflight == 0 || !moreTxs {
+			confirmedStart := time.Now()
+			confirmCtx := ctx
+			if !moreTxs && a.maxConfirmWait > 0 {
+				// Every tx has been issued, so only the confirmation of the pending txs is bounded.
+				var cancel context.CancelFunc
+				confirmCtx, cancel = context.WithTimeoutCause(ctx, a.maxConfirmWait, ErrConfirmWaitExceeded)
+				defer cancel()
+			}
+			confirmed, failed, unconfirmed, unsampled, err := a.confirmTxs(confirmCtx, batchI, pending, txMap)
+			if err != nil {
+				return err
+			}
+			confirmedCount += confirmed
+			failedCount += failed
+			unconfirmedCount += unconfirmed
+			unsampledCount += unsampled
+			if unconfirmed > 0 {
+				m.UnconfirmedTxs.Add(float64(unconfirmed))
+				a.log.Warn("Max confirm wait exceeded, abandoning unconfirmed txs", "batch", batchI, "unconfirmedTxs", unconfirmed, "maxConfirmWait", a.maxConfirmWait)
+			}
+			// Get the batch's confirmation time and add it to totalConfirmedTime
+			confirmedDuration := time.Since(confirmedStart)
+			a.log.Info("Confirmed Batch Done", "batch", batchI, "txs", len(pending), "time", confirmedDuration.Seconds())
+			totalConfirmedTime += confirmedDuration
+			pending = nil
+
+			// Only full batches are representative of the confirmation time of the batch size.
+			if a.adaptiveBatch.Enabled() && moreTxs {
+				nextBatchSize := a.adaptiveBatch.next(batchSize, confirmedDuration)
+				if nextBatchSize != batchSize {
+					a.log.Info("Adapted batch size", "batch", batchI, "batchSize", nextBatchSize, "previousBatchSize", batchSize,
+						"confirmedTime", confirmedDuration.Seconds(), "targetLatency", a.adaptiveBatch.TargetLatency.Seconds())
+					batchSize = nextBatchSize
+				}
 			}
-			confirmationIndividualDuration := time.Since(confirmedIndividualStart)
-			issuanceToConfirmationIndividualDuration := time.Since(txMap[tx.Hash()])
-			m.ConfirmationTxTimes.Observe(confirmationIndividualDuration.Seconds())
-			m.IssuanceToConfirmationTxTimes.Observe(issuanceToConfirmationIndividualDuration.Seconds())
-			delete(txMap, tx.Hash())
-			confirmedCount++
 		}
-		// Get the batch's confirmation time and add it to totalConfirmedTime
-		confirmedDuration := time.Since(confirmedStart)
-		log.Info("Confirmed Batch Done", "batch", batchI, "time", confirmedDuration.Seconds())
-		totalConfirmedTime += confirmedDuration
 
 		// Check if this is the last batch, if so write the final log and return
 		if !moreTxs {
 			totalTime := time.Since(start).Seconds()
-			log.Info("Execution complete", "totalTxs", confirmedCount, "totalTime", totalTime, "TPS", float64(confirmedCount)/totalTime,
-				"issuanceTime", totalIssuedTime.Seconds(), "confirmedTime", totalConfirmedTime.Seconds())
+			// Unsampled txs are assumed to confirm, so that the TPS of an execution confirming only a
+			// sample of its txs is comparable to that of an execution confirming every tx.
+			completedCount := confirmedCount + unsampledCount
+			issuanceLimitedTPS := float64(completedCount) / totalIssuedTime.Seconds()
+			confirmationLimitedTPS := float64(completedCount) / totalConfirmedTime.Seconds()
+			m.IssuanceLimitedTPS.Add(issuanceLimitedTPS)
+			m.ConfirmationLimitedTPS.Add(confirmationLimitedTPS)
+			a.log.Info("Execution complete", "batches", batchI+1, "txs", confirmedCount, "failedTxs", failedCount, "unconfirmedTxs", unconfirmedCount, "unsampledTxs", unsampledCount, "totalTime", totalTime, "TPS", float64(completedCount)/totalTime,
+				"issuanceTime", totalIssuedTime.Seconds(), "confirmedTime", totalConfirmedTime.Seconds(),
+				"issuanceLimitedTPS", issuanceLimitedTPS, "confirmationLimitedTPS", confirmationLimitedTPS,
+				"bottleneck", metrics.Bottleneck(issuanceLimitedTPS, confirmationLimitedTPS), "batchSize", batchSize)
+			if a.adaptiveBatch.OnBatchSize != nil {
+				a.adaptiveBatch.OnBatchSize(batchSize)
+			}
 
 			return nil
 		}
@@ -140,3 +366,95 @@ func (a issueNAgent[T]) Execute(ctx context.Context) error {
 		batchI++
 	}
 }
+
+// confirmTxs confirms [txs], which were issued at the times recorded in [issuedAt], logging failures
+// under batch [batchI], and removes them from [issuedAt]. The worker forgets each tx that did not confirm.
+// It returns the number of txs that confirmed, failed to confirm,
+// were left unconfirmed once the max confirm wait was exceeded, and were not sampled for confirmation,
+// or an error if the execution must be aborted.
+func (a issueNAgent[T]) confirmTxs(ctx context.Context, batchI int, txs []T, issuedAt map[common.Hash]time.Time) (int, int, int, int, error) {
+	confirmErrs, err := a.confirmBatch(ctx, txs, issuedAt)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	var confirmed, failed, unconfirmed, unsampled int
+	for i, tx := range txs {
+		delete(issuedAt, tx.Hash())
+		if confirmErrs[i] != nil {
+			Forget(a.worker, tx.Hash())
+		}
+		if errors.Is(confirmErrs[i], ErrConfirmWaitExceeded) {
+			unconfirmed++
+			continue
+		}
+		if errors.Is(confirmErrs[i], ErrConfirmNotSampled) {
+			unsampled++
+			continue
+		}
+		if confirmErrs[i] != nil {
+			a.log.Warn("Failed to confirm transaction", "batch", batchI, "txHash", tx.Hash(), "err", confirmErrs[i])
+			failed++
+			continue
+		}
+		confirmed++
+	}
+	return confirmed, failed, unconfirmed, unsampled, nil
+}
+
+// confirmBatch confirms [txs], which were issued at the times recorded in [issuedAt], with up to
+// [a.confirmConcurrency] concurrent calls of ConfirmTx, and records the confirmation times of each
+// confirmed tx. It returns the error of each tx that failed to confirm if [a.onError] allows the
+// execution to continue, and otherwise the first error, in which case the remaining confirmations
+// are cancelled. Txs left unconfirmed once [ctx] is cancelled with ErrConfirmWaitExceeded are not
+// failures, and their error is ErrConfirmWaitExceeded regardless of [a.onError]. Neither are txs the
+// worker did not sample for confirmation, whose error is ErrConfirmNotSampled.
+func (a issueNAgent[T]) confirmBatch(ctx context.Context, txs []T, issuedAt map[common.Hash]time.Time) ([]error, error) {
+	m := a.metrics
+	confirmErrs := make([]error, len(txs))
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(a.confirmConcurrency)
+	for i, tx := range txs {
+		i, tx := i, tx
+		eg.Go(func() error {
+			// Do not start confirming txs once the batch is aborted.
+			if err := egCtx.Err(); err != nil {
+				if errors.Is(context.Cause(ctx), ErrConfirmWaitExceeded) {
+					confirmErrs[i] = ErrConfirmWaitExceeded
+					return nil
+				}
+				return err
+			}
+			confirmedIndividualStart := time.Now()
+			if err := a.worker.ConfirmTx(egCtx, tx); err != nil {
+				if errors.Is(err, ErrConfirmNotSampled) {
+					m.UnsampledTxs.Inc()
+					confirmErrs[i] = ErrConfirmNotSampled
+					return nil
+				}
+				if errors.Is(context.Cause(ctx), ErrConfirmWaitExceeded) {
+					confirmErrs[i] = ErrConfirmWaitExceeded
+					return nil
+				}
+				if ctx.Err() == nil && egCtx.Err() != nil {
+					// Cancelled since another tx of the batch failed to confirm.
+					return err
+				}
+				m.ConfirmationFailures.Inc()
+				if a.onError == AbortOnError || egCtx.Err() != nil {
+					return fmt.Errorf("failed to await transaction %d: %w", i, err)
+				}
+				confirmErrs[i] = err
+				return nil
+			}
+			confirmationIndividualDuration := time.Since(confirmedIndividualStart)
+			issuanceToConfirmationIndividualDuration := time.Since(issuedAt[tx.Hash()])
+			m.ConfirmationTxTimes.Observe(confirmationIndividualDuration.Seconds())
+			m.IssuanceToConfirmationTxTimes.Observe(issuanceToConfirmationIndividualDuration.Seconds())
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return confirmErrs, nil
+}
@@ -0,0 +1,289 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/cmd/simulator/metrics"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// delayWorker is a Worker that issues txs instantly and confirms each tx after [confirmDelay].
+type delayWorker struct {
+	confirmDelay time.Duration
+	confirmed    atomic.Uint64
+	forgotten    atomic.Uint64
+}
+
+func (*delayWorker) IssueTx(context.Context, *types.Transaction) error {
+	return nil
+}
+
+func (w *delayWorker) ConfirmTx(ctx context.Context, _ *types.Transaction) error {
+	select {
+	case <-time.After(w.confirmDelay):
+		w.confirmed.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (*delayWorker) LatestHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (w *delayWorker) Forget(common.Hash) {
+	w.forgotten.Add(1)
+}
+
+// newTestSequence returns a sequence of [numTxs] distinct txs.
+func newTestSequence(numTxs int) TxSequence[*types.Transaction] {
+	txs := make([]*types.Transaction, numTxs)
+	for i := range txs {
+		txs[i] = types.NewTx(&types.LegacyTx{Nonce: uint64(i)})
+	}
+	return ConvertTxSliceToSequence(txs)
+}
+
+func TestIssueNAgentConfirmConcurrency(t *testing.T) {
+	const (
+		numTxs       = 20
+		batchSize    = 10
+		confirmDelay = 50 * time.Millisecond
+	)
+	for _, confirmConcurrency := range []int{1, batchSize} {
+		t.Run(fmt.Sprintf("concurrency %d", confirmConcurrency), func(t *testing.T) {
+			require := require.New(t)
+			worker := &delayWorker{confirmDelay: confirmDelay}
+			agent := NewIssueNAgent[*types.Transaction](newTestSequence(numTxs), worker, batchSize, metrics.NewDefaultMetrics(), IssueNAgentOptions{ConfirmConcurrency: confirmConcurrency})
+
+			start := time.Now()
+			require.NoError(agent.Execute(context.Background()))
+			elapsed := time.Since(start)
+			require.Equal(uint64(numTxs), worker.confirmed.Load())
+
+			// Each batch takes about [confirmDelay] times the number of txs it confirms one at a time.
+			sequentialConfirms := numTxs / confirmConcurrency
+			require.GreaterOrEqual(elapsed, time.Duration(sequentialConfirms)*confirmDelay)
+			if confirmConcurrency > 1 {
+				require.Less(elapsed, numTxs*confirmDelay)
+			}
+		})
+	}
+}
+
+// inflightWorker is a Worker that tracks the maximum number of issued txs that were unconfirmed at once.
+type inflightWorker struct {
+	inflight    int
+	maxInflight int
+	confirmed   int
+}
+
+func (w *inflightWorker) IssueTx(context.Context, *types.Transaction) error {
+	w.inflight++
+	w.maxInflight = max(w.maxInflight, w.inflight)
+	return nil
+}
+
+func (w *inflightWorker) ConfirmTx(context.Context, *types.Transaction) error {
+	w.inflight--
+	w.confirmed++
+	return nil
+}
+
+func (*inflightWorker) LatestHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func TestIssueNAgentMaxInflight(t *testing.T) {
+	const (
+		numTxs    = 25
+		batchSize = 10
+	)
+	tests := []struct {
+		maxInflight         int
+		expectedMaxInflight int
+	}{
+		{maxInflight: 0, expectedMaxInflight: batchSize},
+		{maxInflight: 3, expectedMaxInflight: 3},
+		{maxInflight: 15, expectedMaxInflight: 15},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("max inflight %d", test.maxInflight), func(t *testing.T) {
+			require := require.New(t)
+			worker := &inflightWorker{}
+			agent := NewIssueNAgent[*types.Transaction](newTestSequence(numTxs), worker, batchSize, metrics.NewDefaultMetrics(), IssueNAgentOptions{MaxInflight: test.maxInflight})
+			require.NoError(agent.Execute(context.Background()))
+			require.Equal(numTxs, worker.confirmed)
+			require.Zero(worker.inflight)
+			require.Equal(test.expectedMaxInflight, worker.maxInflight)
+		})
+	}
+}
+
+func TestAdaptiveBatchPolicyNext(t *testing.T) {
+	policy := AdaptiveBatchPolicy{
+		TargetLatency: time.Second,
+		MinBatchSize:  5,
+		MaxBatchSize:  100,
+	}
+	tests := []struct {
+		name              string
+		batchSize         uint64
+		latency           time.Duration
+		expectedBatchSize uint64
+	}{
+		{name: "below target grows by 10%", batchSize: 50, latency: time.Millisecond, expectedBatchSize: 55},
+		{name: "below target grows by at least 1", batchSize: 5, latency: time.Millisecond, expectedBatchSize: 6},
+		{name: "below target capped at max", batchSize: 95, latency: time.Millisecond, expectedBatchSize: 100},
+		{name: "at target unchanged", batchSize: 50, latency: time.Second, expectedBatchSize: 50},
+		{name: "above target halves", batchSize: 50, latency: 2 * time.Second, expectedBatchSize: 25},
+		{name: "above target floored at min", batchSize: 8, latency: 2 * time.Second, expectedBatchSize: 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedBatchSize, policy.next(test.batchSize, test.latency))
+		})
+	}
+}
+
+func TestIssueNAgentAdaptiveBatch(t *testing.T) {
+	const (
+		numTxs    = 200
+		batchSize = 10
+	)
+	tests := []struct {
+		name              string
+		confirmDelay      time.Duration
+		targetLatency     time.Duration
+		expectedBatchSize uint64
+	}{
+		{name: "fast confirmations grow to max", confirmDelay: 0, targetLatency: time.Minute, expectedBatchSize: 20},
+		{name: "slow confirmations shrink to min", confirmDelay: 5 * time.Millisecond, targetLatency: time.Millisecond, expectedBatchSize: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			worker := &delayWorker{confirmDelay: test.confirmDelay}
+			var finalBatchSize uint64
+			adaptiveBatch := AdaptiveBatchPolicy{
+				TargetLatency: test.targetLatency,
+				MinBatchSize:  2,
+				MaxBatchSize:  20,
+				OnBatchSize: func(batchSize uint64) {
+					finalBatchSize = batchSize
+				},
+			}
+			agent := NewIssueNAgent[*types.Transaction](newTestSequence(numTxs), worker, batchSize, metrics.NewDefaultMetrics(), IssueNAgentOptions{AdaptiveBatch: adaptiveBatch})
+			require.NoError(agent.Execute(context.Background()))
+			require.Equal(uint64(numTxs), worker.confirmed.Load())
+			require.Equal(test.expectedBatchSize, finalBatchSize)
+		})
+	}
+}
+
+func TestIssueNAgentMaxConfirmWait(t *testing.T) {
+	const (
+		numTxs         = 10
+		maxConfirmWait = 50 * time.Millisecond
+	)
+	tests := map[string]struct {
+		confirmDelay      time.Duration
+		expectedConfirmed uint64
+	}{
+		"confirmed within max confirm wait": {
+			confirmDelay:      time.Millisecond,
+			expectedConfirmed: numTxs,
+		},
+		"unconfirmed at max confirm wait": {
+			confirmDelay:      time.Hour,
+			expectedConfirmed: 0,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			worker := &delayWorker{confirmDelay: test.confirmDelay}
+			agent := NewIssueNAgent[*types.Transaction](newTestSequence(numTxs), worker, numTxs, metrics.NewDefaultMetrics(), IssueNAgentOptions{ConfirmConcurrency: numTxs, MaxConfirmWait: maxConfirmWait})
+			start := time.Now()
+			// Txs left unconfirmed at the max confirm wait do not fail the execution.
+			require.NoError(agent.Execute(context.Background()))
+			require.Less(time.Since(start), time.Second)
+			require.Equal(test.expectedConfirmed, worker.confirmed.Load())
+			// The worker forgets the txs abandoned at the max confirm wait.
+			require.Equal(numTxs-test.expectedConfirmed, worker.forgotten.Load())
+		})
+	}
+}
+
+// oddNonceSampleWorker is a delayWorker that samples only the txs with an even nonce for confirmation.
+type oddNonceSampleWorker struct {
+	delayWorker
+}
+
+func (w *oddNonceSampleWorker) ConfirmTx(ctx context.Context, tx *types.Transaction) error {
+	if tx.Nonce()%2 == 1 {
+		return ErrConfirmNotSampled
+	}
+	return w.delayWorker.ConfirmTx(ctx, tx)
+}
+
+func TestIssueNAgentUnsampledTxs(t *testing.T) {
+	const (
+		numTxs    = 20
+		batchSize = 5
+	)
+	for _, maxInflight := range []int{0, 2} {
+		t.Run(fmt.Sprintf("max inflight %d", maxInflight), func(t *testing.T) {
+			require := require.New(t)
+
+			worker := &oddNonceSampleWorker{delayWorker{confirmDelay: time.Millisecond}}
+			m := metrics.NewDefaultMetrics()
+			agent := NewIssueNAgent[*types.Transaction](newTestSequence(numTxs), worker, batchSize, m, IssueNAgentOptions{MaxInflight: maxInflight})
+			// Unsampled txs do not fail the execution, even if it aborts on error.
+			require.NoError(agent.Execute(context.Background()))
+			require.Equal(uint64(numTxs/2), worker.confirmed.Load())
+			require.Equal(uint64(numTxs/2), worker.forgotten.Load())
+
+			// Only sampled txs record a confirmation time.
+			progress, err := m.Progress()
+			require.NoError(err)
+			require.Equal(metrics.Progress{
+				IssuedTxs:    numTxs,
+				ConfirmedTxs: numTxs / 2,
+				UnsampledTxs: numTxs / 2,
+			}, progress)
+			require.Zero(progress.Inflight())
+		})
+	}
+}
+
+func BenchmarkIssueNAgentConfirmBatch(b *testing.B) {
+	const (
+		batchSize    = 256
+		confirmDelay = time.Millisecond
+	)
+	for _, confirmConcurrency := range []int{1, 16, batchSize} {
+		b.Run(fmt.Sprintf("concurrency %d", confirmConcurrency), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				worker := &delayWorker{confirmDelay: confirmDelay}
+				agent := NewIssueNAgent[*types.Transaction](newTestSequence(batchSize), worker, batchSize, metrics.NewDefaultMetrics(), IssueNAgentOptions{ConfirmConcurrency: confirmConcurrency})
+				b.StartTimer()
+				if err := agent.Execute(context.Background()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// StartBarrier releases agents together once all of them are ready to issue txs, so that the start
+// of a load test does not depend on the order in which the goroutines of its agents are scheduled.
+type StartBarrier struct {
+	parties  int
+	released chan struct{}
+
+	lock    sync.Mutex
+	waiting int
+	start   time.Time
+}
+
+// NewStartBarrier returns a StartBarrier that releases the first [parties] calls of Wait together.
+func NewStartBarrier(parties int) *StartBarrier {
+	return &StartBarrier{
+		parties:  parties,
+		released: make(chan struct{}),
+	}
+}
+
+// Wait blocks until [parties] calls of Wait are waiting, and returns the time at which they were
+// released. Calls of Wait once the barrier has been released return immediately with the current
+// time, since they start after the released agents, such as agents queued behind a worker pool.
+func (b *StartBarrier) Wait(ctx context.Context) (time.Time, error) {
+	b.lock.Lock()
+	select {
+	case <-b.released:
+		b.lock.Unlock()
+		return time.Now(), nil
+	default:
+	}
+	b.waiting++
+	if b.waiting >= b.parties {
+		b.start = time.Now()
+		close(b.released)
+		b.lock.Unlock()
+		return b.start, nil
+	}
+	b.lock.Unlock()
+
+	select {
+	case <-b.released:
+		return b.start, nil
+	case <-ctx.Done():
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		select {
+		case <-b.released:
+			return b.start, nil
+		default:
+			b.waiting--
+			return time.Time{}, ctx.Err()
+		}
+	}
+}
+
+// Start returns the time at which the barrier released its parties, or the zero time if it has not
+// released them yet.
+func (b *StartBarrier) Start() time.Time {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.start
+}
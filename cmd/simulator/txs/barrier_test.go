@@ -0,0 +1,56 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+func TestStartBarrierReleasesPartiesTogether(t *testing.T) {
+	require := require.New(t)
+	const parties = 8
+	barrier := NewStartBarrier(parties)
+
+	starts := make([]time.Time, parties)
+	eg := errgroup.Group{}
+	for i := 0; i < parties; i++ {
+		i := i
+		eg.Go(func() error {
+			start, err := barrier.Wait(context.Background())
+			starts[i] = start
+			return err
+		})
+	}
+	require.NoError(eg.Wait())
+	for _, start := range starts {
+		require.Equal(barrier.Start(), start)
+	}
+
+	// Calls once the barrier has been released start immediately, no earlier than the released calls.
+	start, err := barrier.Wait(context.Background())
+	require.NoError(err)
+	require.False(start.Before(barrier.Start()))
+}
+
+func TestStartBarrierRespectsCancellation(t *testing.T) {
+	require := require.New(t)
+	barrier := NewStartBarrier(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := barrier.Wait(ctx)
+	require.ErrorIs(err, context.DeadlineExceeded)
+	require.True(barrier.Start().IsZero())
+
+	// The cancelled call no longer counts as waiting, so a single call does not release the barrier.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = barrier.Wait(ctx)
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ Worker[THash] = (*latencyWorker[THash])(nil)
+
+// latencyWorker wraps a Worker to delay every IssueTx and ConfirmTx call by an artificial latency.
+type latencyWorker[T THash] struct {
+	Worker[T]
+	minDelay time.Duration
+	maxDelay time.Duration
+}
+
+// NewLatencyWorker returns a Worker that waits for a delay before forwarding each IssueTx and ConfirmTx
+// call to [worker]. The delay is [minDelay] if [maxDelay] is not greater, and is otherwise drawn
+// uniformly from [minDelay, maxDelay] for each call.
+//
+// This is a testing aid that simulates a slow node, so that the timeout and backoff behavior of
+// the simulator and any tooling downstream of it can be validated without degrading a real node.
+// The delay is abandoned with the error of the context if it is cancelled first.
+func NewLatencyWorker[T THash](worker Worker[T], minDelay time.Duration, maxDelay time.Duration) Worker[T] {
+	return &latencyWorker[T]{
+		Worker:   worker,
+		minDelay: minDelay,
+		maxDelay: maxDelay,
+	}
+}
+
+func (w *latencyWorker[T]) IssueTx(ctx context.Context, tx T) error {
+	if err := w.wait(ctx); err != nil {
+		return err
+	}
+	return w.Worker.IssueTx(ctx, tx)
+}
+
+func (w *latencyWorker[T]) ConfirmTx(ctx context.Context, tx T) error {
+	if err := w.wait(ctx); err != nil {
+		return err
+	}
+	return w.Worker.ConfirmTx(ctx, tx)
+}
+
+func (w *latencyWorker[T]) Forget(txHash common.Hash) {
+	Forget(w.Worker, txHash)
+}
+
+// wait blocks for the injected delay, or until [ctx] is cancelled.
+func (w *latencyWorker[T]) wait(ctx context.Context) error {
+	delay := w.minDelay
+	if w.maxDelay > w.minDelay {
+		delay += time.Duration(rand.Int63n(int64(w.maxDelay-w.minDelay) + 1))
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyWorkerDelaysCalls(t *testing.T) {
+	require := require.New(t)
+	const (
+		minDelay = 20 * time.Millisecond
+		maxDelay = 40 * time.Millisecond
+	)
+	inner := &delayWorker{}
+	worker := NewLatencyWorker[*types.Transaction](inner, minDelay, maxDelay)
+	tx := types.NewTx(&types.LegacyTx{})
+
+	start := time.Now()
+	require.NoError(worker.IssueTx(context.Background(), tx))
+	require.GreaterOrEqual(time.Since(start), minDelay)
+
+	start = time.Now()
+	require.NoError(worker.ConfirmTx(context.Background(), tx))
+	require.GreaterOrEqual(time.Since(start), minDelay)
+	require.Equal(uint64(1), inner.confirmed.Load())
+}
+
+func TestLatencyWorkerRespectsCancellation(t *testing.T) {
+	require := require.New(t)
+	inner := &delayWorker{}
+	worker := NewLatencyWorker[*types.Transaction](inner, time.Hour, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := worker.ConfirmTx(ctx, types.NewTx(&types.LegacyTx{}))
+	require.ErrorIs(err, context.DeadlineExceeded)
+	// The wrapped worker is not called once the delay is abandoned.
+	require.Zero(inner.confirmed.Load())
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import "github.com/ava-labs/subnet-evm/core/types"
+
+var _ TxSequence[*types.Transaction] = (*mergedSequence[*types.Transaction])(nil)
+
+// MergePolicy specifies the order in which MergeSequences interleaves the txs of its sequences.
+type MergePolicy int
+
+const (
+	// RoundRobinMerge takes one tx from each sequence in turn, skipping sequences once they are
+	// drained. Since it waits for the next tx of each sequence in turn, a sequence generated in the
+	// background holds back the txs of the other sequences until its next tx is generated.
+	RoundRobinMerge MergePolicy = iota
+	// InOrderMerge takes every tx of each sequence before moving on to the next sequence.
+	InOrderMerge
+)
+
+// mergedSequence is a TxSequence whose txs are forwarded from other sequences.
+type mergedSequence[T THash] struct {
+	txChan chan T
+}
+
+// MergeSequences returns a TxSequence with the txs of every sequence of [sequences], interleaved as
+// specified by [policy]. The txs of each sequence keep their order. The txs are forwarded in the
+// background, and the returned sequence is closed once every sequence of [sequences] is drained.
+func MergeSequences[T THash](policy MergePolicy, sequences ...TxSequence[T]) TxSequence[T] {
+	merged := &mergedSequence[T]{
+		txChan: make(chan T),
+	}
+	go func() {
+		defer close(merged.txChan)
+
+		if policy == InOrderMerge {
+			for _, sequence := range sequences {
+				for tx := range sequence.Chan() {
+					merged.txChan <- tx
+				}
+			}
+			return
+		}
+
+		open := make([]<-chan T, 0, len(sequences))
+		for _, sequence := range sequences {
+			open = append(open, sequence.Chan())
+		}
+		for len(open) > 0 {
+			stillOpen := open[:0]
+			for _, txChan := range open {
+				tx, ok := <-txChan
+				if !ok {
+					continue
+				}
+				merged.txChan <- tx
+				stillOpen = append(stillOpen, txChan)
+			}
+			open = stillOpen
+		}
+	}()
+	return merged
+}
+
+func (s *mergedSequence[T]) Chan() <-chan T {
+	return s.txChan
+}
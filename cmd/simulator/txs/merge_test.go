@@ -0,0 +1,76 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newNonceSequence returns a closed sequence of txs with [nonces].
+func newNonceSequence(nonces ...uint64) TxSequence[*types.Transaction] {
+	txs := make([]*types.Transaction, 0, len(nonces))
+	for _, nonce := range nonces {
+		txs = append(txs, types.NewTx(&types.LegacyTx{Nonce: nonce}))
+	}
+	return ConvertTxSliceToSequence(txs)
+}
+
+func collectNonces(sequence TxSequence[*types.Transaction]) []uint64 {
+	var nonces []uint64
+	for tx := range sequence.Chan() {
+		nonces = append(nonces, tx.Nonce())
+	}
+	return nonces
+}
+
+func TestMergeSequences(t *testing.T) {
+	tests := map[string]struct {
+		policy MergePolicy
+		want   []uint64
+	}{
+		"round robin": {
+			policy: RoundRobinMerge,
+			want:   []uint64{0, 10, 20, 1, 11, 2, 12, 3},
+		},
+		"in order": {
+			policy: InOrderMerge,
+			want:   []uint64{0, 1, 2, 3, 10, 11, 12, 20},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			merged := MergeSequences(
+				test.policy,
+				newNonceSequence(0, 1, 2, 3),
+				newNonceSequence(10, 11, 12),
+				newNonceSequence(20),
+				newNonceSequence(),
+			)
+			require.Equal(t, test.want, collectNonces(merged))
+		})
+	}
+}
+
+func TestMergeSequencesEmpty(t *testing.T) {
+	require.Empty(t, collectNonces(MergeSequences[*types.Transaction](RoundRobinMerge)))
+	require.Empty(t, collectNonces(MergeSequences[*types.Transaction](InOrderMerge)))
+}
+
+func TestMergeSequencesAsync(t *testing.T) {
+	require := require.New(t)
+
+	// Sequences generated in the background are merged as their txs are generated.
+	txChan := make(chan *types.Transaction)
+	go func() {
+		defer close(txChan)
+		for nonce := uint64(100); nonce < 103; nonce++ {
+			txChan <- types.NewTx(&types.LegacyTx{Nonce: nonce})
+		}
+	}()
+	merged := MergeSequences[*types.Transaction](RoundRobinMerge, &txSequence{txChan: txChan}, newNonceSequence(0, 1))
+	require.Equal([]uint64{100, 0, 101, 1, 102}, collectNonces(merged))
+}
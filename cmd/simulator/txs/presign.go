@@ -0,0 +1,99 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ TxGenerator = (*presignGenerator)(nil)
+
+// RawTxStore holds the hex encoded raw bytes of pre-signed txs, keyed by their hash, until they are
+// issued. It is safe for concurrent use, since txs may be generated in the background while they are
+// being issued.
+type RawTxStore struct {
+	lock sync.Mutex
+	raw  map[common.Hash]string
+	// Number of txs and encoded bytes ever added to the store.
+	txs   uint64
+	bytes uint64
+}
+
+// NewRawTxStore returns an empty RawTxStore.
+func NewRawTxStore() *RawTxStore {
+	return &RawTxStore{
+		raw: make(map[common.Hash]string),
+	}
+}
+
+// add encodes [tx] and stores its raw bytes until they are taken by Take. Computing the hash of [tx]
+// caches it, so that it is not computed when [tx] is issued either.
+func (s *RawTxStore) add(tx *types.Transaction) error {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode tx %s: %w", tx.Hash(), err)
+	}
+	raw := hexutil.Encode(data)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.raw[tx.Hash()] = raw
+	s.txs++
+	s.bytes += uint64(len(data))
+	return nil
+}
+
+// Take removes and returns the hex encoded raw bytes of the tx with [txHash], or false if the store
+// does not hold them, such as for a tx that was re-signed after it was pre-signed.
+func (s *RawTxStore) Take(txHash common.Hash) (string, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	raw, ok := s.raw[txHash]
+	if ok {
+		delete(s.raw, txHash)
+	}
+	return raw, ok
+}
+
+// Stats returns the number of txs and encoded bytes added to the store so far.
+func (s *RawTxStore) Stats() (uint64, uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.txs, s.bytes
+}
+
+// presignGenerator is a TxGenerator that adds the raw bytes of each tx it generates to a RawTxStore.
+type presignGenerator struct {
+	TxGenerator
+	store *RawTxStore
+}
+
+// Presign returns a TxGenerator that generates txs with [generator] and adds their raw bytes to [store]
+// as they are generated, so that a worker can issue them without encoding them again.
+func Presign(generator TxGenerator, store *RawTxStore) TxGenerator {
+	return &presignGenerator{
+		TxGenerator: generator,
+		store:       store,
+	}
+}
+
+func (g *presignGenerator) GenerateTx(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+	tx, err := g.TxGenerator.GenerateTx(key, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.store.add(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresign(t *testing.T) {
+	require := require.New(t)
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(err)
+
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	generator := CreateTx(func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+		return types.SignNewTx(key, signer, &types.LegacyTx{Nonce: nonce, Gas: 21_000, GasPrice: big.NewInt(1)})
+	})
+	store := NewRawTxStore()
+	presigned := Presign(generator, store)
+
+	tx, err := presigned.GenerateTx(key, 3)
+	require.NoError(err)
+	numTxs, numBytes := store.Stats()
+	require.Equal(uint64(1), numTxs)
+
+	// The stored raw bytes decode to the generated tx, and are only handed out once.
+	raw, ok := store.Take(tx.Hash())
+	require.True(ok)
+	data, err := hexutil.Decode(raw)
+	require.NoError(err)
+	require.Equal(uint64(len(data)), numBytes)
+	decoded := new(types.Transaction)
+	require.NoError(decoded.UnmarshalBinary(data))
+	require.Equal(tx.Hash(), decoded.Hash())
+
+	_, ok = store.Take(tx.Hash())
+	require.False(ok)
+	_, ok = store.Take(common.Hash{})
+	require.False(ok)
+}
@@ -0,0 +1,107 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	TxIssued        = "issued"
+	TxIssueFailed   = "issue_failed"
+	TxConfirmed     = "confirmed"
+	TxConfirmFailed = "confirm_failed"
+)
+
+var _ Worker[THash] = (*recordingWorker[THash])(nil)
+
+// TxRecord is a single entry written by a TxRecorder.
+type TxRecord struct {
+	TxHash common.Hash `json:"txHash"`
+	Event  string      `json:"event"`
+	Error  string      `json:"error,omitempty"`
+	Time   time.Time   `json:"time"`
+}
+
+// TxRecorder writes a JSON line for every transaction event to an underlying writer.
+// It is safe to share a single TxRecorder across multiple workers.
+type TxRecorder struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewTxRecorder returns a TxRecorder that writes to [w].
+func NewTxRecorder(w io.Writer) *TxRecorder {
+	return &TxRecorder{encoder: json.NewEncoder(w)}
+}
+
+// Record writes a record of [event] for [txHash] and the optional [err].
+func (r *TxRecorder) Record(txHash common.Hash, event string, err error) error {
+	record := TxRecord{
+		TxHash: txHash,
+		Event:  event,
+		Time:   time.Now(),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.encoder.Encode(record)
+}
+
+// recordingWorker wraps a Worker and records the outcome of each IssueTx and ConfirmTx call.
+type recordingWorker[T THash] struct {
+	Worker[T]
+	recorder *TxRecorder
+}
+
+// NewRecordingWorker returns a Worker that forwards all calls to [worker] and records
+// the hash and outcome of every issued and confirmed transaction to [recorder].
+func NewRecordingWorker[T THash](worker Worker[T], recorder *TxRecorder) Worker[T] {
+	return &recordingWorker[T]{
+		Worker:   worker,
+		recorder: recorder,
+	}
+}
+
+func (w *recordingWorker[T]) IssueTx(ctx context.Context, tx T) error {
+	err := w.Worker.IssueTx(ctx, tx)
+	event := TxIssued
+	if err != nil {
+		event = TxIssueFailed
+	}
+	if recordErr := w.recorder.Record(tx.Hash(), event, err); recordErr != nil && err == nil {
+		return recordErr
+	}
+	return err
+}
+
+func (w *recordingWorker[T]) ConfirmTx(ctx context.Context, tx T) error {
+	err := w.Worker.ConfirmTx(ctx, tx)
+	if errors.Is(err, ErrConfirmNotSampled) {
+		// The tx was not confirmed, but did not fail to confirm either.
+		return err
+	}
+	event := TxConfirmed
+	if err != nil {
+		event = TxConfirmFailed
+	}
+	if recordErr := w.recorder.Record(tx.Hash(), event, err); recordErr != nil && err == nil {
+		return recordErr
+	}
+	return err
+}
+
+func (w *recordingWorker[T]) Forget(txHash common.Hash) {
+	Forget(w.Worker, txHash)
+}
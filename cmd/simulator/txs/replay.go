@@ -0,0 +1,114 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// replayTxBufferSize is the number of replayed txs read ahead of the agent issuing them.
+const replayTxBufferSize = 1024
+
+var _ TxSequence[*types.Transaction] = (*ReplayTxSequence)(nil)
+
+// ReplayTxSequence streams the signed transactions recorded in a file in the order they were recorded.
+// The file holds RLP encoded transactions back to back, as written by types.Transaction.EncodeRLP.
+type ReplayTxSequence struct {
+	txChan chan *types.Transaction
+	numTxs uint64
+
+	lock sync.Mutex
+	err  error
+}
+
+// NewReplayTxSequence validates that every transaction in the file at [path] is signed for [chainID]
+// and then streams the transactions from the file until it is exhausted or [ctx] is done.
+// An error is returned without streaming any transaction if the file cannot be decoded or contains
+// a transaction signed for a different chain.
+func NewReplayTxSequence(ctx context.Context, path string, chainID *big.Int) (*ReplayTxSequence, error) {
+	var numTxs uint64
+	err := readTxs(path, func(tx *types.Transaction) error {
+		if tx.ChainId().Cmp(chainID) != 0 {
+			return fmt.Errorf("tx %d (%s) has chain ID %d, expected %d", numTxs, tx.Hash(), tx.ChainId(), chainID)
+		}
+		numTxs++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid replay file %s: %w", path, err)
+	}
+
+	sequence := &ReplayTxSequence{
+		txChan: make(chan *types.Transaction, replayTxBufferSize),
+		numTxs: numTxs,
+	}
+	go func() {
+		defer close(sequence.txChan)
+
+		err := readTxs(path, func(tx *types.Transaction) error {
+			select {
+			case sequence.txChan <- tx:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			sequence.lock.Lock()
+			sequence.err = fmt.Errorf("failed to replay txs from %s: %w", path, err)
+			sequence.lock.Unlock()
+		}
+	}()
+	return sequence, nil
+}
+
+// readTxs calls [onTx] with each transaction decoded from the file at [path], stopping at the
+// first error returned by [onTx].
+func readTxs(path string, onTx func(tx *types.Transaction) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stream := rlp.NewStream(f, 0)
+	for i := 0; ; i++ {
+		tx := new(types.Transaction)
+		if err := stream.Decode(tx); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode tx %d: %w", i, err)
+		}
+		if err := onTx(tx); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *ReplayTxSequence) Chan() <-chan *types.Transaction {
+	return s.txChan
+}
+
+// Len returns the number of transactions in the sequence.
+func (s *ReplayTxSequence) Len() uint64 {
+	return s.numTxs
+}
+
+// Err returns the error that stopped the sequence before every transaction in the file was streamed,
+// if any. It should be checked once the channel is closed.
+func (s *ReplayTxSequence) Err() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.err
+}
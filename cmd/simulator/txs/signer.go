@@ -0,0 +1,63 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts"
+	"github.com/ava-labs/subnet-evm/accounts/external"
+	"github.com/ava-labs/subnet-evm/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	_ TxSigner = (*localSigner)(nil)
+	_ TxSigner = (*remoteSigner)(nil)
+)
+
+// TxSigner signs [tx] on behalf of the account of [key].
+type TxSigner interface {
+	SignTx(key *ecdsa.PrivateKey, tx *types.Transaction) (*types.Transaction, error)
+}
+
+// localSigner signs transactions in memory with the provided private key.
+type localSigner struct {
+	signer types.Signer
+}
+
+// NewLocalSigner returns a TxSigner that signs transactions in memory using [signer].
+func NewLocalSigner(signer types.Signer) TxSigner {
+	return &localSigner{signer: signer}
+}
+
+func (s *localSigner) SignTx(key *ecdsa.PrivateKey, tx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(tx, s.signer, key)
+}
+
+// remoteSigner signs transactions via an external (clef-style) signer. The private key is only
+// used to derive the address of the account, which must be managed by the external signer.
+type remoteSigner struct {
+	signer  *external.ExternalSigner
+	chainID *big.Int
+}
+
+// NewRemoteSigner returns a TxSigner that signs transactions via the external signer at [endpoint].
+func NewRemoteSigner(endpoint string, chainID *big.Int) (TxSigner, error) {
+	signer, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer at %s: %w", endpoint, err)
+	}
+	return &remoteSigner{
+		signer:  signer,
+		chainID: chainID,
+	}, nil
+}
+
+func (s *remoteSigner) SignTx(key *ecdsa.PrivateKey, tx *types.Transaction) (*types.Transaction, error) {
+	account := accounts.Account{Address: ethcrypto.PubkeyToAddress(key.PublicKey)}
+	return s.signer.SignTx(account, tx, s.chainID)
+}
@@ -7,17 +7,121 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 )
 
-var _ TxSequence[*types.Transaction] = (*txSequence)(nil)
+const (
+	// nonceRetries is the maximum number of times the starting nonce of a key is re-fetched after a failed query.
+	nonceRetries = 3
+	// nonceInitialBackoff is the time to wait before re-fetching a starting nonce for the first time,
+	// which doubles after each retry.
+	nonceInitialBackoff = 200 * time.Millisecond
+)
+
+var (
+	_ TxSequence[*types.Transaction] = (*txSequence)(nil)
+	_ TxGenerator                    = CreateTx(nil)
+)
+
+// TxGenerator generates the transactions of a workload.
+type TxGenerator interface {
+	// Setup performs any one-time setup required by the workload, such as deploying a contract.
+	// It is called once before any transaction is generated.
+	Setup(ctx context.Context) error
+	// GenerateTx creates the transaction signed by [key] with [nonce].
+	GenerateTx(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error)
+}
 
+// CreateTx creates the transaction signed by [key] with [nonce].
+// It implements TxGenerator for workloads that require no setup.
 type CreateTx func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error)
 
+// Setup is a no-op.
+func (CreateTx) Setup(context.Context) error {
+	return nil
+}
+
+// GenerateTx calls f(key, nonce).
+func (f CreateTx) GenerateTx(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+	return f(key, nonce)
+}
+
+// GenerateTxSequencesFrom calls Setup on [generator] and then generates a sequence of [txsPerKey]
+// transactions for each key in [keys] with it, as GenerateTxSequences.
+func GenerateTxSequencesFrom(ctx context.Context, generator TxGenerator, client ethclient.Client, keys []*ecdsa.PrivateKey, txsPerKey uint64, async bool) ([]TxSequence[*types.Transaction], error) {
+	if err := generator.Setup(ctx); err != nil {
+		return nil, fmt.Errorf("failed to set up tx generator: %w", err)
+	}
+	return GenerateTxSequences(ctx, generator.GenerateTx, client, keys, txsPerKey, async)
+}
+
+// GenerateTxSequence generates a sequence of [numTxs] transactions signed by [key] with [generator],
+// starting at the current nonce of [key]. If [async] is true, the transactions are generated in the
+// background and the sequence is returned immediately.
+// Generation stops as soon as [ctx] is cancelled, in which case the returned error wraps ctx.Err().
 func GenerateTxSequence(ctx context.Context, generator CreateTx, client ethclient.Client, key *ecdsa.PrivateKey, numTxs uint64, async bool) (TxSequence[*types.Transaction], error) {
+	startingNonce, err := fetchNonce(ctx, client, ethcrypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	return generateTxSequence(ctx, generator, key, startingNonce, numTxs, async)
+}
+
+// GenerateTxSequenceAt generates a sequence of [numTxs] transactions signed by [key] with [generator]
+// like GenerateTxSequence, but starting at [startingNonce] instead of the current nonce of [key].
+func GenerateTxSequenceAt(ctx context.Context, generator CreateTx, key *ecdsa.PrivateKey, startingNonce uint64, numTxs uint64, async bool) (TxSequence[*types.Transaction], error) {
+	return generateTxSequence(ctx, generator, key, startingNonce, numTxs, async)
+}
+
+// GenerateTxSequenceBuffered generates a sequence of [numTxs] transactions signed by [key] with [generator]
+// like GenerateTxSequence, but holds at most [bufferSize] transactions that have not been consumed yet, so
+// that memory stays bounded for a large [numTxs]. The first [bufferSize] transactions are generated before
+// the sequence is returned, and the rest in the background as the sequence is consumed.
+// If [bufferSize] is 0 or at least [numTxs], every transaction is generated before the sequence is returned.
+func GenerateTxSequenceBuffered(ctx context.Context, generator CreateTx, client ethclient.Client, key *ecdsa.PrivateKey, numTxs uint64, bufferSize uint64) (TxSequence[*types.Transaction], error) {
+	if bufferSize == 0 || bufferSize >= numTxs {
+		return GenerateTxSequence(ctx, generator, client, key, numTxs, false)
+	}
+	address := ethcrypto.PubkeyToAddress(key.PublicKey)
+	startingNonce, err := fetchNonce(ctx, client, address)
+	if err != nil {
+		return nil, err
+	}
+	sequence := &txSequence{
+		txChan: make(chan *types.Transaction, bufferSize),
+	}
+	if err := addTxs(ctx, sequence, generator, key, startingNonce, bufferSize); err != nil {
+		return nil, err
+	}
+	go func() {
+		defer close(sequence.txChan)
+
+		for nonce := startingNonce + bufferSize; nonce < startingNonce+numTxs; nonce++ {
+			tx, err := generator(key, nonce)
+			if err != nil {
+				log.Error("Failed to generate tx, ending sequence", "address", address, "nonce", nonce, "err", err)
+				return
+			}
+			select {
+			case sequence.txChan <- tx:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return sequence, nil
+}
+
+// generateTxSequence generates a sequence of [numTxs] transactions signed by [key] with [generator],
+// starting at [startingNonce], as GenerateTxSequence.
+func generateTxSequence(ctx context.Context, generator CreateTx, key *ecdsa.PrivateKey, startingNonce uint64, numTxs uint64, async bool) (TxSequence[*types.Transaction], error) {
 	sequence := &txSequence{
 		txChan: make(chan *types.Transaction, numTxs),
 	}
@@ -26,12 +130,13 @@ func GenerateTxSequence(ctx context.Context, generator CreateTx, client ethclien
 		go func() {
 			defer close(sequence.txChan)
 
-			if err := addTxs(ctx, sequence, generator, client, key, numTxs); err != nil {
+			// If [ctx] is cancelled, the sequence ends early with the txs generated so far.
+			if err := addTxs(ctx, sequence, generator, key, startingNonce, numTxs); err != nil && ctx.Err() == nil {
 				panic(err)
 			}
 		}()
 	} else {
-		if err := addTxs(ctx, sequence, generator, client, key, numTxs); err != nil {
+		if err := addTxs(ctx, sequence, generator, key, startingNonce, numTxs); err != nil {
 			return nil, err
 		}
 		close(sequence.txChan)
@@ -40,25 +145,126 @@ func GenerateTxSequence(ctx context.Context, generator CreateTx, client ethclien
 	return sequence, nil
 }
 
+// GenerateTxSequenceUntilDone generates transactions signed by [key] with [generator] in the background
+// until [ctx] is done, starting at the current nonce of [key], and returns the sequence immediately.
+// Since the number of transactions is unbounded, at most [bufferSize] transactions are generated ahead
+// of the consumer of the sequence. The nonces of the sequence are contiguous, and the sequence is closed
+// once [ctx] is done, so that the consumer can drain the transactions it has already received.
+func GenerateTxSequenceUntilDone(ctx context.Context, generator CreateTx, client ethclient.Client, key *ecdsa.PrivateKey, bufferSize uint64) (TxSequence[*types.Transaction], error) {
+	address := ethcrypto.PubkeyToAddress(key.PublicKey)
+	startingNonce, err := fetchNonce(ctx, client, address)
+	if err != nil {
+		return nil, err
+	}
+	sequence := &txSequence{
+		txChan: make(chan *types.Transaction, bufferSize),
+	}
+	go func() {
+		defer close(sequence.txChan)
+
+		for nonce := startingNonce; ctx.Err() == nil; nonce++ {
+			tx, err := generator(key, nonce)
+			if err != nil {
+				log.Error("Failed to generate tx, ending sequence", "address", address, "nonce", nonce, "err", err)
+				return
+			}
+			select {
+			case sequence.txChan <- tx:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return sequence, nil
+}
+
+// GenerateTxSequences generates a sequence of [txsPerKey] transactions for each key in [keys] with
+// [generator], as GenerateTxSequence.
+//
+// The starting nonce of each key is re-fetched with exponential backoff if querying it fails. If it still
+// cannot be fetched, the other keys are not affected: the sequences of the other keys are returned along
+// with a *NonceFetchError reporting the keys that failed, whose sequences are nil. Any other error aborts
+// the generation and no sequences are returned.
 func GenerateTxSequences(ctx context.Context, generator CreateTx, client ethclient.Client, keys []*ecdsa.PrivateKey, txsPerKey uint64, async bool) ([]TxSequence[*types.Transaction], error) {
 	txSequences := make([]TxSequence[*types.Transaction], len(keys))
+	nonceErr := &NonceFetchError{}
 	for i, key := range keys {
-		txs, err := GenerateTxSequence(ctx, generator, client, key, txsPerKey, async)
+		address := ethcrypto.PubkeyToAddress(key.PublicKey)
+		startingNonce, err := fetchNonce(ctx, client, address)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, fmt.Errorf("failed to generate tx sequence at index %d: %w", i, ctxErr)
+			}
+			log.Warn("Failed to fetch starting nonce, skipping key", "index", i, "address", address, "err", err)
+			nonceErr.FailedKeys = append(nonceErr.FailedKeys, FailedKey{
+				Index:   i,
+				Address: address,
+				Err:     err,
+			})
+			continue
+		}
+		txs, err := generateTxSequence(ctx, generator, key, startingNonce, txsPerKey, async)
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate tx sequence at index %d: %w", i, err)
 		}
 		txSequences[i] = txs
 	}
+	if len(nonceErr.FailedKeys) > 0 {
+		return txSequences, nonceErr
+	}
 	return txSequences, nil
 }
 
-func addTxs(ctx context.Context, txSequence *txSequence, generator CreateTx, client ethclient.Client, key *ecdsa.PrivateKey, numTxs uint64) error {
-	address := ethcrypto.PubkeyToAddress(key.PublicKey)
-	startingNonce, err := client.NonceAt(ctx, address, nil)
-	if err != nil {
-		return err
+// FailedKey is a key whose starting nonce could not be fetched.
+type FailedKey struct {
+	// Index is the index of the key in the keys passed to GenerateTxSequences.
+	Index   int
+	Address common.Address
+	// Err is the error of the last attempt to fetch the starting nonce of the key.
+	Err error
+}
+
+// NonceFetchError is returned by GenerateTxSequences if the starting nonce of some of its keys could not
+// be fetched, in which case no sequence was generated for them.
+type NonceFetchError struct {
+	FailedKeys []FailedKey
+}
+
+func (e *NonceFetchError) Error() string {
+	failures := make([]string, len(e.FailedKeys))
+	for i, failedKey := range e.FailedKeys {
+		failures[i] = fmt.Sprintf("index %d (%s): %s", failedKey.Index, failedKey.Address, failedKey.Err)
+	}
+	return fmt.Sprintf("failed to fetch the starting nonce of %d keys: %s", len(e.FailedKeys), strings.Join(failures, "; "))
+}
+
+// fetchNonce returns the current nonce of [address], re-fetching it up to [nonceRetries] times with
+// exponential backoff if the query fails. If [ctx] is done, it returns the error of the last query.
+func fetchNonce(ctx context.Context, client ethclient.Client, address common.Address) (uint64, error) {
+	backoff := nonceInitialBackoff
+	for retry := 0; ; retry++ {
+		nonce, err := client.NonceAt(ctx, address, nil)
+		if err == nil {
+			return nonce, nil
+		}
+		if retry == nonceRetries || ctx.Err() != nil {
+			return 0, fmt.Errorf("failed to fetch nonce of %s after %d attempts: %w", address, retry+1, err)
+		}
+		log.Debug("Failed to fetch nonce, retrying", "address", address, "retry", retry+1, "backoff", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return 0, fmt.Errorf("failed to fetch nonce of %s after %d attempts: %w", address, retry+1, err)
+		}
+		backoff *= 2
 	}
+}
+
+func addTxs(ctx context.Context, txSequence *txSequence, generator CreateTx, key *ecdsa.PrivateKey, startingNonce uint64, numTxs uint64) error {
 	for i := uint64(0); i < numTxs; i++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("stopped generating txs after %d of %d: %w", i, numTxs, err)
+		}
 		tx, err := generator(key, startingNonce+i)
 		if err != nil {
 			return err
@@ -0,0 +1,219 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// ethClient aliases ethclient.Client so that it can be embedded despite its Client method.
+type ethClient = ethclient.Client
+
+// nonceClient is an ethclient.Client that only implements NonceAt.
+type nonceClient struct {
+	ethClient
+}
+
+func (nonceClient) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	return 0, nil
+}
+
+// flakyNonceClient is an ethclient.Client that only implements NonceAt, which fails the first [failures]
+// queries for each address, and every query for the addresses in [broken].
+type flakyNonceClient struct {
+	ethClient
+	failures int
+	broken   map[common.Address]bool
+
+	lock    sync.Mutex
+	queries map[common.Address]int
+}
+
+func (c *flakyNonceClient) NonceAt(_ context.Context, address common.Address, _ *big.Int) (uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.queries[address]++
+	if c.broken[address] || c.queries[address] <= c.failures {
+		return 0, errors.New("connection reset by peer")
+	}
+	return 5, nil
+}
+
+// cancellingGenerator returns a CreateTx that cancels [cancel] once it has generated [cancelAfter] txs.
+func cancellingGenerator(cancel context.CancelFunc, cancelAfter uint64) CreateTx {
+	var generated uint64
+	return func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+		generated++
+		if generated == cancelAfter {
+			cancel()
+		}
+		return types.NewTx(&types.LegacyTx{Nonce: nonce}), nil
+	}
+}
+
+func TestGenerateTxSequenceCancelled(t *testing.T) {
+	require := require.New(t)
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(err)
+
+	const (
+		numTxs      = 1_000_000
+		cancelAfter = 10
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Now()
+	_, err = GenerateTxSequence(ctx, cancellingGenerator(cancel, cancelAfter), nonceClient{}, key, numTxs, false)
+	require.ErrorIs(err, context.Canceled)
+	require.Less(time.Since(start), time.Second)
+}
+
+func TestGenerateTxSequenceCancelledAsync(t *testing.T) {
+	require := require.New(t)
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(err)
+
+	const (
+		numTxs      = 1_000_000
+		cancelAfter = 10
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sequence, err := GenerateTxSequence(ctx, cancellingGenerator(cancel, cancelAfter), nonceClient{}, key, numTxs, true)
+	require.NoError(err)
+
+	// The sequence is closed with the txs generated before cancellation.
+	var numGenerated int
+	for range sequence.Chan() {
+		numGenerated++
+	}
+	require.Equal(cancelAfter, numGenerated)
+}
+
+func TestGenerateTxSequenceUntilDone(t *testing.T) {
+	require := require.New(t)
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const cancelAfter = 100
+	generator := func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+		return types.NewTx(&types.LegacyTx{Nonce: nonce}), nil
+	}
+	sequence, err := GenerateTxSequenceUntilDone(ctx, generator, nonceClient{}, key, 10)
+	require.NoError(err)
+
+	// The nonces stay contiguous until the sequence is closed after cancellation.
+	var nextNonce uint64
+	for tx := range sequence.Chan() {
+		require.Equal(nextNonce, tx.Nonce())
+		nextNonce++
+		if nextNonce == cancelAfter {
+			cancel()
+		}
+	}
+	require.GreaterOrEqual(nextNonce, uint64(cancelAfter))
+}
+
+func TestGenerateTxSequencesNonceRetries(t *testing.T) {
+	require := require.New(t)
+
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := range keys {
+		key, err := ethcrypto.GenerateKey()
+		require.NoError(err)
+		keys[i] = key
+	}
+	brokenAddr := ethcrypto.PubkeyToAddress(keys[2].PublicKey)
+	client := &flakyNonceClient{
+		failures: 1,
+		broken:   map[common.Address]bool{brokenAddr: true},
+		queries:  make(map[common.Address]int),
+	}
+	generator := func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+		return types.NewTx(&types.LegacyTx{Nonce: nonce}), nil
+	}
+
+	const txsPerKey = 3
+	sequences, err := GenerateTxSequences(context.Background(), generator, client, keys, txsPerKey, false)
+
+	// Only the key whose nonce queries fail on every retry is reported.
+	var nonceErr *NonceFetchError
+	require.ErrorAs(err, &nonceErr)
+	require.Len(nonceErr.FailedKeys, 1)
+	require.Equal(2, nonceErr.FailedKeys[0].Index)
+	require.Equal(brokenAddr, nonceErr.FailedKeys[0].Address)
+	require.Equal(nonceRetries+1, client.queries[brokenAddr])
+
+	// The transient failures of the other keys are retried, and their sequences start at their nonce.
+	require.Len(sequences, len(keys))
+	for i, sequence := range sequences {
+		if i == 2 {
+			require.Nil(sequence)
+			continue
+		}
+		require.Equal(2, client.queries[ethcrypto.PubkeyToAddress(keys[i].PublicKey)])
+		nextNonce := uint64(5)
+		for tx := range sequence.Chan() {
+			require.Equal(nextNonce, tx.Nonce())
+			nextNonce++
+		}
+		require.Equal(uint64(5+txsPerKey), nextNonce)
+	}
+}
+
+func TestGenerateTxSequenceBuffered(t *testing.T) {
+	require := require.New(t)
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(err)
+
+	const (
+		numTxs     = 100
+		bufferSize = 10
+	)
+	var (
+		lock      sync.Mutex
+		generated uint64
+	)
+	generator := func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+		lock.Lock()
+		generated++
+		lock.Unlock()
+		return types.NewTx(&types.LegacyTx{Nonce: nonce}), nil
+	}
+	sequence, err := GenerateTxSequenceBuffered(context.Background(), generator, nonceClient{}, key, numTxs, bufferSize)
+	require.NoError(err)
+
+	// The buffer is filled before the sequence is returned, and at most one tx beyond it is generated
+	// before any tx is consumed.
+	time.Sleep(10 * time.Millisecond)
+	lock.Lock()
+	require.GreaterOrEqual(generated, uint64(bufferSize))
+	require.LessOrEqual(generated, uint64(bufferSize+1))
+	lock.Unlock()
+
+	var nextNonce uint64
+	for tx := range sequence.Chan() {
+		require.Equal(nextNonce, tx.Nonce())
+		nextNonce++
+	}
+	require.Equal(uint64(numTxs), nextNonce)
+}
@@ -11,6 +11,19 @@ struct WarpMessage {
   bytes payload;
 }
 
+struct SequencedWarpMessage {
+  bytes32 sourceChainID;
+  address originSenderAddress;
+  uint64 sequence;
+  bytes payload;
+}
+
+struct SendWarpMessageInput {
+  bytes32 destinationChainID;
+  bytes32 destinationAddress;
+  bytes payload;
+}
+
 struct WarpBlockHash {
   bytes32 sourceChainID;
   bytes32 blockHash;
@@ -28,6 +41,34 @@ interface IWarpMessenger {
   // it is willing to sign for an off-chain relayer to aggregate Warp signatures.
   function sendWarpMessage(bytes calldata payload) external returns (bytes32 messageID);
 
+  // sendWarpMessageWithIndex sends a warp message as sendWarpMessage does and also returns the
+  // [index] of the message among the messages sent in the current transaction, which can be passed
+  // to getSentWarpMessage.
+  // Only available if sentMessagesEnabled is set in the config of the Warp precompile.
+  function sendWarpMessageWithIndex(bytes calldata payload) external returns (bytes32 messageID, uint32 index);
+
+  // sendWarpMessageMulti emits a request for the subnet to send a warp message from [msg.sender]
+  // to each of [destinationChainIDs]. The payload of each message is
+  // abi.encode(destinationChainID, destinationAddress, payload).
+  // This emits one SendWarpMessage log per destination chain and returns the message IDs in the
+  // order of [destinationChainIDs].
+  // Reverts on a destination chain other than this chain that is not in the destinationChainIDs config, if it is set.
+  // Only available if enabled in the config of the Warp precompile.
+  function sendWarpMessageMulti(
+    bytes32[] calldata destinationChainIDs,
+    bytes32 destinationAddress,
+    bytes calldata payload
+  ) external returns (bytes32[] memory messageIDs);
+
+  // sendWarpMessages emits a request for the subnet to send each of [messages] from [msg.sender],
+  // each to its own destination chain and address with its own payload. The payload of each message is
+  // abi.encode(destinationChainID, destinationAddress, payload), as for sendWarpMessageMulti.
+  // This emits one SendWarpMessage log per message and returns the message IDs in the order of [messages].
+  // Reverts on a destination chain other than this chain that is not in the destinationChainIDs config, if it is set.
+  // Only available if the maxBatchMessages config of the precompile is set, which bounds the number of
+  // messages per call.
+  function sendWarpMessages(SendWarpMessageInput[] calldata messages) external returns (bytes32[] memory messageIDs);
+
   // getVerifiedWarpMessage parses the pre-verified warp message in the
   // predicate storage slots as a WarpMessage and returns it to the caller.
   // If the message exists and passes verification, returns the verified message
@@ -35,6 +76,41 @@ interface IWarpMessenger {
   // Otherwise, returns false and the empty value for the message.
   function getVerifiedWarpMessage(uint32 index) external view returns (WarpMessage calldata message, bool valid);
 
+  // getVerifiedWarpMessageRaw behaves as getVerifiedWarpMessage and additionally returns
+  // the bytes of the unsigned warp message, so that it can be forwarded or re-hashed
+  // without re-encoding it.
+  // Only available if enabled by the rawMessagesEnabled config of the precompile.
+  function getVerifiedWarpMessageRaw(
+    uint32 index
+  ) external view returns (WarpMessage calldata message, bytes calldata unsignedMessage, bool valid);
+
+  // getVerifiedSequencedWarpMessage behaves as getVerifiedWarpMessage and additionally decodes
+  // the sequence of a payload encoded as
+  // abi.encodeWithSignature("sequencedWarpMessage(uint64,bytes)", sequence, payload).
+  // A sequenced message is only valid if its sequence is one more than the last sequence consumed
+  // from its source chain and origin sender, starting at 1, and consuming it records its sequence.
+  // Any other payload is returned as is with a sequence of 0 and is not ordered.
+  // Only available if enabled by the enforceSequenceOrdering config of the precompile.
+  function getVerifiedSequencedWarpMessage(
+    uint32 index
+  ) external returns (SequencedWarpMessage memory message, bool valid);
+
+  // estimateVerifiedWarpMessageGas returns the gas getVerifiedWarpMessage charges to read the
+  // warp message at [index], without reading it, so that contracts can budget for the call.
+  // If the message does not exist or failed verification, returns the base cost charged for
+  // an invalid message and false.
+  // Only available if enabled by the gasEstimatesEnabled config of the precompile.
+  function estimateVerifiedWarpMessageGas(uint32 index) external view returns (uint64 gas, bool valid);
+
+  // getSentWarpMessage returns the WarpMessage and message ID sent by sendWarpMessage at [index]
+  // within the current transaction, so that the sender can reference the message it just sent.
+  // If no message was sent at [index] in the current transaction, returns false and the empty
+  // value for the message.
+  // Only available if enabled by the sentMessagesEnabled config of the precompile.
+  function getSentWarpMessage(
+    uint32 index
+  ) external view returns (WarpMessage calldata message, bytes32 messageID, bool valid);
+
   // getVerifiedWarpBlockHash parses the pre-verified WarpBlockHash message in the
   // predicate storage slots as a WarpBlockHash message and returns it to the caller.
   // If the message exists and passes verification, returns the verified message
@@ -48,4 +124,13 @@ interface IWarpMessenger {
   // This blockchainID is the hash of the transaction that created this blockchain on the P-Chain
   // and is not related to the Ethereum ChainID.
   function getBlockchainID() external view returns (bytes32 blockchainID);
+
+  // getWarpFormatVersion returns the codec version of the warp messages sent by this chain, so that
+  // receivers can check that they support the format of its messages.
+  // Only available if enabled by the formatVersionEnabled config of the precompile.
+  function getWarpFormatVersion() external view returns (uint16 version);
+
+  // hasWarpPredicates returns whether the current transaction includes warp messages in its access list.
+  // Only available if enabled by the warpPredicatesEnabled config of the precompile.
+  function hasWarpPredicates() external view returns (bool hasPredicates);
 }
@@ -16,7 +16,10 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-var ErrMissingPredicateContext = errors.New("missing predicate context")
+var (
+	ErrMissingPredicateContext = errors.New("missing predicate context")
+	ErrTooManyPredicates       = errors.New("too many predicates")
+)
 
 // CheckPredicates verifies the predicates of [tx] and returns the result. Returning an error invalidates the block.
 func CheckPredicates(rules params.Rules, predicateContext *precompileconfig.PredicateContext, tx *types.Transaction) (map[common.Address][]byte, error) {
@@ -49,6 +52,18 @@ func CheckPredicates(rules params.Rules, predicateContext *precompileconfig.Pred
 		return nil, ErrMissingPredicateContext
 	}
 
+	// Enforce any per-transaction predicate limits before verifying any predicates, so that
+	// a transaction cannot force an unbounded amount of verification work.
+	for address, predicates := range predicateArguments {
+		limiter, ok := rules.Predicaters[address].(precompileconfig.PredicateLimiter)
+		if !ok {
+			continue
+		}
+		if maxPredicates, limited := limiter.MaxPredicates(); limited && len(predicates) > maxPredicates {
+			return nil, fmt.Errorf("%w for %s: %d > max %d", ErrTooManyPredicates, address, len(predicates), maxPredicates)
+		}
+	}
+
 	for address, predicates := range predicateArguments {
 		// Since [address] is only added to [predicateArguments] when there's a valid predicate in the ruleset
 		// there's no need to check if the predicate exists here.
@@ -56,6 +71,7 @@ func CheckPredicates(rules params.Rules, predicateContext *precompileconfig.Pred
 		bitset := set.NewBits()
 		for i, predicate := range predicates {
 			if err := predicaterContract.VerifyPredicate(predicateContext, predicate); err != nil {
+				log.Debug("predicate verification failed", "tx", tx.Hash(), "address", address, "index", i, "pChainHeight", predicateContext.ProposerVMBlockCtx.PChainHeight, "err", err)
 				bitset.Add(i)
 			}
 		}
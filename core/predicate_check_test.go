@@ -17,6 +17,15 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+// limitedPredicater wraps a MockPredicater with a fixed PredicateLimiter limit.
+// A maxPredicates of 0 reports that there is no limit.
+type limitedPredicater struct {
+	*precompileconfig.MockPredicater
+	maxPredicates int
+}
+
+func (l *limitedPredicater) MaxPredicates() (int, bool) { return l.maxPredicates, l.maxPredicates > 0 }
+
 type predicateCheckTest struct {
 	accessList       types.AccessList
 	gas              uint64
@@ -271,6 +280,68 @@ func TestCheckPredicate(t *testing.T) {
 			expectedRes: make(map[common.Address][]byte),
 			expectedErr: nil,
 		},
+		"predicates at limit are verified": {
+			gas:              53000,
+			predicateContext: predicateContext,
+			createPredicates: func(t testing.TB) map[common.Address]precompileconfig.Predicater {
+				predicater := precompileconfig.NewMockPredicater(gomock.NewController(t))
+				arg := common.Hash{1}
+				predicater.EXPECT().PredicateGas(arg[:]).Return(uint64(0), nil).Times(4)
+				predicater.EXPECT().VerifyPredicate(gomock.Any(), arg[:]).Return(nil).Times(2)
+				return map[common.Address]precompileconfig.Predicater{
+					addr1: &limitedPredicater{MockPredicater: predicater, maxPredicates: 2},
+				}
+			},
+			accessList: types.AccessList([]types.AccessTuple{
+				{Address: addr1, StorageKeys: []common.Hash{{1}}},
+				{Address: addr1, StorageKeys: []common.Hash{{1}}},
+			}),
+			expectedRes: map[common.Address][]byte{
+				addr1: {}, // valid bytes
+			},
+			expectedErr: nil,
+		},
+		"predicates over limit are rejected without verification": {
+			gas:              53000,
+			predicateContext: predicateContext,
+			createPredicates: func(t testing.TB) map[common.Address]precompileconfig.Predicater {
+				predicater := precompileconfig.NewMockPredicater(gomock.NewController(t))
+				arg := common.Hash{1}
+				predicater.EXPECT().PredicateGas(arg[:]).Return(uint64(0), nil).Times(3)
+				// No VerifyPredicate expectation: any verification work fails the test.
+				return map[common.Address]precompileconfig.Predicater{
+					addr1: &limitedPredicater{MockPredicater: predicater, maxPredicates: 2},
+				}
+			},
+			accessList: types.AccessList([]types.AccessTuple{
+				{Address: addr1, StorageKeys: []common.Hash{{1}}},
+				{Address: addr1, StorageKeys: []common.Hash{{1}}},
+				{Address: addr1, StorageKeys: []common.Hash{{1}}},
+			}),
+			expectedErr: ErrTooManyPredicates,
+		},
+		"predicates without limit are verified": {
+			gas:              53000,
+			predicateContext: predicateContext,
+			createPredicates: func(t testing.TB) map[common.Address]precompileconfig.Predicater {
+				predicater := precompileconfig.NewMockPredicater(gomock.NewController(t))
+				arg := common.Hash{1}
+				predicater.EXPECT().PredicateGas(arg[:]).Return(uint64(0), nil).Times(6)
+				predicater.EXPECT().VerifyPredicate(gomock.Any(), arg[:]).Return(nil).Times(3)
+				return map[common.Address]precompileconfig.Predicater{
+					addr1: &limitedPredicater{MockPredicater: predicater},
+				}
+			},
+			accessList: types.AccessList([]types.AccessTuple{
+				{Address: addr1, StorageKeys: []common.Hash{{1}}},
+				{Address: addr1, StorageKeys: []common.Hash{{1}}},
+				{Address: addr1, StorageKeys: []common.Hash{{1}}},
+			}),
+			expectedRes: map[common.Address][]byte{
+				addr1: {}, // valid bytes
+			},
+			expectedErr: nil,
+		},
 		"insufficient gas": {
 			gas:              53000,
 			predicateContext: predicateContext,
@@ -116,12 +116,12 @@ func (b *Block) handlePrecompileAccept(rules params.Rules, sharedMemoryWriter *s
 		Warp:         b.vm.warpBackend,
 	}
 	for _, receipt := range receipts {
-		for logIdx, log := range receipt.Logs {
+		for _, log := range receipt.Logs {
 			accepter, ok := rules.AccepterPrecompiles[log.Address]
 			if !ok {
 				continue
 			}
-			if err := accepter.Accept(acceptCtx, log.BlockHash, log.BlockNumber, log.TxHash, logIdx, log.Topics, log.Data); err != nil {
+			if err := accepter.Accept(acceptCtx, log.BlockHash, log.BlockNumber, log.TxHash, int(log.Index), log.Topics, log.Data); err != nil {
 				return err
 			}
 		}
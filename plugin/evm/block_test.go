@@ -51,16 +51,27 @@ func TestHandlePrecompileAccept(t *testing.T) {
 			},
 		},
 	}
+	// Prepare a receipt of a second tx with a log from the precompile, whose index follows the logs of the first tx
+	receipt2 := &types.Receipt{
+		Logs: []*types.Log{
+			{
+				Address: precompileAddr,
+				Topics:  []common.Hash{{0x01}, {0x02}, {0x06}},
+				Data:    []byte("log4"),
+			},
+		},
+	}
+	receipts := []*types.Receipt{receipt, receipt2}
 	ethBlock := types.NewBlock(
 		&types.Header{Number: big.NewInt(1)},
-		[]*types.Transaction{types.NewTx(&types.LegacyTx{})},
+		[]*types.Transaction{types.NewTx(&types.LegacyTx{}), types.NewTx(&types.LegacyTx{Nonce: 1})},
 		nil,
-		[]*types.Receipt{receipt},
+		receipts,
 		trie.NewStackTrie(nil),
 	)
 	// Write the block to the db
 	rawdb.WriteBlock(db, ethBlock)
-	rawdb.WriteReceipts(db, ethBlock.Hash(), ethBlock.NumberU64(), []*types.Receipt{receipt})
+	rawdb.WriteReceipts(db, ethBlock.Hash(), ethBlock.NumberU64(), receipts)
 
 	// Set up the mock with the expected calls to Accept
 	txIndex := 0
@@ -84,6 +95,15 @@ func TestHandlePrecompileAccept(t *testing.T) {
 			receipt.Logs[2].Topics,                  // topics
 			receipt.Logs[2].Data,                    // logData
 		),
+		mockAccepter.EXPECT().Accept(
+			gomock.Not(gomock.Nil()),          // acceptCtx
+			ethBlock.Hash(),                   // blockHash
+			ethBlock.NumberU64(),              // blockNumber
+			ethBlock.Transactions()[1].Hash(), // txHash
+			3,                                 // logIndex
+			receipt2.Logs[0].Topics,           // topics
+			receipt2.Logs[0].Data,             // logData
+		),
 	)
 
 	// Call handlePrecompileAccept
@@ -308,6 +308,20 @@ func (mr *MockStateDBMockRecorder) GetState(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockStateDB)(nil).GetState), arg0, arg1)
 }
 
+// GetTransientState mocks base method.
+func (m *MockStateDB) GetTransientState(arg0 common.Address, arg1 common.Hash) common.Hash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransientState", arg0, arg1)
+	ret0, _ := ret[0].(common.Hash)
+	return ret0
+}
+
+// GetTransientState indicates an expected call of GetTransientState.
+func (mr *MockStateDBMockRecorder) GetTransientState(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransientState", reflect.TypeOf((*MockStateDB)(nil).GetTransientState), arg0, arg1)
+}
+
 // GetTxHash mocks base method.
 func (m *MockStateDB) GetTxHash() common.Hash {
 	m.ctrl.T.Helper()
@@ -370,6 +384,18 @@ func (mr *MockStateDBMockRecorder) SetState(arg0, arg1, arg2 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetState", reflect.TypeOf((*MockStateDB)(nil).SetState), arg0, arg1, arg2)
 }
 
+// SetTransientState mocks base method.
+func (m *MockStateDB) SetTransientState(arg0 common.Address, arg1, arg2 common.Hash) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTransientState", arg0, arg1, arg2)
+}
+
+// SetTransientState indicates an expected call of SetTransientState.
+func (mr *MockStateDBMockRecorder) SetTransientState(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransientState", reflect.TypeOf((*MockStateDB)(nil).SetTransientState), arg0, arg1, arg2)
+}
+
 // Snapshot mocks base method.
 func (m *MockStateDB) Snapshot() int {
 	m.ctrl.T.Helper()
@@ -383,3 +409,15 @@ func (mr *MockStateDBMockRecorder) Snapshot() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockStateDB)(nil).Snapshot))
 }
+
+// SubBalance mocks base method.
+func (m *MockStateDB) SubBalance(arg0 common.Address, arg1 *big.Int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SubBalance", arg0, arg1)
+}
+
+// SubBalance indicates an expected call of SubBalance.
+func (mr *MockStateDBMockRecorder) SubBalance(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubBalance", reflect.TypeOf((*MockStateDB)(nil).SubBalance), arg0, arg1)
+}
@@ -0,0 +1,28 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FormatBlockchainID returns the CB58 string of [blockchainID] as returned by getBlockchainID,
+// which is how the Avalanche platform displays blockchain IDs, such as in the P-Chain API and
+// the RPC path of a chain.
+func FormatBlockchainID(blockchainID common.Hash) string {
+	return ids.ID(blockchainID).String()
+}
+
+// ParseBlockchainID parses the CB58 string of a blockchain ID into the bytes32 form used by the
+// warp precompile, such as for the sourceChainID of a warp message.
+func ParseBlockchainID(blockchainID string) (common.Hash, error) {
+	id, err := ids.FromString(blockchainID)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid blockchain ID %q: %w", blockchainID, err)
+	}
+	return common.Hash(id), nil
+}
@@ -0,0 +1,36 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatParseBlockchainID(t *testing.T) {
+	require := require.New(t)
+
+	blockchainID := ids.GenerateTestID()
+	formatted := FormatBlockchainID(common.Hash(blockchainID))
+	require.Equal(blockchainID.String(), formatted)
+
+	parsed, err := ParseBlockchainID(formatted)
+	require.NoError(err)
+	require.Equal(common.Hash(blockchainID), parsed)
+
+	// The checksum of a CB58 string rejects a mistyped blockchain ID.
+	mistyped := []byte(formatted)
+	if mistyped[0] == '2' {
+		mistyped[0] = '3'
+	} else {
+		mistyped[0] = '2'
+	}
+	_, err = ParseBlockchainID(string(mistyped))
+	require.Error(err)
+	_, err = ParseBlockchainID(common.Hash(blockchainID).Hex())
+	require.Error(err)
+}
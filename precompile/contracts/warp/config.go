@@ -7,11 +7,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"slices"
 
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/utils"
 	warpValidators "github.com/ava-labs/subnet-evm/warp/validators"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -22,32 +28,182 @@ const (
 	WarpDefaultQuorumNumerator uint64 = 67
 	WarpQuorumNumeratorMinimum uint64 = 33
 	WarpQuorumDenominator      uint64 = 100
+
+	// WarpDefaultMaxMessagesPerPredicate is the maximum number of warp messages a single
+	// transaction may include in its access list when MaxMessagesPerPredicate is not set.
+	WarpDefaultMaxMessagesPerPredicate uint64 = 64
+	// WarpMaxMessagesPerPredicateLimit is the largest value MaxMessagesPerPredicate may be set to.
+	WarpMaxMessagesPerPredicateLimit uint64 = 1024
+	// WarpMaxSignersLimit is the largest value MaxSigners may be set to, which bounds the size of
+	// the validator set of a source subnet.
+	WarpMaxSignersLimit uint64 = 1 << 16
 )
 
 var (
 	_ precompileconfig.Config     = &Config{}
 	_ precompileconfig.Predicater = &Config{}
 	_ precompileconfig.Accepter   = &Config{}
+
+	_ precompileconfig.PredicateLimiter = &Config{}
+)
+
+// Errors returned for a warp message that fails verification or cannot be read during execution,
+// which can be classified with errors.Is.
+var (
+	// ErrWarpParseFailed is wrapped by every error returned for a warp message that cannot be parsed.
+	ErrWarpParseFailed = errors.New("malformed warp message")
+	// ErrWarpWrongChain is wrapped by the error returned for a warp message signed for a different network.
+	ErrWarpWrongChain = errors.New("warp message for wrong network")
+	// ErrWarpSignatureInvalid is wrapped by the error returned for a warp message whose signature
+	// fails verification against the validator set of its source subnet.
+	ErrWarpSignatureInvalid = errors.New("cannot verify warp signature")
 )
 
 var (
 	errOverflowSignersGasCost  = errors.New("overflow calculating warp signers gas cost")
-	errInvalidPredicateBytes   = errors.New("cannot unpack predicate bytes")
-	errInvalidWarpMsg          = errors.New("cannot unpack warp message")
-	errCannotParseWarpMsg      = errors.New("cannot parse warp message")
-	errInvalidWarpMsgPayload   = errors.New("cannot unpack warp message payload")
-	errInvalidAddressedPayload = errors.New("cannot unpack addressed payload")
-	errInvalidBlockHashPayload = errors.New("cannot unpack block hash payload")
+	errInvalidPredicateBytes   = fmt.Errorf("%w: cannot unpack predicate bytes", ErrWarpParseFailed)
+	errInvalidWarpMsg          = fmt.Errorf("%w: cannot unpack warp message", ErrWarpParseFailed)
+	errCannotParseWarpMsg      = fmt.Errorf("%w: cannot parse warp message", ErrWarpParseFailed)
+	errInvalidWarpMsgPayload   = fmt.Errorf("%w: cannot unpack warp message payload", ErrWarpParseFailed)
+	errInvalidAddressedPayload = fmt.Errorf("%w: cannot unpack addressed payload", ErrWarpParseFailed)
+	errInvalidBlockHashPayload = fmt.Errorf("%w: cannot unpack block hash payload", ErrWarpParseFailed)
 	errCannotGetNumSigners     = errors.New("cannot fetch num signers from warp message")
+	errTooManySigners          = errors.New("too many warp message signers")
+	errPredicateTooLarge       = errors.New("warp predicate too large")
 	errWarpCannotBeActivated   = errors.New("warp cannot be activated before Durango")
-	errFailedVerification      = errors.New("cannot verify warp signature")
+	errOriginSenderNotAllowed  = errors.New("warp message origin sender is not allowed")
+
+	errZeroMaxMessagesPerPredicate = errors.New("max messages per predicate cannot be 0")
+	errZeroMaxSigners              = errors.New("max signers cannot be 0")
+	errZeroMaxStorageSlotsBytes    = errors.New("max storage slots bytes cannot be 0")
+	errZeroBaseGasCost             = errors.New("base gas cost cannot be 0")
+	errDuplicateOriginSender       = errors.New("duplicate allowed origin sender")
+	errDuplicateDestinationChain   = errors.New("duplicate destination chain")
+	errNegativeMessageFee          = errors.New("message fee cannot be negative")
+	errMessageFeeTooLarge          = errors.New("message fee must fit in 256 bits")
+	errNoMessageFeeRecipient       = errors.New("must specify fee recipient to charge a message fee")
+	errSenderAllowListManaged      = errors.New("sender allow list cannot have admin or manager addresses")
 )
 
+// GasCosts overrides the gas costs charged by the warp precompile.
+// Any cost left nil falls back to the corresponding package constant.
+type GasCosts struct {
+	// GetBlockchainID overrides GetBlockchainIDGasCost.
+	GetBlockchainID *uint64 `json:"getBlockchainID,omitempty"`
+	// GetVerifiedWarpMessageBase overrides GetVerifiedWarpMessageBaseCost for both
+	// getVerifiedWarpMessage and getVerifiedWarpBlockHash.
+	GetVerifiedWarpMessageBase *uint64 `json:"getVerifiedWarpMessageBase,omitempty"`
+	// SendWarpMessageBase overrides SendWarpMessageGasCost.
+	SendWarpMessageBase *uint64 `json:"sendWarpMessageBase,omitempty"`
+	// SendWarpMessagePerByte overrides SendWarpMessageGasCostPerByte.
+	SendWarpMessagePerByte *uint64 `json:"sendWarpMessagePerByte,omitempty"`
+	// PerWarpSigner overrides GasCostPerWarpSigner.
+	PerWarpSigner *uint64 `json:"perWarpSigner,omitempty"`
+	// PerWarpMessageByte overrides GasCostPerWarpMessageBytes.
+	PerWarpMessageByte *uint64 `json:"perWarpMessageByte,omitempty"`
+	// SignatureVerification overrides GasCostPerSignatureVerification.
+	SignatureVerification *uint64 `json:"signatureVerification,omitempty"`
+}
+
+// Verify returns an error if any base cost is overridden with 0.
+func (g *GasCosts) Verify() error {
+	baseCosts := map[string]*uint64{
+		"getBlockchainID":            g.GetBlockchainID,
+		"getVerifiedWarpMessageBase": g.GetVerifiedWarpMessageBase,
+		"sendWarpMessageBase":        g.SendWarpMessageBase,
+		"signatureVerification":      g.SignatureVerification,
+	}
+	for name, cost := range baseCosts {
+		if cost != nil && *cost == 0 {
+			return fmt.Errorf("%w: %s", errZeroBaseGasCost, name)
+		}
+	}
+	return nil
+}
+
+// Equal returns true if [other] overrides the same gas costs with the same values as [g].
+func (g *GasCosts) Equal(other *GasCosts) bool {
+	if g == nil || other == nil {
+		return g == nil && other == nil
+	}
+	return utils.Uint64PtrEqual(g.GetBlockchainID, other.GetBlockchainID) &&
+		utils.Uint64PtrEqual(g.GetVerifiedWarpMessageBase, other.GetVerifiedWarpMessageBase) &&
+		utils.Uint64PtrEqual(g.SendWarpMessageBase, other.SendWarpMessageBase) &&
+		utils.Uint64PtrEqual(g.SendWarpMessagePerByte, other.SendWarpMessagePerByte) &&
+		utils.Uint64PtrEqual(g.PerWarpSigner, other.PerWarpSigner) &&
+		utils.Uint64PtrEqual(g.PerWarpMessageByte, other.PerWarpMessageByte) &&
+		utils.Uint64PtrEqual(g.SignatureVerification, other.SignatureVerification)
+}
+
+// gasCost returns [override] if it is set or [defaultCost] otherwise.
+func gasCost(override *uint64, defaultCost uint64) uint64 {
+	if override == nil {
+		return defaultCost
+	}
+	return *override
+}
+
 // Config implements the precompileconfig.Config interface and
 // adds specific configuration for Warp.
 type Config struct {
 	precompileconfig.Upgrade
 	QuorumNumerator uint64 `json:"quorumNumerator"`
+	// MaxMessagesPerPredicate is the maximum number of warp messages a single transaction may include.
+	// Transactions with more messages are rejected before verifying any of them. If nil,
+	// WarpDefaultMaxMessagesPerPredicate is used.
+	MaxMessagesPerPredicate *uint64 `json:"maxMessagesPerPredicate,omitempty"`
+	// MaxSigners, if non-nil, is the maximum number of signers of a warp message. Messages with more
+	// signers are rejected before charging gas for or verifying their signature.
+	MaxSigners *uint64 `json:"maxSigners,omitempty"`
+	// MaxStorageSlotsBytes, if non-nil, is the maximum size in bytes of the storage slots encoding a warp
+	// message in the access list of a transaction. Larger predicates are rejected before they are decoded.
+	MaxStorageSlotsBytes *uint64 `json:"maxStorageSlotsBytes,omitempty"`
+	// AllowedOriginSenders, if non-empty, restricts the warp messages accepted by predicate verification
+	// to addressed calls sent by one of these addresses. Any other message fails verification.
+	AllowedOriginSenders []common.Address `json:"allowedOriginSenders,omitempty"`
+
+	// The fields below are recorded in the state of the warp precompile in Configure, which is where its
+	// methods read them from.
+
+	// SenderAllowList, if non-nil, restricts sendWarpMessage to its EnabledAddresses. The warp precompile
+	// has no methods to manage the allow list, so it is only changed by network upgrades and cannot have
+	// admin or manager addresses.
+	SenderAllowList *allowlist.AllowListConfig `json:"senderAllowList,omitempty"`
+	// GasCosts, if non-nil, overrides the gas costs of the warp precompile.
+	GasCosts *GasCosts `json:"gasCosts,omitempty"`
+	// RawMessagesEnabled activates getVerifiedWarpMessageRaw, which additionally returns the bytes
+	// of the unsigned warp message.
+	RawMessagesEnabled bool `json:"rawMessagesEnabled,omitempty"`
+	// MultiDestinationMessagesEnabled activates sendWarpMessageMulti, which sends the same payload to
+	// multiple destination chains.
+	MultiDestinationMessagesEnabled bool `json:"multiDestinationMessagesEnabled,omitempty"`
+	// MaxBatchMessages, if non-zero, activates sendWarpMessages, which sends distinct messages to their own
+	// destinations in one call, and is the maximum number of messages per call.
+	MaxBatchMessages uint64 `json:"maxBatchMessages,omitempty"`
+	// EnforceSequenceOrdering activates getVerifiedSequencedWarpMessage, which only accepts the sequenced
+	// messages of each origin sender in order.
+	EnforceSequenceOrdering bool `json:"enforceSequenceOrdering,omitempty"`
+	// GasEstimatesEnabled activates estimateVerifiedWarpMessageGas, which returns the gas getVerifiedWarpMessage
+	// charges to read a warp message.
+	GasEstimatesEnabled bool `json:"gasEstimatesEnabled,omitempty"`
+	// SentMessagesEnabled makes sendWarpMessage record each message it sends for getSentWarpMessage until the end
+	// of the transaction, and activates sendWarpMessageWithIndex, which also returns the index of the message.
+	SentMessagesEnabled bool `json:"sentMessagesEnabled,omitempty"`
+	// FormatVersionEnabled activates getWarpFormatVersion, which returns the codec version of the warp
+	// messages sent by this chain.
+	FormatVersionEnabled bool `json:"formatVersionEnabled,omitempty"`
+	// WarpPredicatesEnabled activates hasWarpPredicates, which returns whether the current transaction
+	// includes warp messages in its access list.
+	WarpPredicatesEnabled bool `json:"warpPredicatesEnabled,omitempty"`
+	// DestinationChainIDs, if non-empty, makes sendWarpMessageMulti and sendWarpMessages reject destination chains
+	// that are neither this chain nor one of these chains, regardless of the view of the P-Chain of each node. It is
+	// empty by default for chains that message destinations outside of the network.
+	DestinationChainIDs []common.Hash `json:"destinationChainIDs,omitempty"`
+	// MessageFee, if non-zero, is the native-token fee in wei that sendWarpMessage, sendWarpMessageMulti, and
+	// sendWarpMessages deduct from the balance of the caller for each message sent and credit to FeeRecipient.
+	// Sending fails if the caller cannot pay.
+	MessageFee   *big.Int       `json:"messageFee,omitempty"`
+	FeeRecipient common.Address `json:"feeRecipient,omitempty"`
 }
 
 // NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
@@ -76,6 +232,19 @@ func NewDisableConfig(blockTimestamp *uint64) *Config {
 	}
 }
 
+// PrecompileSchedule reports whether a precompile is enabled at a block timestamp according to the
+// upgrade schedule of a chain. It is implemented by *params.ChainConfig, which imports this package's
+// registry and so cannot be imported here.
+type PrecompileSchedule interface {
+	IsPrecompileEnabled(address common.Address, timestamp uint64) bool
+}
+
+// IsWarpEnabledAt returns true if Warp is enabled at [timestamp] by the upgrade schedule of
+// [chainConfig], that is, if the latest Warp upgrade at or before [timestamp] does not disable it.
+func IsWarpEnabledAt(chainConfig PrecompileSchedule, timestamp uint64) bool {
+	return chainConfig.IsPrecompileEnabled(ContractAddress, timestamp)
+}
+
 // Key returns the key for the Warp precompileconfig.
 // This should be the same key as used in the precompile module.
 func (*Config) Key() string { return ConfigKey }
@@ -97,6 +266,65 @@ func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 	if c.QuorumNumerator != 0 && c.QuorumNumerator < WarpQuorumNumeratorMinimum {
 		return fmt.Errorf("cannot specify quorum numerator (%d) < min quorum numerator (%d)", c.QuorumNumerator, WarpQuorumNumeratorMinimum)
 	}
+	if c.SenderAllowList != nil {
+		if err := c.SenderAllowList.Verify(chainConfig, c.Upgrade); err != nil {
+			return fmt.Errorf("invalid sender allow list: %w", err)
+		}
+		if len(c.SenderAllowList.AdminAddresses) > 0 || len(c.SenderAllowList.ManagerAddresses) > 0 {
+			return errSenderAllowListManaged
+		}
+	}
+	if c.GasCosts != nil {
+		if err := c.GasCosts.Verify(); err != nil {
+			return fmt.Errorf("invalid gas costs: %w", err)
+		}
+	}
+	if c.MaxSigners != nil {
+		maxSigners := *c.MaxSigners
+		if maxSigners == 0 {
+			return errZeroMaxSigners
+		}
+		if maxSigners > WarpMaxSignersLimit {
+			return fmt.Errorf("cannot specify max signers (%d) > limit (%d)", maxSigners, WarpMaxSignersLimit)
+		}
+	}
+	if c.MaxStorageSlotsBytes != nil && *c.MaxStorageSlotsBytes == 0 {
+		return errZeroMaxStorageSlotsBytes
+	}
+	allowedOriginSenders := set.NewSet[common.Address](len(c.AllowedOriginSenders))
+	for _, sender := range c.AllowedOriginSenders {
+		if allowedOriginSenders.Contains(sender) {
+			return fmt.Errorf("%w: %s", errDuplicateOriginSender, sender)
+		}
+		allowedOriginSenders.Add(sender)
+	}
+	destinationChainIDs := set.NewSet[common.Hash](len(c.DestinationChainIDs))
+	for _, chainID := range c.DestinationChainIDs {
+		if destinationChainIDs.Contains(chainID) {
+			return fmt.Errorf("%w: %s", errDuplicateDestinationChain, chainID)
+		}
+		destinationChainIDs.Add(chainID)
+	}
+	if c.MessageFee != nil {
+		if c.MessageFee.Sign() < 0 {
+			return errNegativeMessageFee
+		}
+		if c.MessageFee.BitLen() > 256 {
+			return errMessageFeeTooLarge
+		}
+		if c.MessageFee.Sign() > 0 && c.FeeRecipient == (common.Address{}) {
+			return errNoMessageFeeRecipient
+		}
+	}
+	if c.MaxMessagesPerPredicate != nil {
+		maxMessages := *c.MaxMessagesPerPredicate
+		if maxMessages == 0 {
+			return errZeroMaxMessagesPerPredicate
+		}
+		if maxMessages > WarpMaxMessagesPerPredicateLimit {
+			return fmt.Errorf("cannot specify max messages per predicate (%d) > limit (%d)", maxMessages, WarpMaxMessagesPerPredicateLimit)
+		}
+	}
 	return nil
 }
 
@@ -108,7 +336,63 @@ func (c *Config) Equal(s precompileconfig.Config) bool {
 		return false
 	}
 	equals := c.Upgrade.Equal(&other.Upgrade)
-	return equals && c.QuorumNumerator == other.QuorumNumerator
+	if !equals || c.QuorumNumerator != other.QuorumNumerator || c.maxMessagesPerPredicate() != other.maxMessagesPerPredicate() || c.RawMessagesEnabled != other.RawMessagesEnabled {
+		return false
+	}
+	if c.MultiDestinationMessagesEnabled != other.MultiDestinationMessagesEnabled || c.EnforceSequenceOrdering != other.EnforceSequenceOrdering {
+		return false
+	}
+	if c.GasEstimatesEnabled != other.GasEstimatesEnabled || c.SentMessagesEnabled != other.SentMessagesEnabled {
+		return false
+	}
+	if c.FormatVersionEnabled != other.FormatVersionEnabled || c.WarpPredicatesEnabled != other.WarpPredicatesEnabled {
+		return false
+	}
+	if c.MaxBatchMessages != other.MaxBatchMessages {
+		return false
+	}
+	if !utils.Uint64PtrEqual(c.MaxSigners, other.MaxSigners) || !utils.Uint64PtrEqual(c.MaxStorageSlotsBytes, other.MaxStorageSlotsBytes) {
+		return false
+	}
+	if !utils.BigNumEqual(c.messageFee(), other.messageFee()) || c.FeeRecipient != other.FeeRecipient {
+		return false
+	}
+	if !c.GasCosts.Equal(other.GasCosts) {
+		return false
+	}
+	if !set.Of(c.AllowedOriginSenders...).Equals(set.Of(other.AllowedOriginSenders...)) {
+		return false
+	}
+	// The order of the destination chains is compared, since it determines the state written by Configure.
+	if !slices.Equal(c.DestinationChainIDs, other.DestinationChainIDs) {
+		return false
+	}
+	if c.SenderAllowList == nil || other.SenderAllowList == nil {
+		return c.SenderAllowList == nil && other.SenderAllowList == nil
+	}
+	return c.SenderAllowList.Equal(other.SenderAllowList)
+}
+
+// messageFee returns the configured MessageFee, or nil if no fee is charged.
+func (c *Config) messageFee() *big.Int {
+	if c.MessageFee == nil || c.MessageFee.Sign() == 0 {
+		return nil
+	}
+	return c.MessageFee
+}
+
+// maxMessagesPerPredicate returns the configured MaxMessagesPerPredicate or the default if unset.
+func (c *Config) maxMessagesPerPredicate() uint64 {
+	if c.MaxMessagesPerPredicate == nil {
+		return WarpDefaultMaxMessagesPerPredicate
+	}
+	return *c.MaxMessagesPerPredicate
+}
+
+// MaxPredicates returns the maximum number of warp messages that may be included in a single transaction.
+// Transactions exceeding this limit are rejected before any warp signature is verified.
+func (c *Config) MaxPredicates() (int, bool) {
+	return int(c.maxMessagesPerPredicate()), true
 }
 
 func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash common.Hash, blockNumber uint64, txHash common.Hash, logIndex int, topics []common.Hash, logData []byte) error {
@@ -128,6 +412,9 @@ func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash com
 	if err := acceptCtx.Warp.AddMessage(unsignedMessage); err != nil {
 		return fmt.Errorf("failed to add warp message during accept (TxHash: %s, LogIndex: %d): %w", txHash, logIndex, err)
 	}
+	if err := acceptCtx.Warp.IndexMessage(blockNumber, uint32(logIndex), unsignedMessage.ID()); err != nil {
+		return fmt.Errorf("failed to index warp message during accept (TxHash: %s, LogIndex: %d): %w", txHash, logIndex, err)
+	}
 	return nil
 }
 
@@ -140,8 +427,17 @@ func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash com
 //
 // If the payload of the warp message fails parsing, return a non-nil error invalidating the transaction.
 func (c *Config) PredicateGas(predicateBytes []byte) (uint64, error) {
-	totalGas := GasCostPerSignatureVerification
-	bytesGasCost, overflow := math.SafeMul(GasCostPerWarpMessageBytes, uint64(len(predicateBytes)))
+	// Reject oversized predicates before decoding them, so that they cannot cause large allocations.
+	if c.MaxStorageSlotsBytes != nil && uint64(len(predicateBytes)) > *c.MaxStorageSlotsBytes {
+		return 0, fmt.Errorf("%w: %d bytes > max storage slots bytes (%d)", errPredicateTooLarge, len(predicateBytes), *c.MaxStorageSlotsBytes)
+	}
+	gasCosts := c.GasCosts
+	if gasCosts == nil {
+		gasCosts = &GasCosts{}
+	}
+	totalGas := gasCost(gasCosts.SignatureVerification, GasCostPerSignatureVerification)
+	perByteGas := gasCost(gasCosts.PerWarpMessageByte, GasCostPerWarpMessageBytes)
+	bytesGasCost, overflow := math.SafeMul(perByteGas, uint64(len(predicateBytes)))
 	if overflow {
 		return 0, fmt.Errorf("overflow calculating gas cost for warp message bytes of size %d", len(predicateBytes))
 	}
@@ -167,13 +463,17 @@ func (c *Config) PredicateGas(predicateBytes []byte) (uint64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("%w: %s", errCannotGetNumSigners, err)
 	}
-	signerGas, overflow := math.SafeMul(uint64(numSigners), GasCostPerWarpSigner)
+	if c.MaxSigners != nil && uint64(numSigners) > *c.MaxSigners {
+		return 0, fmt.Errorf("%w: %d > max signers (%d)", errTooManySigners, numSigners, *c.MaxSigners)
+	}
+	perSignerGas := gasCost(gasCosts.PerWarpSigner, GasCostPerWarpSigner)
+	signerGas, overflow := math.SafeMul(uint64(numSigners), perSignerGas)
 	if overflow {
-		return 0, errOverflowSignersGasCost
+		return 0, fmt.Errorf("%w: %d signers * %d gas", errOverflowSignersGasCost, numSigners, perSignerGas)
 	}
 	totalGas, overflow = math.SafeAdd(totalGas, signerGas)
 	if overflow {
-		return 0, fmt.Errorf("overflow adding signer gas (PrevTotal: %d, VerificationGas: %d)", totalGas, signerGas)
+		return 0, fmt.Errorf("%w: adding signer gas (PrevTotal: %d, VerificationGas: %d)", errOverflowSignersGasCost, totalGas, signerGas)
 	}
 
 	return totalGas, nil
@@ -191,27 +491,68 @@ func (c *Config) VerifyPredicate(predicateContext *precompileconfig.PredicateCon
 	if err != nil {
 		return fmt.Errorf("%w: %w", errCannotParseWarpMsg, err)
 	}
-
-	quorumNumerator := WarpDefaultQuorumNumerator
-	if c.QuorumNumerator != 0 {
-		quorumNumerator = c.QuorumNumerator
+	// Check the origin sender before the signature, since it is much cheaper to verify.
+	if err := c.verifyOriginSender(warpMsg); err != nil {
+		return err
 	}
 
-	log.Debug("verifying warp message", "warpMsg", warpMsg, "quorumNum", quorumNumerator, "quorumDenom", WarpQuorumDenominator)
-	err = warpMsg.Signature.Verify(
+	return VerifyWarpMessage(
 		context.Background(),
-		&warpMsg.UnsignedMessage,
+		warpMsg,
 		predicateContext.SnowCtx.NetworkID,
 		warpValidators.NewState(predicateContext.SnowCtx), // Wrap validators.State on the chain snow context to special case the Primary Network
 		predicateContext.ProposerVMBlockCtx.PChainHeight,
+		c.QuorumNumerator,
+	)
+}
+
+// VerifyWarpMessage verifies that [warpMsg] was sent on [networkID] and that its signers hold at least
+// [quorumNumerator]/WarpQuorumDenominator of the weight of the validator set of its source subnet in
+// [validatorState] at [pChainHeight]. A [quorumNumerator] of 0 denotes WarpDefaultQuorumNumerator.
+//
+// This is the signature verification performed by VerifyPredicate, so that tools can verify a signed
+// warp message off-chain exactly as the warp precompile would. Errors wrap ErrWarpWrongChain or
+// ErrWarpSignatureInvalid.
+func VerifyWarpMessage(ctx context.Context, warpMsg *warp.Message, networkID uint32, validatorState validators.State, pChainHeight uint64, quorumNumerator uint64) error {
+	if quorumNumerator == 0 {
+		quorumNumerator = WarpDefaultQuorumNumerator
+	}
+
+	log.Debug("verifying warp message", "warpMsg", warpMsg, "pChainHeight", pChainHeight, "quorumNum", quorumNumerator, "quorumDenom", WarpQuorumDenominator)
+	err := warpMsg.Signature.Verify(
+		ctx,
+		&warpMsg.UnsignedMessage,
+		networkID,
+		validatorState,
+		pChainHeight,
 		quorumNumerator,
 		WarpQuorumDenominator,
 	)
-
 	if err != nil {
-		log.Debug("failed to verify warp signature", "msgID", warpMsg.ID(), "err", err)
-		return fmt.Errorf("%w: %w", errFailedVerification, err)
+		log.Debug("failed to verify warp signature", "msgID", warpMsg.ID(), "pChainHeight", pChainHeight, "err", err)
+		if errors.Is(err, warp.ErrWrongNetworkID) {
+			return fmt.Errorf("%w: %w", ErrWarpWrongChain, err)
+		}
+		return fmt.Errorf("%w at P-Chain height %d: %w", ErrWarpSignatureInvalid, pChainHeight, err)
 	}
-
 	return nil
 }
+
+// verifyOriginSender returns an error if AllowedOriginSenders is non-empty and [warpMsg] is not an
+// addressed call sent by one of the allowed origin senders.
+func (c *Config) verifyOriginSender(warpMsg *warp.Message) error {
+	if len(c.AllowedOriginSenders) == 0 {
+		return nil
+	}
+	addressedCall, err := payload.ParseAddressedCall(warpMsg.Payload)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errOriginSenderNotAllowed, err)
+	}
+	originSender := common.BytesToAddress(addressedCall.SourceAddress)
+	for _, allowed := range c.AllowedOriginSenders {
+		if originSender == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", errOriginSenderNotAllowed, originSender)
+}
@@ -5,11 +5,16 @@ package warp
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/precompile/testutils"
 	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -32,6 +37,174 @@ func TestVerify(t *testing.T) {
 		"valid quorum numerator 1 more than minimum": {
 			Config: NewConfig(utils.NewUint64(3), WarpQuorumNumeratorMinimum+1),
 		},
+		"zero max messages per predicate": {
+			Config: &Config{
+				Upgrade:                 precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxMessagesPerPredicate: utils.NewUint64(0),
+			},
+			ExpectedError: errZeroMaxMessagesPerPredicate.Error(),
+		},
+		"max messages per predicate greater than limit": {
+			Config: &Config{
+				Upgrade:                 precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxMessagesPerPredicate: utils.NewUint64(WarpMaxMessagesPerPredicateLimit + 1),
+			},
+			ExpectedError: fmt.Sprintf("cannot specify max messages per predicate (%d) > limit (%d)", WarpMaxMessagesPerPredicateLimit+1, WarpMaxMessagesPerPredicateLimit),
+		},
+		"valid max messages per predicate": {
+			Config: &Config{
+				Upgrade:                 precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxMessagesPerPredicate: utils.NewUint64(WarpMaxMessagesPerPredicateLimit),
+			},
+		},
+		"negative message fee": {
+			Config: &Config{
+				Upgrade:      precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MessageFee:   big.NewInt(-1),
+				FeeRecipient: common.Address{1},
+			},
+			ExpectedError: errNegativeMessageFee.Error(),
+		},
+		"message fee larger than 256 bits": {
+			Config: &Config{
+				Upgrade:      precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MessageFee:   new(big.Int).Lsh(common.Big1, 256),
+				FeeRecipient: common.Address{1},
+			},
+			ExpectedError: errMessageFeeTooLarge.Error(),
+		},
+		"message fee without recipient": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MessageFee: big.NewInt(1),
+			},
+			ExpectedError: errNoMessageFeeRecipient.Error(),
+		},
+		"zero message fee without recipient": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MessageFee: big.NewInt(0),
+			},
+		},
+		"valid message fee": {
+			Config: &Config{
+				Upgrade:      precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MessageFee:   big.NewInt(1),
+				FeeRecipient: common.Address{1},
+			},
+		},
+		"zero max signers": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxSigners: utils.NewUint64(0),
+			},
+			ExpectedError: errZeroMaxSigners.Error(),
+		},
+		"max signers greater than limit": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxSigners: utils.NewUint64(WarpMaxSignersLimit + 1),
+			},
+			ExpectedError: fmt.Sprintf("cannot specify max signers (%d) > limit (%d)", WarpMaxSignersLimit+1, WarpMaxSignersLimit),
+		},
+		"valid max signers": {
+			Config: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxSigners: utils.NewUint64(WarpMaxSignersLimit),
+			},
+		},
+		"zero max storage slots bytes": {
+			Config: &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxStorageSlotsBytes: utils.NewUint64(0),
+			},
+			ExpectedError: errZeroMaxStorageSlotsBytes.Error(),
+		},
+		"valid max storage slots bytes": {
+			Config: &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxStorageSlotsBytes: utils.NewUint64(1024),
+			},
+		},
+		"invalid sender allow list": {
+			Config: &Config{
+				Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				SenderAllowList: &allowlist.AllowListConfig{
+					AdminAddresses:   []common.Address{{1}},
+					EnabledAddresses: []common.Address{{1}},
+				},
+			},
+			ExpectedError: "invalid sender allow list: cannot set address as both admin and enabled",
+		},
+		"sender allow list with admin": {
+			Config: &Config{
+				Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				SenderAllowList: &allowlist.AllowListConfig{
+					AdminAddresses:   []common.Address{{1}},
+					EnabledAddresses: []common.Address{{2}},
+				},
+			},
+			ExpectedError: errSenderAllowListManaged.Error(),
+		},
+		"sender allow list with manager": {
+			Config: &Config{
+				Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				SenderAllowList: &allowlist.AllowListConfig{
+					ManagerAddresses: []common.Address{{1}},
+				},
+			},
+			ExpectedError: errSenderAllowListManaged.Error(),
+		},
+		"valid sender allow list": {
+			Config: &Config{
+				Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				SenderAllowList: &allowlist.AllowListConfig{
+					EnabledAddresses: []common.Address{{1}, {2}},
+				},
+			},
+		},
+		"zero base gas cost": {
+			Config: &Config{
+				Upgrade:  precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				GasCosts: &GasCosts{SendWarpMessageBase: utils.NewUint64(0)},
+			},
+			ExpectedError: "invalid gas costs: base gas cost cannot be 0: sendWarpMessageBase",
+		},
+		"valid gas costs": {
+			Config: &Config{
+				Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				GasCosts: &GasCosts{
+					SendWarpMessageBase:    utils.NewUint64(SendWarpMessageGasCost * 2),
+					SendWarpMessagePerByte: utils.NewUint64(0),
+				},
+			},
+		},
+		"duplicate allowed origin sender": {
+			Config: &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				AllowedOriginSenders: []common.Address{{1}, {2}, {1}},
+			},
+			ExpectedError: fmt.Sprintf("%s: %s", errDuplicateOriginSender, common.Address{1}),
+		},
+		"valid allowed origin senders": {
+			Config: &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				AllowedOriginSenders: []common.Address{{1}, {2}},
+			},
+		},
+		"duplicate destination chain": {
+			Config: &Config{
+				Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				DestinationChainIDs: []common.Hash{{1}, {2}, {1}},
+			},
+			ExpectedError: fmt.Sprintf("%s: %s", errDuplicateDestinationChain, common.Hash{1}),
+		},
+		"valid destination chains": {
+			Config: &Config{
+				Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				DestinationChainIDs: []common.Hash{{1}, {2}},
+			},
+		},
 		"invalid cannot activated before Durango activation": {
 			Config: NewConfig(utils.NewUint64(3), 0),
 			ChainConfig: func() precompileconfig.ChainConfig {
@@ -71,6 +244,248 @@ func TestEqualWarpConfig(t *testing.T) {
 			Expected: false,
 		},
 
+		"different max messages per predicate": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:                 precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxMessagesPerPredicate: utils.NewUint64(WarpDefaultMaxMessagesPerPredicate + 1),
+			},
+			Expected: false,
+		},
+
+		"default max messages per predicate": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:                 precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxMessagesPerPredicate: utils.NewUint64(WarpDefaultMaxMessagesPerPredicate),
+			},
+			Expected: true,
+		},
+
+		"different max signers": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxSigners: utils.NewUint64(100),
+			},
+			Expected: false,
+		},
+
+		"different max storage slots bytes": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxStorageSlotsBytes: utils.NewUint64(1024),
+			},
+			Expected: false,
+		},
+
+		"different sender allow list": {
+			Config: &Config{
+				Upgrade:         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				SenderAllowList: &allowlist.AllowListConfig{EnabledAddresses: []common.Address{{1}}},
+			},
+			Other: &Config{
+				Upgrade:         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				SenderAllowList: &allowlist.AllowListConfig{EnabledAddresses: []common.Address{{2}}},
+			},
+			Expected: false,
+		},
+
+		"sender allow list and nil sender allow list": {
+			Config: &Config{
+				Upgrade:         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				SenderAllowList: &allowlist.AllowListConfig{},
+			},
+			Other:    NewDefaultConfig(utils.NewUint64(3)),
+			Expected: false,
+		},
+
+		"different raw messages enabled": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:            precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				RawMessagesEnabled: true,
+			},
+			Expected: false,
+		},
+
+		"different multi destination messages enabled": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:                         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MultiDestinationMessagesEnabled: true,
+			},
+			Expected: false,
+		},
+
+		"different max batch messages": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:          precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MaxBatchMessages: 10,
+			},
+			Expected: false,
+		},
+
+		"different enforce sequence ordering": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:                 precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				EnforceSequenceOrdering: true,
+			},
+			Expected: false,
+		},
+
+		"different gas estimates enabled": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				GasEstimatesEnabled: true,
+			},
+			Expected: false,
+		},
+
+		"different format version enabled": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				FormatVersionEnabled: true,
+			},
+			Expected: false,
+		},
+
+		"different warp predicates enabled": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:               precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				WarpPredicatesEnabled: true,
+			},
+			Expected: false,
+		},
+		"different destination chains": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				DestinationChainIDs: []common.Hash{{1}},
+			},
+			Expected: false,
+		},
+		"same destination chains in different order": {
+			Config: &Config{
+				Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				DestinationChainIDs: []common.Hash{{1}, {2}},
+			},
+			Other: &Config{
+				Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				DestinationChainIDs: []common.Hash{{2}, {1}},
+			},
+			Expected: false,
+		},
+
+		"different sent messages enabled": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				SentMessagesEnabled: true,
+			},
+			Expected: false,
+		},
+
+		"different message fee": {
+			Config: &Config{
+				Upgrade:      precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MessageFee:   big.NewInt(1),
+				FeeRecipient: common.Address{1},
+			},
+			Other: &Config{
+				Upgrade:      precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MessageFee:   big.NewInt(2),
+				FeeRecipient: common.Address{1},
+			},
+			Expected: false,
+		},
+
+		"different fee recipient": {
+			Config: &Config{
+				Upgrade:      precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MessageFee:   big.NewInt(1),
+				FeeRecipient: common.Address{1},
+			},
+			Other: &Config{
+				Upgrade:      precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MessageFee:   big.NewInt(1),
+				FeeRecipient: common.Address{2},
+			},
+			Expected: false,
+		},
+
+		"zero and unset message fee": {
+			Config: NewDefaultConfig(utils.NewUint64(3)),
+			Other: &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				MessageFee: big.NewInt(0),
+			},
+			Expected: true,
+		},
+
+		"different allowed origin senders": {
+			Config: &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				AllowedOriginSenders: []common.Address{{1}},
+			},
+			Other: &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				AllowedOriginSenders: []common.Address{{1}, {2}},
+			},
+			Expected: false,
+		},
+
+		"same allowed origin senders in different order": {
+			Config: &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				AllowedOriginSenders: []common.Address{{1}, {2}},
+			},
+			Other: &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				AllowedOriginSenders: []common.Address{{2}, {1}},
+			},
+			Expected: true,
+		},
+
+		"different gas costs": {
+			Config: &Config{
+				Upgrade:  precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				GasCosts: &GasCosts{PerWarpSigner: utils.NewUint64(GasCostPerWarpSigner)},
+			},
+			Other: &Config{
+				Upgrade:  precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				GasCosts: &GasCosts{PerWarpSigner: utils.NewUint64(GasCostPerWarpSigner + 1)},
+			},
+			Expected: false,
+		},
+
+		"gas costs and nil gas costs": {
+			Config: &Config{
+				Upgrade:  precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				GasCosts: &GasCosts{},
+			},
+			Other:    NewDefaultConfig(utils.NewUint64(3)),
+			Expected: false,
+		},
+
+		"same gas costs": {
+			Config: &Config{
+				Upgrade:  precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				GasCosts: &GasCosts{PerWarpSigner: utils.NewUint64(GasCostPerWarpSigner)},
+			},
+			Other: &Config{
+				Upgrade:  precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(3)},
+				GasCosts: &GasCosts{PerWarpSigner: utils.NewUint64(GasCostPerWarpSigner)},
+			},
+			Expected: true,
+		},
+
 		"same default config": {
 			Config:   NewDefaultConfig(utils.NewUint64(3)),
 			Other:    NewDefaultConfig(utils.NewUint64(3)),
@@ -85,3 +500,45 @@ func TestEqualWarpConfig(t *testing.T) {
 	}
 	testutils.RunEqualTests(t, tests)
 }
+
+func TestIsWarpEnabledAt(t *testing.T) {
+	baseConfig := *params.TestChainConfig
+	chainConfig := &baseConfig
+	chainConfig.GenesisPrecompiles = params.Precompiles{}
+	chainConfig.PrecompileUpgrades = []params.PrecompileUpgrade{
+		{Config: NewDefaultConfig(utils.NewUint64(2))},
+		{Config: NewDisableConfig(utils.NewUint64(4))},
+		{Config: NewDefaultConfig(utils.NewUint64(6))},
+	}
+	tests := []struct {
+		timestamp uint64
+		enabled   bool
+	}{
+		{timestamp: 0, enabled: false},
+		{timestamp: 1, enabled: false},
+		{timestamp: 2, enabled: true},
+		{timestamp: 3, enabled: true},
+		{timestamp: 4, enabled: false},
+		{timestamp: 5, enabled: false},
+		{timestamp: 6, enabled: true},
+		{timestamp: 100, enabled: true},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.enabled, IsWarpEnabledAt(chainConfig, test.timestamp), "timestamp %d", test.timestamp)
+	}
+}
+
+func TestMaxPredicates(t *testing.T) {
+	require := require.New(t)
+
+	// Without MaxMessagesPerPredicate, the default limit applies.
+	maxPredicates, limited := NewDefaultConfig(utils.NewUint64(0)).MaxPredicates()
+	require.True(limited)
+	require.Equal(int(WarpDefaultMaxMessagesPerPredicate), maxPredicates)
+
+	config := NewDefaultConfig(utils.NewUint64(0))
+	config.MaxMessagesPerPredicate = utils.NewUint64(3)
+	maxPredicates, limited = config.MaxPredicates()
+	require.True(limited)
+	require.Equal(3, maxPredicates)
+}
@@ -4,12 +4,19 @@
 package warp
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"math/big"
+	"slices"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 
@@ -17,11 +24,14 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
 	GetVerifiedWarpMessageBaseCost uint64 = 2      // Base cost of entering getVerifiedWarpMessage
 	GetBlockchainIDGasCost         uint64 = 2      // Based on GasQuickStep used in existing EVM instructions
+	GetWarpFormatVersionGasCost    uint64 = 2      // Based on GasQuickStep used in existing EVM instructions
+	HasWarpPredicatesGasCost       uint64 = 2      // Based on GasQuickStep used in existing EVM instructions
 	AddWarpMessageGasCost          uint64 = 20_000 // Cost of producing and serving a BLS Signature
 	// Sum of base log gas cost, cost of producing 4 topics, and producing + serving a BLS Signature (sign + trie write)
 	// Note: using trie write for the gas cost results in a conservative overestimate since the message is stored in a
@@ -34,13 +44,387 @@ const (
 	GasCostPerWarpSigner            uint64 = 500
 	GasCostPerWarpMessageBytes      uint64 = 100
 	GasCostPerSignatureVerification uint64 = 200_000
+
+	// GetVerifiedWarpMessageRawGasCostPerByte is charged in addition to the cost of getVerifiedWarpMessage
+	// for each byte of the unsigned message returned by getVerifiedWarpMessageRaw.
+	// Based on GasFastestStep charged per word to copy memory, conservatively charged per byte.
+	GetVerifiedWarpMessageRawGasCostPerByte uint64 = 3
+
+	// GetVerifiedSequencedWarpMessageGasCost is charged in addition to the cost of getVerifiedWarpMessage
+	// by getVerifiedSequencedWarpMessage to read and update the last consumed sequence of the message.
+	GetVerifiedSequencedWarpMessageGasCost uint64 = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot
+
+	// EstimateVerifiedWarpMessageGasCost is charged by estimateVerifiedWarpMessageGas to look up the size
+	// of a predicate already loaded in memory. Based on the warm storage read cost of EIP-2929.
+	EstimateVerifiedWarpMessageGasCost uint64 = 100
+
+	// SentWarpMessageGasCostPerSlot is charged for each slot of transient storage written by sendWarpMessage
+	// to record a sent message once sent messages are enabled, or read by getSentWarpMessage.
+	// Based on the cost of TSTORE and TLOAD of EIP-1153.
+	SentWarpMessageGasCostPerSlot uint64 = 100
+	// CheckDestinationChainGasCost is charged by sendWarpMessageMulti and sendWarpMessages for each
+	// destination chain once destination chains are configured, to read whether it is allowed.
+	CheckDestinationChainGasCost uint64 = contract.ReadGasCostPerSlot
+
+	// GetSentWarpMessageBaseGasCost is charged by getSentWarpMessage to read the number of sent messages
+	// and the message ID, sender, and payload length of the message. The words of the payload are
+	// charged SentWarpMessageGasCostPerSlot each.
+	GetSentWarpMessageBaseGasCost uint64 = 4 * SentWarpMessageGasCostPerSlot
+)
+
+var (
+	errInvalidSendInput      = errors.New("invalid sendWarpMessage input")
+	errInvalidSendMultiInput = errors.New("invalid sendWarpMessageMulti input")
+	errNoDestinations        = errors.New("sendWarpMessageMulti requires at least one destination chain")
+	errInvalidSendBatchInput = errors.New("invalid sendWarpMessages input")
+	errNoMessages            = errors.New("sendWarpMessages requires at least one message")
+	errTooManyMessages       = errors.New("too many messages in sendWarpMessages")
+	errUnknownDestination    = errors.New("unknown destination chain")
+	errInvalidIndexInput     = errors.New("invalid index to specify warp message")
+	errMalformedIndexInput   = fmt.Errorf("%w: malformed input", errInvalidIndexInput)
+	errIndexOutOfRange       = fmt.Errorf("%w: index out of range", errInvalidIndexInput)
+
+	ErrCannotSendWarpMessage = errors.New("non-enabled cannot call sendWarpMessage")
+	// ErrInsufficientMessageFeeBalance is returned when the caller of sendWarpMessage or
+	// sendWarpMessageMulti cannot pay the configured message fee.
+	ErrInsufficientMessageFeeBalance = errors.New("insufficient balance to pay warp message fee")
+	// ErrInvalidSendWarpMessageLog is returned by DecodeSendWarpMessageLog for a log that is not a
+	// SendWarpMessage log emitted by the warp precompile.
+	ErrInvalidSendWarpMessageLog = errors.New("invalid SendWarpMessage log")
+)
+
+// configKey returns the storage slot of the warp precompile recording the config value [name]. Since it
+// is a hash of a namespaced string, it cannot collide with an allow list role slot, which is keyed by a
+// left-padded address, or with the other slots of the precompile.
+func configKey(name string) common.Hash {
+	return crypto.Keccak256Hash([]byte("warp.config." + name))
+}
+
+// stateFlag is a boolean config value of the warp precompile recorded in its storage, which enables
+// a method of the precompile or a behavior of sending messages.
+type stateFlag struct {
+	key common.Hash
+}
+
+// Flags of the warp precompile, set by Configure from the corresponding fields of the Config.
+var (
+	// senderAllowListFlag restricts sendWarpMessage by the sender allow list.
+	senderAllowListFlag = stateFlag{key: configKey("senderAllowListEnabled")}
+	// rawMessagesFlag enables getVerifiedWarpMessageRaw.
+	rawMessagesFlag = stateFlag{key: configKey("rawMessagesEnabled")}
+	// multiDestinationMessagesFlag enables sendWarpMessageMulti.
+	multiDestinationMessagesFlag = stateFlag{key: configKey("multiDestinationMessagesEnabled")}
+	// sequenceOrderingFlag enables getVerifiedSequencedWarpMessage.
+	sequenceOrderingFlag = stateFlag{key: configKey("enforceSequenceOrdering")}
+	// gasEstimatesFlag enables estimateVerifiedWarpMessageGas.
+	gasEstimatesFlag = stateFlag{key: configKey("gasEstimatesEnabled")}
+	// formatVersionFlag enables getWarpFormatVersion.
+	formatVersionFlag = stateFlag{key: configKey("formatVersionEnabled")}
+	// warpPredicatesFlag enables hasWarpPredicates.
+	warpPredicatesFlag = stateFlag{key: configKey("warpPredicatesEnabled")}
+	// sentMessagesFlag records the messages sent by sendWarpMessage for getSentWarpMessage and enables
+	// getSentWarpMessage and sendWarpMessageWithIndex.
+	sentMessagesFlag = stateFlag{key: configKey("sentMessagesEnabled")}
 )
 
+// set records in [stateDB] whether the flag is enabled.
+func (f stateFlag) set(stateDB contract.StateDB, enabled bool) {
+	var value common.Hash
+	if enabled {
+		value = common.Hash{31: 1}
+	}
+	stateDB.SetState(ContractAddress, f.key, value)
+}
+
+// configure records in [stateDB] whether the flag is enabled, avoiding touching the state unless
+// the flag is or was enabled.
+func (f stateFlag) configure(stateDB contract.StateDB, enabled bool) {
+	if enabled || f.enabled(stateDB) {
+		f.set(stateDB, enabled)
+	}
+}
+
+// enabled returns true if the flag is enabled in [stateDB].
+func (f stateFlag) enabled(stateDB contract.StateDB) bool {
+	return stateDB.GetState(ContractAddress, f.key) != (common.Hash{})
+}
+
+// activated is the contract.ActivationFunc of the methods enabled by the flag.
+func (f stateFlag) activated(accessibleState contract.AccessibleState) bool {
+	return f.enabled(accessibleState.GetStateDB())
+}
+
+// maxBatchMessagesKey is the storage slot of the warp precompile recording the maximum number of
+// messages sent by a call of sendWarpMessages, which is enabled if it is non-zero.
+var maxBatchMessagesKey = configKey("maxBatchMessages")
+
+// setMaxBatchMessages records in [stateDB] the maximum number of messages sent by a call of sendWarpMessages.
+func setMaxBatchMessages(stateDB contract.StateDB, maxMessages uint64) {
+	stateDB.SetState(ContractAddress, maxBatchMessagesKey, common.BigToHash(new(big.Int).SetUint64(maxMessages)))
+}
+
+// getMaxBatchMessages returns the maximum number of messages sent by a call of sendWarpMessages, or 0
+// if sendWarpMessages may not be called.
+func getMaxBatchMessages(stateDB contract.StateDB) uint64 {
+	return stateDB.GetState(ContractAddress, maxBatchMessagesKey).Big().Uint64()
+}
+
+// isBatchMessagesActivated is the contract.ActivationFunc of sendWarpMessages.
+func isBatchMessagesActivated(accessibleState contract.AccessibleState) bool {
+	return getMaxBatchMessages(accessibleState.GetStateDB()) > 0
+}
+
+// destinationChainsCountKey is the storage slot of the warp precompile recording the number of destination
+// chains that sendWarpMessageMulti and sendWarpMessages may send to besides this chain. Destination chains
+// are only checked if it is non-zero.
+var destinationChainsCountKey = configKey("destinationChainsCount")
+
+// destinationChainKey returns the storage slot of the warp precompile recording the destination chain at
+// [index], so that the destination chains of a previous upgrade can be cleared.
+func destinationChainKey(index uint64) common.Hash {
+	var indexBytes [wrappers.LongLen]byte
+	binary.BigEndian.PutUint64(indexBytes[:], index)
+	return crypto.Keccak256Hash(configKey("destinationChain").Bytes(), indexBytes[:])
+}
+
+// allowedDestinationChainKey returns the storage slot of the warp precompile recording whether
+// [chainID] is a destination chain.
+func allowedDestinationChainKey(chainID common.Hash) common.Hash {
+	return crypto.Keccak256Hash(configKey("allowedDestinationChain").Bytes(), chainID[:])
+}
+
+// getDestinationChainsCount returns the number of destination chains recorded in [stateDB].
+func getDestinationChainsCount(stateDB contract.StateDB) uint64 {
+	value := stateDB.GetState(ContractAddress, destinationChainsCountKey)
+	return binary.BigEndian.Uint64(value[common.HashLength-wrappers.LongLen:])
+}
+
+// storeDestinationChains records [chainIDs] in [stateDB] as the destination chains that messages may be
+// sent to besides this chain, replacing the destination chains of any previous upgrade. An empty
+// [chainIDs] disables the check of destination chains.
+func storeDestinationChains(stateDB contract.StateDB, chainIDs []common.Hash) {
+	// Avoid touching the state unless a previous upgrade recorded destination chains.
+	prevCount := getDestinationChainsCount(stateDB)
+	for i := uint64(0); i < prevCount; i++ {
+		chainID := stateDB.GetState(ContractAddress, destinationChainKey(i))
+		stateDB.SetState(ContractAddress, allowedDestinationChainKey(chainID), common.Hash{})
+		stateDB.SetState(ContractAddress, destinationChainKey(i), common.Hash{})
+	}
+	for i, chainID := range chainIDs {
+		stateDB.SetState(ContractAddress, destinationChainKey(uint64(i)), chainID)
+		stateDB.SetState(ContractAddress, allowedDestinationChainKey(chainID), common.Hash{31: 1})
+	}
+	if prevCount > 0 || len(chainIDs) > 0 {
+		var count common.Hash
+		binary.BigEndian.PutUint64(count[common.HashLength-wrappers.LongLen:], uint64(len(chainIDs)))
+		stateDB.SetState(ContractAddress, destinationChainsCountKey, count)
+	}
+}
+
+// checkDestinationChains charges CheckDestinationChainGasCost for each of [chainIDs] and returns
+// errUnknownDestination for the first of them that is neither this chain nor a destination chain
+// recorded in the state, unless no destination chains are recorded. It only reads the state, so that
+// every node computes the same result for the same block.
+func checkDestinationChains(accessibleState contract.AccessibleState, chainIDs []common.Hash, suppliedGas uint64) (remainingGas uint64, err error) {
+	stateDB := accessibleState.GetStateDB()
+	if getDestinationChainsCount(stateDB) == 0 {
+		return suppliedGas, nil
+	}
+	checkGas, overflow := math.SafeMul(CheckDestinationChainGasCost, uint64(len(chainIDs)))
+	if overflow {
+		return 0, vmerrs.ErrOutOfGas
+	}
+	if remainingGas, err = contract.DeductGas(suppliedGas, checkGas); err != nil {
+		return 0, err
+	}
+	chainID := common.Hash(accessibleState.GetSnowContext().ChainID)
+	for _, destinationChainID := range chainIDs {
+		if destinationChainID == chainID {
+			continue
+		}
+		if stateDB.GetState(ContractAddress, allowedDestinationChainKey(destinationChainID)) == (common.Hash{}) {
+			return remainingGas, fmt.Errorf("%w: %s", errUnknownDestination, ids.ID(destinationChainID))
+		}
+	}
+	return remainingGas, nil
+}
+
+// messageFeeKey and messageFeeRecipientKey are the storage slots of the warp precompile recording the
+// native-token fee charged for each message sent and the address it is credited to.
 var (
-	errInvalidSendInput  = errors.New("invalid sendWarpMessage input")
-	errInvalidIndexInput = errors.New("invalid index to specify warp message")
+	messageFeeKey          = configKey("messageFee")
+	messageFeeRecipientKey = configKey("messageFeeRecipient")
 )
 
+// storeMessageFee records in [stateDB] the fee charged for each message sent and its [recipient], or
+// clears them if [fee] is nil. Returns an error if [fee] is charged without a recipient.
+func storeMessageFee(stateDB contract.StateDB, fee *big.Int, recipient common.Address) error {
+	if fee == nil {
+		// Avoid touching the state unless a previous upgrade set a message fee.
+		if stateDB.GetState(ContractAddress, messageFeeKey) != (common.Hash{}) {
+			stateDB.SetState(ContractAddress, messageFeeKey, common.Hash{})
+			stateDB.SetState(ContractAddress, messageFeeRecipientKey, common.Hash{})
+		}
+		return nil
+	}
+	if recipient == (common.Address{}) {
+		return errNoMessageFeeRecipient
+	}
+	stateDB.SetState(ContractAddress, messageFeeKey, common.BigToHash(fee))
+	stateDB.SetState(ContractAddress, messageFeeRecipientKey, common.BytesToHash(recipient.Bytes()))
+	return nil
+}
+
+// chargeMessageFee transfers the message fee recorded in [stateDB] for each of [numMessages] messages
+// from [caller] to the fee recipient. Returns ErrInsufficientMessageFeeBalance without transferring
+// anything if [caller] cannot pay the total fee.
+func chargeMessageFee(stateDB contract.StateDB, caller common.Address, numMessages int) error {
+	feeValue := stateDB.GetState(ContractAddress, messageFeeKey)
+	if feeValue == (common.Hash{}) {
+		return nil
+	}
+	fee := new(big.Int).Mul(feeValue.Big(), big.NewInt(int64(numMessages)))
+	if balance := stateDB.GetBalance(caller); balance.Cmp(fee) < 0 {
+		return fmt.Errorf("%w: %s has %s, fee is %s", ErrInsufficientMessageFeeBalance, caller, balance, fee)
+	}
+	recipient := common.BytesToAddress(stateDB.GetState(ContractAddress, messageFeeRecipientKey).Bytes())
+	stateDB.SubBalance(caller, fee)
+	stateDB.AddBalance(recipient, fee)
+	return nil
+}
+
+// sentWarpMessagesCountKey is the transient storage slot of the warp precompile recording the number
+// of messages sent by sendWarpMessage in the current transaction.
+var sentWarpMessagesCountKey = crypto.Keccak256Hash([]byte("sentWarpMessagesCount"))
+
+// Offsets of the transient storage slots recording a sent message, as returned by sentWarpMessageKey.
+// The words of the payload follow the payload length.
+const (
+	sentMessageIDOffset uint64 = iota
+	sentMessageSenderOffset
+	sentMessagePayloadLenOffset
+	sentMessagePayloadOffset
+)
+
+// sentWarpMessageKey returns the transient storage slot of the warp precompile at [offset] of the message
+// sent at [index] in the current transaction. Since it is a hash, it cannot collide with the count slot.
+func sentWarpMessageKey(index uint32, offset uint64) common.Hash {
+	var indexBytes [wrappers.IntLen]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+	var offsetBytes [wrappers.LongLen]byte
+	binary.BigEndian.PutUint64(offsetBytes[:], offset)
+	return crypto.Keccak256Hash([]byte("sentWarpMessage"), indexBytes[:], offsetBytes[:])
+}
+
+// sentWarpMessageSlots returns the number of transient storage slots recording a sent message with a
+// payload of [payloadLen] bytes.
+func sentWarpMessageSlots(payloadLen int) uint64 {
+	return sentMessagePayloadOffset + (uint64(payloadLen)+common.HashLength-1)/common.HashLength
+}
+
+// getSentWarpMessagesCount returns the number of messages sent by sendWarpMessage in the current transaction.
+func getSentWarpMessagesCount(stateDB contract.StateDB) uint32 {
+	value := stateDB.GetTransientState(ContractAddress, sentWarpMessagesCountKey)
+	return binary.BigEndian.Uint32(value[common.HashLength-wrappers.IntLen:])
+}
+
+// recordSentWarpMessage records the message with [messageID] sent by [sender] with [payload] in the
+// transient storage of [stateDB], so that it can be read by getSentWarpMessage until the end of the
+// current transaction, and returns the index of the message.
+func recordSentWarpMessage(stateDB contract.StateDB, sender common.Address, messageID common.Hash, payload []byte) uint32 {
+	index := getSentWarpMessagesCount(stateDB)
+	var count common.Hash
+	binary.BigEndian.PutUint32(count[common.HashLength-wrappers.IntLen:], index+1)
+	stateDB.SetTransientState(ContractAddress, sentWarpMessagesCountKey, count)
+
+	var payloadLen common.Hash
+	binary.BigEndian.PutUint64(payloadLen[common.HashLength-wrappers.LongLen:], uint64(len(payload)))
+	stateDB.SetTransientState(ContractAddress, sentWarpMessageKey(index, sentMessageIDOffset), messageID)
+	stateDB.SetTransientState(ContractAddress, sentWarpMessageKey(index, sentMessageSenderOffset), common.BytesToHash(sender[:]))
+	stateDB.SetTransientState(ContractAddress, sentWarpMessageKey(index, sentMessagePayloadLenOffset), payloadLen)
+	for offset := sentMessagePayloadOffset; offset < sentWarpMessageSlots(len(payload)); offset++ {
+		var word common.Hash
+		copy(word[:], payload[(offset-sentMessagePayloadOffset)*common.HashLength:])
+		stateDB.SetTransientState(ContractAddress, sentWarpMessageKey(index, offset), word)
+	}
+	return index
+}
+
+// lastConsumedSequenceKey returns the storage slot of the warp precompile recording the last sequence
+// consumed from [originSenderAddress] on [sourceChainID]. Since it is a hash, it cannot collide with
+// the other slots of the precompile.
+func lastConsumedSequenceKey(sourceChainID common.Hash, originSenderAddress common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("lastConsumedSequence"), sourceChainID[:], originSenderAddress[:])
+}
+
+// GetLastConsumedSequence returns the sequence of the last sequenced warp message from [originSenderAddress]
+// on [sourceChainID] consumed by getVerifiedSequencedWarpMessage, or 0 if none has been consumed.
+func GetLastConsumedSequence(stateDB contract.StateDB, sourceChainID common.Hash, originSenderAddress common.Address) uint64 {
+	value := stateDB.GetState(ContractAddress, lastConsumedSequenceKey(sourceChainID, originSenderAddress))
+	return binary.BigEndian.Uint64(value[common.HashLength-wrappers.LongLen:])
+}
+
+// setLastConsumedSequence records [sequence] as the last sequence consumed from [originSenderAddress]
+// on [sourceChainID] in [stateDB].
+func setLastConsumedSequence(stateDB contract.StateDB, sourceChainID common.Hash, originSenderAddress common.Address, sequence uint64) {
+	var value common.Hash
+	binary.BigEndian.PutUint64(value[common.HashLength-wrappers.LongLen:], sequence)
+	stateDB.SetState(ContractAddress, lastConsumedSequenceKey(sourceChainID, originSenderAddress), value)
+}
+
+// GetSenderAllowListStatus returns the role of [address] in the sender allow list of the warp precompile.
+func GetSenderAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// Storage slots of the warp precompile recording the GasCosts overrides charged during EVM execution.
+// A slot is empty if the corresponding cost is not overridden.
+var (
+	getBlockchainIDGasCostKey            = configKey("getBlockchainIDGasCost")
+	getVerifiedWarpMessageBaseGasCostKey = configKey("getVerifiedWarpMessageBaseGasCost")
+	sendWarpMessageBaseGasCostKey        = configKey("sendWarpMessageBaseGasCost")
+	sendWarpMessagePerByteGasCostKey     = configKey("sendWarpMessagePerByteGasCost")
+	perWarpMessageByteGasCostKey         = configKey("perWarpMessageByteGasCost")
+)
+
+// storeGasCosts records the overrides of [gasCosts] in [stateDB], clearing the overrides
+// of any previous upgrade that [gasCosts] leaves unset. [gasCosts] may be nil.
+func storeGasCosts(stateDB contract.StateDB, gasCosts *GasCosts) {
+	if gasCosts == nil {
+		gasCosts = &GasCosts{}
+	}
+	overrides := []struct {
+		key  common.Hash
+		cost *uint64
+	}{
+		{getBlockchainIDGasCostKey, gasCosts.GetBlockchainID},
+		{getVerifiedWarpMessageBaseGasCostKey, gasCosts.GetVerifiedWarpMessageBase},
+		{sendWarpMessageBaseGasCostKey, gasCosts.SendWarpMessageBase},
+		{sendWarpMessagePerByteGasCostKey, gasCosts.SendWarpMessagePerByte},
+		{perWarpMessageByteGasCostKey, gasCosts.PerWarpMessageByte},
+	}
+	for _, override := range overrides {
+		if override.cost != nil {
+			// Mark the slot as set, so that an override of 0 is distinguished from an empty slot.
+			value := common.Hash{0: 1}
+			binary.BigEndian.PutUint64(value[common.HashLength-wrappers.LongLen:], *override.cost)
+			stateDB.SetState(ContractAddress, override.key, value)
+		} else if stateDB.GetState(ContractAddress, override.key) != (common.Hash{}) {
+			// Avoid touching the state unless a previous upgrade overrode this cost.
+			stateDB.SetState(ContractAddress, override.key, common.Hash{})
+		}
+	}
+}
+
+// getGasCost returns the gas cost stored in [stateDB] at [key] or [defaultCost] if it is not overridden.
+func getGasCost(stateDB contract.StateDB, key common.Hash, defaultCost uint64) uint64 {
+	value := stateDB.GetState(ContractAddress, key)
+	if value == (common.Hash{}) {
+		return defaultCost
+	}
+	return binary.BigEndian.Uint64(value[common.HashLength-wrappers.LongLen:])
+}
+
 // Singleton StatefulPrecompiledContract and signatures.
 var (
 	// WarpRawABI contains the raw ABI of Warp contract.
@@ -75,10 +459,86 @@ type GetVerifiedWarpMessageOutput struct {
 	Valid   bool
 }
 
+type GetVerifiedWarpMessageRawOutput struct {
+	Message         WarpMessage
+	UnsignedMessage []byte
+	Valid           bool
+}
+
+// SequencedWarpMessage is an auto generated low-level Go binding around an user-defined struct.
+type SequencedWarpMessage struct {
+	SourceChainID       common.Hash
+	OriginSenderAddress common.Address
+	Sequence            uint64
+	Payload             []byte
+}
+
+type GetVerifiedSequencedWarpMessageOutput struct {
+	Message SequencedWarpMessage
+	Valid   bool
+}
+
+type EstimateVerifiedWarpMessageGasOutput struct {
+	Gas   uint64
+	Valid bool
+}
+
+type SendWarpMessageWithIndexOutput struct {
+	MessageID common.Hash
+	Index     uint32
+}
+
+type GetSentWarpMessageOutput struct {
+	Message   WarpMessage
+	MessageID common.Hash
+	Valid     bool
+}
+
+type SendWarpMessageMultiInput struct {
+	DestinationChainIDs []common.Hash
+	DestinationAddress  common.Hash
+	Payload             []byte
+}
+
+// SendWarpMessageInput is a message sent by sendWarpMessages. Its payload is sent as the
+// MultiDestinationPayload of its destination, so that receiving contracts decode the messages of
+// sendWarpMessages and sendWarpMessageMulti alike.
+type SendWarpMessageInput struct {
+	DestinationChainID common.Hash
+	DestinationAddress common.Hash
+	Payload            []byte
+}
+
+type SendWarpMessagesInput struct {
+	Messages []SendWarpMessageInput
+}
+
 type SendWarpMessageEventData struct {
 	Message []byte
 }
 
+// MultiDestinationPayload is the payload of the AddressedCall of each warp message sent by
+// sendWarpMessageMulti. It is ABI encoded as (bytes32, bytes32, bytes), so that receiving
+// contracts can decode it with abi.decode.
+type MultiDestinationPayload struct {
+	DestinationChainID common.Hash
+	DestinationAddress common.Hash
+	Payload            []byte
+}
+
+// multiDestinationPayloadArgs are the ABI arguments MultiDestinationPayload is encoded with.
+var multiDestinationPayloadArgs = func() abi.Arguments {
+	bytes32Type, err := abi.NewType("bytes32", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	bytesType, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return abi.Arguments{{Type: bytes32Type}, {Type: bytes32Type}, {Type: bytesType}}
+}()
+
 // PackGetBlockchainID packs the include selector (first 4 func signature bytes).
 // This function is mostly used for tests.
 func PackGetBlockchainID() ([]byte, error) {
@@ -93,7 +553,8 @@ func PackGetBlockchainIDOutput(blockchainID common.Hash) ([]byte, error) {
 
 // getBlockchainID returns the snow Chain Context ChainID of this blockchain.
 func getBlockchainID(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
-	if remainingGas, err = contract.DeductGas(suppliedGas, GetBlockchainIDGasCost); err != nil {
+	gasCost := getGasCost(accessibleState.GetStateDB(), getBlockchainIDGasCostKey, GetBlockchainIDGasCost)
+	if remainingGas, err = contract.DeductGas(suppliedGas, gasCost); err != nil {
 		return nil, 0, err
 	}
 	packedOutput, err := PackGetBlockchainIDOutput(common.Hash(accessibleState.GetSnowContext().ChainID))
@@ -105,17 +566,62 @@ func getBlockchainID(accessibleState contract.AccessibleState, caller common.Add
 	return packedOutput, remainingGas, nil
 }
 
+// PackGetWarpFormatVersion packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetWarpFormatVersion() ([]byte, error) {
+	return WarpABI.Pack("getWarpFormatVersion")
+}
+
+// PackGetWarpFormatVersionOutput attempts to pack given [version] of type uint16
+// to conform the ABI outputs.
+func PackGetWarpFormatVersionOutput(version uint16) ([]byte, error) {
+	return WarpABI.PackOutput("getWarpFormatVersion", version)
+}
+
+// getWarpFormatVersion returns the codec version of the unsigned warp messages sent by sendWarpMessage,
+// so that receivers can check whether they support the format of the messages of this chain.
+// Only activated by the FormatVersionEnabled config of the precompile.
+func getWarpFormatVersion(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetWarpFormatVersionGasCost); err != nil {
+		return nil, 0, err
+	}
+	packedOutput, err := PackGetWarpFormatVersionOutput(warp.CodecVersion)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackHasWarpPredicates packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackHasWarpPredicates() ([]byte, error) {
+	return WarpABI.Pack("hasWarpPredicates")
+}
+
+// PackHasWarpPredicatesOutput attempts to pack given [hasPredicates] of type bool
+// to conform the ABI outputs.
+func PackHasWarpPredicatesOutput(hasPredicates bool) ([]byte, error) {
+	return WarpABI.PackOutput("hasWarpPredicates", hasPredicates)
+}
+
+// hasWarpPredicates returns whether the current transaction includes warp messages in its access list.
+// Only activated by the WarpPredicatesEnabled config of the precompile.
+func hasWarpPredicates(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, HasWarpPredicatesGasCost); err != nil {
+		return nil, 0, err
+	}
+	_, hasPredicates := accessibleState.GetStateDB().GetPredicateStorageSlots(ContractAddress, 0)
+	packedOutput, err := PackHasWarpPredicatesOutput(hasPredicates)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
 // UnpackGetVerifiedWarpBlockHashInput attempts to unpack [input] into the uint32 type argument
 // assumes that [input] does not include selector (omits first 4 func signature bytes)
 func UnpackGetVerifiedWarpBlockHashInput(input []byte) (uint32, error) {
-	// We don't use strict mode here because it was disabled with Durango.
-	// Since Warp will be deployed after Durango, we don't need to use strict mode
-	res, err := WarpABI.UnpackInput("getVerifiedWarpBlockHash", input, false)
-	if err != nil {
-		return 0, err
-	}
-	unpacked := *abi.ConvertType(res[0], new(uint32)).(*uint32)
-	return unpacked, nil
+	return unpackIndexInput("getVerifiedWarpBlockHash", input)
 }
 
 // PackGetVerifiedWarpBlockHash packs [index] of type uint32 into the appropriate arguments for getVerifiedWarpBlockHash.
@@ -150,11 +656,25 @@ func getVerifiedWarpBlockHash(accessibleState contract.AccessibleState, caller c
 // UnpackGetVerifiedWarpMessageInput attempts to unpack [input] into the uint32 type argument
 // assumes that [input] does not include selector (omits first 4 func signature bytes)
 func UnpackGetVerifiedWarpMessageInput(input []byte) (uint32, error) {
+	return unpackIndexInput("getVerifiedWarpMessage", input)
+}
+
+// unpackIndexInput unpacks [input] into the uint32 index argument of [method]. Returns an error wrapping
+// errMalformedIndexInput if [input] cannot be unpacked as the arguments of [method], or
+// errIndexOutOfRange if it holds an index that does not fit in a uint32, so that neither is mistaken
+// for a valid index.
+func unpackIndexInput(method string, input []byte) (uint32, error) {
+	if len(input) < common.HashLength {
+		return 0, fmt.Errorf("%w: %d bytes, expected %d", errMalformedIndexInput, len(input), common.HashLength)
+	}
+	if index := new(big.Int).SetBytes(input[:common.HashLength]); !index.IsUint64() || index.Uint64() > math.MaxUint32 {
+		return 0, fmt.Errorf("%w: %s exceeds MaxUint32", errIndexOutOfRange, index)
+	}
 	// We don't use strict mode here because it was disabled with Durango.
 	// Since Warp will be deployed after Durango, we don't need to use strict mode.
-	res, err := WarpABI.UnpackInput("getVerifiedWarpMessage", input, false)
+	res, err := WarpABI.UnpackInput(method, input, false)
 	if err != nil {
-		return 0, err
+		return 0, fmt.Errorf("%w: %s", errMalformedIndexInput, err)
 	}
 	unpacked := *abi.ConvertType(res[0], new(uint32)).(*uint32)
 	return unpacked, nil
@@ -191,6 +711,138 @@ func getVerifiedWarpMessage(accessibleState contract.AccessibleState, caller com
 	return handleWarpMessage(accessibleState, input, suppliedGas, addressedPayloadHandler{})
 }
 
+// PackGetVerifiedWarpMessageRaw packs [index] of type uint32 into the appropriate arguments for getVerifiedWarpMessageRaw.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetVerifiedWarpMessageRaw(index uint32) ([]byte, error) {
+	return WarpABI.Pack("getVerifiedWarpMessageRaw", index)
+}
+
+// PackGetVerifiedWarpMessageRawOutput attempts to pack given [outputStruct] of type GetVerifiedWarpMessageRawOutput
+// to conform the ABI outputs.
+func PackGetVerifiedWarpMessageRawOutput(outputStruct GetVerifiedWarpMessageRawOutput) ([]byte, error) {
+	return WarpABI.PackOutput("getVerifiedWarpMessageRaw",
+		outputStruct.Message,
+		outputStruct.UnsignedMessage,
+		outputStruct.Valid,
+	)
+}
+
+// UnpackGetVerifiedWarpMessageRawOutput attempts to unpack [output] as GetVerifiedWarpMessageRawOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetVerifiedWarpMessageRawOutput(output []byte) (GetVerifiedWarpMessageRawOutput, error) {
+	outputStruct := GetVerifiedWarpMessageRawOutput{}
+	err := WarpABI.UnpackIntoInterface(&outputStruct, "getVerifiedWarpMessageRaw", output)
+
+	return outputStruct, err
+}
+
+// getVerifiedWarpMessageRaw behaves as getVerifiedWarpMessage and additionally returns the bytes of the
+// unsigned warp message, so that the caller can forward or re-hash the message without re-encoding it.
+// It is only activated if enabled by the RawMessagesEnabled config of the precompile.
+func getVerifiedWarpMessageRaw(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	return handleWarpMessage(accessibleState, input, suppliedGas, rawAddressedPayloadHandler{})
+}
+
+// PackGetVerifiedSequencedWarpMessage packs [index] of type uint32 into the appropriate arguments for getVerifiedSequencedWarpMessage.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetVerifiedSequencedWarpMessage(index uint32) ([]byte, error) {
+	return WarpABI.Pack("getVerifiedSequencedWarpMessage", index)
+}
+
+// PackGetVerifiedSequencedWarpMessageOutput attempts to pack given [outputStruct] of type GetVerifiedSequencedWarpMessageOutput
+// to conform the ABI outputs.
+func PackGetVerifiedSequencedWarpMessageOutput(outputStruct GetVerifiedSequencedWarpMessageOutput) ([]byte, error) {
+	return WarpABI.PackOutput("getVerifiedSequencedWarpMessage",
+		outputStruct.Message,
+		outputStruct.Valid,
+	)
+}
+
+// UnpackGetVerifiedSequencedWarpMessageOutput attempts to unpack [output] as GetVerifiedSequencedWarpMessageOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetVerifiedSequencedWarpMessageOutput(output []byte) (GetVerifiedSequencedWarpMessageOutput, error) {
+	outputStruct := GetVerifiedSequencedWarpMessageOutput{}
+	err := WarpABI.UnpackIntoInterface(&outputStruct, "getVerifiedSequencedWarpMessage", output)
+
+	return outputStruct, err
+}
+
+// getVerifiedSequencedWarpMessage behaves as getVerifiedWarpMessage and additionally decodes the sequence
+// of a message whose payload was packed by PackSequencedWarpPayload. A sequenced message is only valid if
+// its sequence directly follows the last sequence consumed from its source chain and origin sender, and
+// reading it consumes its sequence. Any other payload is returned as is with a sequence of 0.
+// It is only activated if enabled by the EnforceSequenceOrdering config of the precompile.
+func getVerifiedSequencedWarpMessage(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetVerifiedSequencedWarpMessageGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	return handleWarpMessage(accessibleState, input, remainingGas, sequencedPayloadHandler{stateDB: accessibleState.GetStateDB()})
+}
+
+// PackEstimateVerifiedWarpMessageGas packs [index] of type uint32 into the appropriate arguments for estimateVerifiedWarpMessageGas.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackEstimateVerifiedWarpMessageGas(index uint32) ([]byte, error) {
+	return WarpABI.Pack("estimateVerifiedWarpMessageGas", index)
+}
+
+// PackEstimateVerifiedWarpMessageGasOutput attempts to pack given [outputStruct] of type EstimateVerifiedWarpMessageGasOutput
+// to conform the ABI outputs.
+func PackEstimateVerifiedWarpMessageGasOutput(outputStruct EstimateVerifiedWarpMessageGasOutput) ([]byte, error) {
+	return WarpABI.PackOutput("estimateVerifiedWarpMessageGas",
+		outputStruct.Gas,
+		outputStruct.Valid,
+	)
+}
+
+// UnpackEstimateVerifiedWarpMessageGasOutput attempts to unpack [output] as EstimateVerifiedWarpMessageGasOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackEstimateVerifiedWarpMessageGasOutput(output []byte) (EstimateVerifiedWarpMessageGasOutput, error) {
+	outputStruct := EstimateVerifiedWarpMessageGasOutput{}
+	err := WarpABI.UnpackIntoInterface(&outputStruct, "estimateVerifiedWarpMessageGas", output)
+
+	return outputStruct, err
+}
+
+// estimateVerifiedWarpMessageGas returns the gas getVerifiedWarpMessage charges to read the warp message
+// at the index given by [input], and whether the message at that index exists and passed verification.
+// If it does not, getVerifiedWarpMessage only charges its base cost. The message is not read, so only
+// EstimateVerifiedWarpMessageGasCost is charged. It is only activated if enabled by the
+// GasEstimatesEnabled config of the precompile.
+func estimateVerifiedWarpMessageGas(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, EstimateVerifiedWarpMessageGasCost); err != nil {
+		return nil, 0, err
+	}
+	predicateBytes, valid, err := getPredicateBytes(accessibleState, input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	state := accessibleState.GetStateDB()
+	gas := getGasCost(state, getVerifiedWarpMessageBaseGasCostKey, GetVerifiedWarpMessageBaseCost)
+	if valid {
+		msgBytesGas, overflow := warpMessageBytesGas(state, predicateBytes)
+		if overflow {
+			return nil, 0, vmerrs.ErrOutOfGas
+		}
+		if gas, overflow = math.SafeAdd(gas, msgBytesGas); overflow {
+			return nil, 0, vmerrs.ErrOutOfGas
+		}
+	}
+	packedOutput, err := PackEstimateVerifiedWarpMessageGasOutput(EstimateVerifiedWarpMessageGasOutput{
+		Gas:   gas,
+		Valid: valid,
+	})
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
 // UnpackSendWarpMessageInput attempts to unpack [input] as []byte
 // assumes that [input] does not include selector (omits first 4 func signature bytes)
 func UnpackSendWarpMessageInput(input []byte) ([]byte, error) {
@@ -229,47 +881,233 @@ func UnpackSendWarpMessageOutput(output []byte) (common.Hash, error) {
 // sendWarpMessage constructs an Avalanche Warp Message containing an AddressedPayload and emits a log to signal validators that they should
 // be willing to sign this message.
 func sendWarpMessage(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
-	if remainingGas, err = contract.DeductGas(suppliedGas, SendWarpMessageGasCost); err != nil {
-		return nil, 0, err
+	messageID, _, remainingGas, err := sendSingleWarpMessage(accessibleState, caller, input, suppliedGas, readOnly)
+	if err != nil {
+		return nil, remainingGas, err
 	}
-	// This gas cost includes buffer room because it is based off of the total size of the input instead of the produced payload.
-	// This ensures that we charge gas before we unpack the variable sized input.
-	payloadGas, overflow := math.SafeMul(SendWarpMessageGasCostPerByte, uint64(len(input)))
-	if overflow {
-		return nil, 0, vmerrs.ErrOutOfGas
+	packed, err := PackSendWarpMessageOutput(messageID)
+	if err != nil {
+		return nil, remainingGas, err
 	}
-	if remainingGas, err = contract.DeductGas(remainingGas, payloadGas); err != nil {
-		return nil, 0, err
+
+	// Return the packed message ID and the remaining gas
+	return packed, remainingGas, nil
+}
+
+// PackSendWarpMessageWithIndex packs [payloadData] of type []byte into the appropriate arguments for sendWarpMessageWithIndex.
+func PackSendWarpMessageWithIndex(payloadData []byte) ([]byte, error) {
+	return WarpABI.Pack("sendWarpMessageWithIndex", payloadData)
+}
+
+// PackSendWarpMessageWithIndexOutput attempts to pack given [outputStruct] of type SendWarpMessageWithIndexOutput
+// to conform the ABI outputs.
+func PackSendWarpMessageWithIndexOutput(outputStruct SendWarpMessageWithIndexOutput) ([]byte, error) {
+	return WarpABI.PackOutput("sendWarpMessageWithIndex",
+		outputStruct.MessageID,
+		outputStruct.Index,
+	)
+}
+
+// UnpackSendWarpMessageWithIndexOutput attempts to unpack [output] as SendWarpMessageWithIndexOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackSendWarpMessageWithIndexOutput(output []byte) (SendWarpMessageWithIndexOutput, error) {
+	outputStruct := SendWarpMessageWithIndexOutput{}
+	err := WarpABI.UnpackIntoInterface(&outputStruct, "sendWarpMessageWithIndex", output)
+
+	return outputStruct, err
+}
+
+// sendWarpMessageWithIndex sends a warp message as sendWarpMessage does, and returns the index of the message
+// among the messages sent in the current transaction along with its ID, which can be passed to getSentWarpMessage.
+// Only activated by the SentMessagesEnabled config of the precompile, so the message is always recorded.
+func sendWarpMessageWithIndex(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	messageID, index, remainingGas, err := sendSingleWarpMessage(accessibleState, caller, input, suppliedGas, readOnly)
+	if err != nil {
+		return nil, remainingGas, err
 	}
-	if readOnly {
-		return nil, remainingGas, vmerrs.ErrWriteProtection
+	packed, err := PackSendWarpMessageWithIndexOutput(SendWarpMessageWithIndexOutput{
+		MessageID: messageID,
+		Index:     index,
+	})
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	// Return the packed message ID and index and the remaining gas
+	return packed, remainingGas, nil
+}
+
+// sendSingleWarpMessage sends the payload of [input] from [caller] in a warp message, which is the input of both
+// sendWarpMessage and sendWarpMessageWithIndex, and returns the ID of the message.
+// Once sent messages are enabled by the SentMessagesEnabled config of the precompile, the message is also
+// recorded for getSentWarpMessage and its index among the messages sent in the current transaction is returned.
+func sendSingleWarpMessage(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (messageID common.Hash, index uint32, remainingGas uint64, err error) {
+	stateDB := accessibleState.GetStateDB()
+	if remainingGas, err = deductSendGas(stateDB, caller, input, suppliedGas, readOnly); err != nil {
+		return common.Hash{}, 0, remainingGas, err
 	}
 	// unpack the arguments
 	payloadData, err := UnpackSendWarpMessageInput(input)
 	if err != nil {
-		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidSendInput, err)
+		return common.Hash{}, 0, remainingGas, fmt.Errorf("%w: %s", errInvalidSendInput, err)
 	}
 
-	var (
-		sourceChainID = accessibleState.GetSnowContext().ChainID
-		sourceAddress = caller
-	)
+	sentMessagesEnabled := sentMessagesFlag.enabled(stateDB)
+	if sentMessagesEnabled {
+		// Charge for writing the message and reading and writing the count of sent messages.
+		recordGas, overflow := math.SafeMul(SentWarpMessageGasCostPerSlot, sentWarpMessageSlots(len(payloadData))+2)
+		if overflow {
+			return common.Hash{}, 0, 0, vmerrs.ErrOutOfGas
+		}
+		if remainingGas, err = contract.DeductGas(remainingGas, recordGas); err != nil {
+			return common.Hash{}, 0, 0, err
+		}
+	}
+	if err := chargeMessageFee(stateDB, caller, 1); err != nil {
+		return common.Hash{}, 0, remainingGas, err
+	}
 
-	addressedPayload, err := payload.NewAddressedCall(
-		sourceAddress.Bytes(),
-		payloadData,
-	)
+	messageID, err = emitWarpMessage(accessibleState, caller, payloadData)
 	if err != nil {
-		return nil, remainingGas, err
+		return common.Hash{}, 0, remainingGas, err
 	}
-	unsignedWarpMessage, err := warp.NewUnsignedMessage(
-		accessibleState.GetSnowContext().NetworkID,
-		sourceChainID,
-		addressedPayload.Bytes(),
+	if sentMessagesEnabled {
+		index = recordSentWarpMessage(stateDB, caller, messageID, payloadData)
+	}
+	return messageID, index, remainingGas, nil
+}
+
+// PackGetSentWarpMessage packs [index] of type uint32 into the appropriate arguments for getSentWarpMessage.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetSentWarpMessage(index uint32) ([]byte, error) {
+	return WarpABI.Pack("getSentWarpMessage", index)
+}
+
+// UnpackGetSentWarpMessageInput attempts to unpack [input] into the uint32 type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetSentWarpMessageInput(input []byte) (uint32, error) {
+	res, err := WarpABI.UnpackInput("getSentWarpMessage", input, false)
+	if err != nil {
+		return 0, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(uint32)).(*uint32)
+	return unpacked, nil
+}
+
+// PackGetSentWarpMessageOutput attempts to pack given [outputStruct] of type GetSentWarpMessageOutput
+// to conform the ABI outputs.
+func PackGetSentWarpMessageOutput(outputStruct GetSentWarpMessageOutput) ([]byte, error) {
+	return WarpABI.PackOutput("getSentWarpMessage",
+		outputStruct.Message,
+		outputStruct.MessageID,
+		outputStruct.Valid,
 	)
+}
+
+// UnpackGetSentWarpMessageOutput attempts to unpack [output] as GetSentWarpMessageOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetSentWarpMessageOutput(output []byte) (GetSentWarpMessageOutput, error) {
+	outputStruct := GetSentWarpMessageOutput{}
+	err := WarpABI.UnpackIntoInterface(&outputStruct, "getSentWarpMessage", output)
+
+	return outputStruct, err
+}
+
+// getSentWarpMessage returns the WarpMessage and message ID sent by sendWarpMessage at the index given by
+// [input] within the current transaction, and whether a message was sent at that index. Messages are
+// recorded in transient storage, so messages sent by previous transactions are not returned.
+// It is only activated if enabled by the SentMessagesEnabled config of the precompile.
+func getSentWarpMessage(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetSentWarpMessageBaseGasCost); err != nil {
+		return nil, 0, err
+	}
+	index, err := UnpackGetSentWarpMessageInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidIndexInput, err)
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	outputStruct := GetSentWarpMessageOutput{}
+	if index < getSentWarpMessagesCount(stateDB) {
+		payloadLenValue := stateDB.GetTransientState(ContractAddress, sentWarpMessageKey(index, sentMessagePayloadLenOffset))
+		payloadLen := binary.BigEndian.Uint64(payloadLenValue[common.HashLength-wrappers.LongLen:])
+		// The payload was unpacked from calldata, so its length fits in an int.
+		slots := sentWarpMessageSlots(int(payloadLen))
+		payloadGas, overflow := math.SafeMul(SentWarpMessageGasCostPerSlot, slots-sentMessagePayloadOffset)
+		if overflow {
+			return nil, 0, vmerrs.ErrOutOfGas
+		}
+		if remainingGas, err = contract.DeductGas(remainingGas, payloadGas); err != nil {
+			return nil, 0, err
+		}
+		payload := make([]byte, 0, (slots-sentMessagePayloadOffset)*common.HashLength)
+		for offset := sentMessagePayloadOffset; offset < slots; offset++ {
+			word := stateDB.GetTransientState(ContractAddress, sentWarpMessageKey(index, offset))
+			payload = append(payload, word[:]...)
+		}
+		sender := stateDB.GetTransientState(ContractAddress, sentWarpMessageKey(index, sentMessageSenderOffset))
+		outputStruct = GetSentWarpMessageOutput{
+			Message: WarpMessage{
+				SourceChainID:       common.Hash(accessibleState.GetSnowContext().ChainID),
+				OriginSenderAddress: common.BytesToAddress(sender[:]),
+				Payload:             payload[:payloadLen],
+			},
+			MessageID: stateDB.GetTransientState(ContractAddress, sentWarpMessageKey(index, sentMessageIDOffset)),
+			Valid:     true,
+		}
+	}
+	packedOutput, err := PackGetSentWarpMessageOutput(outputStruct)
 	if err != nil {
 		return nil, remainingGas, err
 	}
+	return packedOutput, remainingGas, nil
+}
+
+// deductSendGas deducts the cost of sending a single warp message with [input] from [suppliedGas]
+// and verifies that [caller] may send warp messages.
+func deductSendGas(stateDB contract.StateDB, caller common.Address, input []byte, suppliedGas uint64, readOnly bool) (remainingGas uint64, err error) {
+	baseGas := getGasCost(stateDB, sendWarpMessageBaseGasCostKey, SendWarpMessageGasCost)
+	if remainingGas, err = contract.DeductGas(suppliedGas, baseGas); err != nil {
+		return 0, err
+	}
+	// This gas cost includes buffer room because it is based off of the total size of the input instead of the produced payload.
+	// This ensures that we charge gas before we unpack the variable sized input.
+	perByteGas := getGasCost(stateDB, sendWarpMessagePerByteGasCostKey, SendWarpMessageGasCostPerByte)
+	payloadGas, overflow := math.SafeMul(perByteGas, uint64(len(input)))
+	if overflow {
+		return 0, vmerrs.ErrOutOfGas
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, payloadGas); err != nil {
+		return 0, err
+	}
+	if readOnly {
+		return remainingGas, vmerrs.ErrWriteProtection
+	}
+	if senderAllowListFlag.enabled(stateDB) {
+		if remainingGas, err = contract.DeductGas(remainingGas, allowlist.ReadAllowListGasCost); err != nil {
+			return 0, err
+		}
+		// Verify that the caller is in the sender allow list and therefore has the right to send warp messages.
+		callerStatus := GetSenderAllowListStatus(stateDB, caller)
+		if !callerStatus.IsEnabled() {
+			return remainingGas, fmt.Errorf("%w: %s", ErrCannotSendWarpMessage, caller)
+		}
+	}
+	return remainingGas, nil
+}
+
+// emitWarpMessage constructs the unsigned warp message sent by [sourceAddress] with [payloadData] and
+// emits it in a SendWarpMessage log. It returns the ID of the unsigned message.
+func emitWarpMessage(accessibleState contract.AccessibleState, sourceAddress common.Address, payloadData []byte) (common.Hash, error) {
+	unsignedWarpMessage, err := newUnsignedWarpMessage(accessibleState.GetSnowContext().NetworkID, &WarpMessage{
+		SourceChainID:       common.Hash(accessibleState.GetSnowContext().ChainID),
+		OriginSenderAddress: sourceAddress,
+		Payload:             payloadData,
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
 
 	// Add a log to be handled if this action is finalized.
 	topics, data, err := PackSendWarpMessageEvent(
@@ -278,7 +1116,7 @@ func sendWarpMessage(accessibleState contract.AccessibleState, caller common.Add
 		unsignedWarpMessage.Bytes(),
 	)
 	if err != nil {
-		return nil, remainingGas, err
+		return common.Hash{}, err
 	}
 	accessibleState.GetStateDB().AddLog(
 		ContractAddress,
@@ -286,16 +1124,253 @@ func sendWarpMessage(accessibleState contract.AccessibleState, caller common.Add
 		data,
 		accessibleState.GetBlockContext().Number().Uint64(),
 	)
+	return common.Hash(unsignedWarpMessage.ID()), nil
+}
+
+// UnpackSendWarpMessageMultiInput attempts to unpack [input] as SendWarpMessageMultiInput
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSendWarpMessageMultiInput(input []byte) (SendWarpMessageMultiInput, error) {
+	inputStruct := SendWarpMessageMultiInput{}
+	err := WarpABI.UnpackInputIntoInterface(&inputStruct, "sendWarpMessageMulti", input, false)
+	return inputStruct, err
+}
+
+// PackSendWarpMessageMulti packs [inputStruct] of type SendWarpMessageMultiInput into the appropriate arguments for sendWarpMessageMulti.
+func PackSendWarpMessageMulti(inputStruct SendWarpMessageMultiInput) ([]byte, error) {
+	return WarpABI.Pack("sendWarpMessageMulti", inputStruct.DestinationChainIDs, inputStruct.DestinationAddress, inputStruct.Payload)
+}
+
+// PackSendWarpMessageMultiOutput attempts to pack given messageIDs of type []common.Hash
+// to conform the ABI outputs.
+func PackSendWarpMessageMultiOutput(messageIDs []common.Hash) ([]byte, error) {
+	return WarpABI.PackOutput("sendWarpMessageMulti", messageIDs)
+}
 
-	packed, err := PackSendWarpMessageOutput(common.Hash(unsignedWarpMessage.ID()))
+// UnpackSendWarpMessageMultiOutput attempts to unpack given [output] into the []common.Hash type output
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackSendWarpMessageMultiOutput(output []byte) ([]common.Hash, error) {
+	res, err := WarpABI.Unpack("sendWarpMessageMulti", output)
 	if err != nil {
+		return nil, err
+	}
+	unpacked := *abi.ConvertType(res[0], new([]common.Hash)).(*[]common.Hash)
+	return unpacked, nil
+}
+
+// PackMultiDestinationPayload encodes [payload] as the payload of the AddressedCall of a warp message
+// sent by sendWarpMessageMulti.
+func PackMultiDestinationPayload(payload MultiDestinationPayload) ([]byte, error) {
+	return multiDestinationPayloadArgs.Pack(payload.DestinationChainID, payload.DestinationAddress, payload.Payload)
+}
+
+// UnpackMultiDestinationPayload decodes the payload of the AddressedCall of a warp message sent by
+// sendWarpMessageMulti.
+func UnpackMultiDestinationPayload(data []byte) (MultiDestinationPayload, error) {
+	res, err := multiDestinationPayloadArgs.Unpack(data)
+	if err != nil {
+		return MultiDestinationPayload{}, err
+	}
+	return MultiDestinationPayload{
+		DestinationChainID: *abi.ConvertType(res[0], new(common.Hash)).(*common.Hash),
+		DestinationAddress: *abi.ConvertType(res[1], new(common.Hash)).(*common.Hash),
+		Payload:            *abi.ConvertType(res[2], new([]byte)).(*[]byte),
+	}, nil
+}
+
+// sendWarpMessageMulti sends a warp message from [caller] to each of the destination chains in [input].
+// Each message is emitted in its own SendWarpMessage log and carries a MultiDestinationPayload, so that
+// its destination is covered by the signature of the message.
+//
+// In addition to the cost of sendWarpMessage, it charges the base cost and the per-byte cost of the
+// payload for each destination after the first, since each log includes the payload.
+func sendWarpMessageMulti(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	stateDB := accessibleState.GetStateDB()
+	if remainingGas, err = deductSendGas(stateDB, caller, input, suppliedGas, readOnly); err != nil {
 		return nil, remainingGas, err
 	}
+	// unpack the arguments
+	inputStruct, err := UnpackSendWarpMessageMultiInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidSendMultiInput, err)
+	}
+	if len(inputStruct.DestinationChainIDs) == 0 {
+		return nil, remainingGas, errNoDestinations
+	}
 
-	// Return the packed message ID and the remaining gas
+	baseGas := getGasCost(stateDB, sendWarpMessageBaseGasCostKey, SendWarpMessageGasCost)
+	perByteGas := getGasCost(stateDB, sendWarpMessagePerByteGasCostKey, SendWarpMessageGasCostPerByte)
+	payloadGas, overflow := math.SafeMul(perByteGas, uint64(len(inputStruct.Payload)))
+	if overflow {
+		return nil, 0, vmerrs.ErrOutOfGas
+	}
+	perDestinationGas, overflow := math.SafeAdd(baseGas, payloadGas)
+	if overflow {
+		return nil, 0, vmerrs.ErrOutOfGas
+	}
+	destinationsGas, overflow := math.SafeMul(perDestinationGas, uint64(len(inputStruct.DestinationChainIDs)-1))
+	if overflow {
+		return nil, 0, vmerrs.ErrOutOfGas
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, destinationsGas); err != nil {
+		return nil, 0, err
+	}
+	if remainingGas, err = checkDestinationChains(accessibleState, inputStruct.DestinationChainIDs, remainingGas); err != nil {
+		return nil, remainingGas, err
+	}
+	if err := chargeMessageFee(stateDB, caller, len(inputStruct.DestinationChainIDs)); err != nil {
+		return nil, remainingGas, err
+	}
+
+	messageIDs := make([]common.Hash, 0, len(inputStruct.DestinationChainIDs))
+	for _, destinationChainID := range inputStruct.DestinationChainIDs {
+		payloadData, err := PackMultiDestinationPayload(MultiDestinationPayload{
+			DestinationChainID: destinationChainID,
+			DestinationAddress: inputStruct.DestinationAddress,
+			Payload:            inputStruct.Payload,
+		})
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		messageID, err := emitWarpMessage(accessibleState, caller, payloadData)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		messageIDs = append(messageIDs, messageID)
+	}
+
+	packed, err := PackSendWarpMessageMultiOutput(messageIDs)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packed, remainingGas, nil
+}
+
+// UnpackSendWarpMessagesInput attempts to unpack [input] as SendWarpMessagesInput
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSendWarpMessagesInput(input []byte) (SendWarpMessagesInput, error) {
+	inputStruct := SendWarpMessagesInput{}
+	err := WarpABI.UnpackInputIntoInterface(&inputStruct, "sendWarpMessages", input, false)
+	return inputStruct, err
+}
+
+// PackSendWarpMessages packs [messages] into the appropriate arguments for sendWarpMessages.
+func PackSendWarpMessages(messages []SendWarpMessageInput) ([]byte, error) {
+	return WarpABI.Pack("sendWarpMessages", messages)
+}
+
+// PackSendWarpMessagesOutput attempts to pack given messageIDs of type []common.Hash
+// to conform the ABI outputs.
+func PackSendWarpMessagesOutput(messageIDs []common.Hash) ([]byte, error) {
+	return WarpABI.PackOutput("sendWarpMessages", messageIDs)
+}
+
+// UnpackSendWarpMessagesOutput attempts to unpack given [output] into the []common.Hash type output
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackSendWarpMessagesOutput(output []byte) ([]common.Hash, error) {
+	res, err := WarpABI.Unpack("sendWarpMessages", output)
+	if err != nil {
+		return nil, err
+	}
+	unpacked := *abi.ConvertType(res[0], new([]common.Hash)).(*[]common.Hash)
+	return unpacked, nil
+}
+
+// sendWarpMessages sends each message in [input] from [caller] to its own destination with its own
+// payload. Each message is emitted in its own SendWarpMessage log and carries a MultiDestinationPayload,
+// so that its destination is covered by the signature of the message.
+//
+// In addition to the cost of sendWarpMessage, which covers the bytes of every payload in [input], it
+// charges the base cost for each message after the first. The call fails if [input] holds more messages
+// than the configured maximum.
+func sendWarpMessages(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	stateDB := accessibleState.GetStateDB()
+	if remainingGas, err = deductSendGas(stateDB, caller, input, suppliedGas, readOnly); err != nil {
+		return nil, remainingGas, err
+	}
+	// unpack the arguments
+	inputStruct, err := UnpackSendWarpMessagesInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidSendBatchInput, err)
+	}
+	messages := inputStruct.Messages
+	if len(messages) == 0 {
+		return nil, remainingGas, errNoMessages
+	}
+	if maxMessages := getMaxBatchMessages(stateDB); uint64(len(messages)) > maxMessages {
+		return nil, remainingGas, fmt.Errorf("%w: %d > max batch messages (%d)", errTooManyMessages, len(messages), maxMessages)
+	}
+
+	baseGas := getGasCost(stateDB, sendWarpMessageBaseGasCostKey, SendWarpMessageGasCost)
+	messagesGas, overflow := math.SafeMul(baseGas, uint64(len(messages)-1))
+	if overflow {
+		return nil, 0, vmerrs.ErrOutOfGas
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, messagesGas); err != nil {
+		return nil, 0, err
+	}
+	destinationChainIDs := make([]common.Hash, 0, len(messages))
+	for _, message := range messages {
+		destinationChainIDs = append(destinationChainIDs, message.DestinationChainID)
+	}
+	if remainingGas, err = checkDestinationChains(accessibleState, destinationChainIDs, remainingGas); err != nil {
+		return nil, remainingGas, err
+	}
+	if err := chargeMessageFee(stateDB, caller, len(messages)); err != nil {
+		return nil, remainingGas, err
+	}
+
+	messageIDs := make([]common.Hash, 0, len(messages))
+	for _, message := range messages {
+		payloadData, err := PackMultiDestinationPayload(MultiDestinationPayload{
+			DestinationChainID: message.DestinationChainID,
+			DestinationAddress: message.DestinationAddress,
+			Payload:            message.Payload,
+		})
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		messageID, err := emitWarpMessage(accessibleState, caller, payloadData)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		messageIDs = append(messageIDs, messageID)
+	}
+
+	packed, err := PackSendWarpMessagesOutput(messageIDs)
+	if err != nil {
+		return nil, remainingGas, err
+	}
 	return packed, remainingGas, nil
 }
 
+// newUnsignedWarpMessage returns the unsigned warp message on [networkID] that carries [message]
+// as an AddressedCall payload.
+func newUnsignedWarpMessage(networkID uint32, message *WarpMessage) (*warp.UnsignedMessage, error) {
+	addressedPayload, err := payload.NewAddressedCall(
+		message.OriginSenderAddress.Bytes(),
+		message.Payload,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return warp.NewUnsignedMessage(
+		networkID,
+		ids.ID(message.SourceChainID),
+		addressedPayload.Bytes(),
+	)
+}
+
+// ComputeWarpMessageID returns the ID of the unsigned warp message on [networkID] that carries [message].
+// This is the ID returned by sendWarpMessage and emitted in the SendWarpMessage log, so the sender and
+// any receiver of a message, as returned by getVerifiedWarpMessage, derive the same ID.
+func ComputeWarpMessageID(networkID uint32, message *WarpMessage) (common.Hash, error) {
+	unsignedMessage, err := newUnsignedWarpMessage(networkID, message)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.Hash(unsignedMessage.ID()), nil
+}
+
 // PackSendWarpMessageEvent packs the given arguments into SendWarpMessage events including topics and data.
 func PackSendWarpMessageEvent(sourceAddress common.Address, unsignedMessageID common.Hash, unsignedMessageBytes []byte) ([]common.Hash, []byte, error) {
 	return WarpABI.PackEvent("SendWarpMessage", sourceAddress, unsignedMessageID, unsignedMessageBytes)
@@ -311,23 +1386,171 @@ func UnpackSendWarpEventDataToMessage(data []byte) (*warp.UnsignedMessage, error
 	return warp.ParseUnsignedMessage(event.Message)
 }
 
+// DecodeSendWarpMessageLog decodes the WarpMessage sent in [log], which must be a SendWarpMessage log
+// emitted by the warp precompile, such as a log returned by a filter on its address and event ID.
+// The sender and message ID topics of [log] must match the decoded message, so that a log cannot
+// claim a different sender or message than it carries.
+func DecodeSendWarpMessageLog(log types.Log) (*WarpMessage, error) {
+	eventID := WarpABI.Events["SendWarpMessage"].ID
+	if log.Address != ContractAddress || len(log.Topics) != 3 || log.Topics[0] != eventID {
+		return nil, fmt.Errorf("%w: log of %s with %d topics", ErrInvalidSendWarpMessageLog, log.Address, len(log.Topics))
+	}
+	unsignedMessage, err := UnpackSendWarpEventDataToMessage(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSendWarpMessageLog, err)
+	}
+	message, err := parseUnsignedWarpMessage(unsignedMessage)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSendWarpMessageLog, err)
+	}
+	if sender := common.BytesToAddress(log.Topics[1].Bytes()); sender != message.OriginSenderAddress {
+		return nil, fmt.Errorf("%w: sender topic %s does not match sender %s", ErrInvalidSendWarpMessageLog, sender, message.OriginSenderAddress)
+	}
+	if messageID := common.Hash(unsignedMessage.ID()); log.Topics[2] != messageID {
+		return nil, fmt.Errorf("%w: message ID topic %s does not match message ID %s", ErrInvalidSendWarpMessageLog, log.Topics[2], messageID)
+	}
+	return &message, nil
+}
+
+// warpMethod is an entry of the dispatch table of the warp precompile.
+type warpMethod struct {
+	name string
+	run  contract.RunStatefulPrecompileFunc
+	// activator is nil if the method is always active.
+	activator contract.ActivationFunc
+	// gasCosts returns the base and per byte gas costs of the method under [gasCosts], which may be nil.
+	gasCosts func(gasCosts *GasCosts) (baseGas uint64, perByteGas uint64)
+}
+
+// warpMethods is the dispatch table of the warp precompile.
+var warpMethods = []warpMethod{
+	{
+		name:     "getBlockchainID",
+		run:      getBlockchainID,
+		gasCosts: getBlockchainIDGasCosts,
+	},
+	{
+		name:     "getVerifiedWarpBlockHash",
+		run:      getVerifiedWarpBlockHash,
+		gasCosts: verifiedWarpMessageGasCosts,
+	},
+	{
+		name:     "getVerifiedWarpMessage",
+		run:      getVerifiedWarpMessage,
+		gasCosts: verifiedWarpMessageGasCosts,
+	},
+	// getVerifiedWarpMessageRaw is only activated once enabled in the config, so that
+	// enabling it does not change the execution of existing chains.
+	{
+		name:      "getVerifiedWarpMessageRaw",
+		run:       getVerifiedWarpMessageRaw,
+		activator: rawMessagesFlag.activated,
+		gasCosts:  verifiedWarpMessageGasCosts,
+	},
+	// getVerifiedSequencedWarpMessage is likewise only activated once enabled in the config.
+	{
+		name:      "getVerifiedSequencedWarpMessage",
+		run:       getVerifiedSequencedWarpMessage,
+		activator: sequenceOrderingFlag.activated,
+		gasCosts:  verifiedSequencedWarpMessageGasCosts,
+	},
+	// estimateVerifiedWarpMessageGas is likewise only activated once enabled in the config.
+	{
+		name:      "estimateVerifiedWarpMessageGas",
+		run:       estimateVerifiedWarpMessageGas,
+		activator: gasEstimatesFlag.activated,
+		gasCosts:  estimateVerifiedWarpMessageGasCosts,
+	},
+	// getSentWarpMessage is likewise only activated once enabled in the config.
+	{
+		name:      "getSentWarpMessage",
+		run:       getSentWarpMessage,
+		activator: sentMessagesFlag.activated,
+		gasCosts:  getSentWarpMessageGasCosts,
+	},
+	// getWarpFormatVersion is likewise only activated once enabled in the config.
+	{
+		name:      "getWarpFormatVersion",
+		run:       getWarpFormatVersion,
+		activator: formatVersionFlag.activated,
+		gasCosts:  getWarpFormatVersionGasCosts,
+	},
+	// hasWarpPredicates is likewise only activated once enabled in the config.
+	{
+		name:      "hasWarpPredicates",
+		run:       hasWarpPredicates,
+		activator: warpPredicatesFlag.activated,
+		gasCosts:  hasWarpPredicatesGasCosts,
+	},
+	{
+		name:     "sendWarpMessage",
+		run:      sendWarpMessage,
+		gasCosts: sendWarpMessageGasCosts,
+	},
+	// sendWarpMessageWithIndex is likewise only activated once sent messages are enabled in the config.
+	{
+		name:      "sendWarpMessageWithIndex",
+		run:       sendWarpMessageWithIndex,
+		activator: sentMessagesFlag.activated,
+		gasCosts:  sendWarpMessageGasCosts,
+	},
+	// sendWarpMessageMulti is likewise only activated once enabled in the config.
+	{
+		name:      "sendWarpMessageMulti",
+		run:       sendWarpMessageMulti,
+		activator: multiDestinationMessagesFlag.activated,
+		gasCosts:  sendWarpMessageGasCosts,
+	},
+	// sendWarpMessages is likewise only activated once enabled in the config.
+	{
+		name:      "sendWarpMessages",
+		run:       sendWarpMessages,
+		activator: isBatchMessagesActivated,
+		gasCosts:  sendWarpMessageGasCosts,
+	},
+}
+
+// checkWarpMethods returns an error unless the methods of [warpABI] are exactly the methods of [methods],
+// so that no method of the ABI is left without a handler and no handler is left unreachable.
+func checkWarpMethods(warpABI abi.ABI, methods []warpMethod) error {
+	handled := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		if _, ok := warpABI.Methods[method.name]; !ok {
+			return fmt.Errorf("given method (%s) does not exist in the ABI", method.name)
+		}
+		if handled[method.name] {
+			return fmt.Errorf("given method (%s) has more than one handler", method.name)
+		}
+		handled[method.name] = true
+	}
+	var unhandled []string
+	for name := range warpABI.Methods {
+		if !handled[name] {
+			unhandled = append(unhandled, name)
+		}
+	}
+	if len(unhandled) > 0 {
+		slices.Sort(unhandled)
+		return fmt.Errorf("methods %v of the ABI have no handler in warpMethods", unhandled)
+	}
+	return nil
+}
+
 // createWarpPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// It panics unless every method of WarpABI has exactly one handler in warpMethods.
 func createWarpPrecompile() contract.StatefulPrecompiledContract {
-	var functions []*contract.StatefulPrecompileFunction
-
-	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
-		"getBlockchainID":          getBlockchainID,
-		"getVerifiedWarpBlockHash": getVerifiedWarpBlockHash,
-		"getVerifiedWarpMessage":   getVerifiedWarpMessage,
-		"sendWarpMessage":          sendWarpMessage,
+	if err := checkWarpMethods(WarpABI, warpMethods); err != nil {
+		panic(err)
 	}
 
-	for name, function := range abiFunctionMap {
-		method, ok := WarpABI.Methods[name]
-		if !ok {
-			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+	var functions []*contract.StatefulPrecompileFunction
+	for _, warpMethod := range warpMethods {
+		method := WarpABI.Methods[warpMethod.name]
+		if warpMethod.activator == nil {
+			functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, warpMethod.run))
+		} else {
+			functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, warpMethod.run, warpMethod.activator))
 		}
-		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
 	}
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
@@ -4,8 +4,10 @@
 package warp
 
 import (
+	"fmt"
 	"math"
 	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -15,7 +17,10 @@ import (
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/precompile/testutils"
 	"github.com/ava-labs/subnet-evm/predicate"
 	"github.com/ava-labs/subnet-evm/utils"
@@ -77,6 +82,88 @@ func TestGetBlockchainID(t *testing.T) {
 			ReadOnly:    false,
 			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
 		},
+		"getBlockchainID overridden gas cost": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetBlockchainID()
+				require.NoError(t, err)
+
+				return input
+			},
+			Config: &Config{
+				GasCosts: &GasCosts{GetBlockchainID: utils.NewUint64(GetBlockchainIDGasCost + 10)},
+			},
+			SuppliedGas: GetBlockchainIDGasCost + 10,
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				expectedOutput, err := PackGetBlockchainIDOutput(common.Hash(blockchainID))
+				require.NoError(t, err)
+
+				return expectedOutput
+			}(),
+		},
+		"getBlockchainID overridden gas cost insufficient gas": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetBlockchainID()
+				require.NoError(t, err)
+
+				return input
+			},
+			Config: &Config{
+				GasCosts: &GasCosts{GetBlockchainID: utils.NewUint64(GetBlockchainIDGasCost + 10)},
+			},
+			SuppliedGas: GetBlockchainIDGasCost + 9,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestGetWarpFormatVersion(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+	enabledConfig := &Config{
+		Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		FormatVersionEnabled: true,
+	}
+	getFormatVersion, err := PackGetWarpFormatVersion()
+	require.NoError(t, err)
+	expectedOutput, err := PackGetWarpFormatVersionOutput(avalancheWarp.CodecVersion)
+	require.NoError(t, err)
+
+	tests := map[string]testutils.PrecompileTest{
+		"getWarpFormatVersion success": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return getFormatVersion },
+			SuppliedGas: GetWarpFormatVersionGasCost,
+			ReadOnly:    false,
+			ExpectedRes: expectedOutput,
+		},
+		"getWarpFormatVersion readOnly": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return getFormatVersion },
+			SuppliedGas: GetWarpFormatVersionGasCost,
+			ReadOnly:    true,
+			ExpectedRes: expectedOutput,
+		},
+		"getWarpFormatVersion insufficient gas": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return getFormatVersion },
+			SuppliedGas: GetWarpFormatVersionGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"getWarpFormatVersion not activated": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return getFormatVersion },
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
 	}
 
 	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
@@ -172,134 +259,650 @@ func TestSendWarpMessage(t *testing.T) {
 	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
 }
 
-func TestGetVerifiedWarpMessage(t *testing.T) {
-	networkID := uint32(54321)
-	callerAddr := common.HexToAddress("0x0123")
-	sourceAddress := common.HexToAddress("0x456789")
-	sourceChainID := ids.GenerateTestID()
-	packagedPayloadBytes := []byte("mcsorley")
-	addressedPayload, err := payload.NewAddressedCall(
-		sourceAddress.Bytes(),
-		packagedPayloadBytes,
+func TestSendWarpMessageSenderAllowList(t *testing.T) {
+	var (
+		enabledAddr = common.HexToAddress("0x0456")
+		noRoleAddr  = common.HexToAddress("0x0789")
 	)
+	config := &Config{
+		Upgrade: precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		SenderAllowList: &allowlist.AllowListConfig{
+			EnabledAddresses: []common.Address{enabledAddr},
+		},
+	}
+	snowCtx := utils.TestSnowContext()
+	sendWarpMessagePayload := agoUtils.RandomBytes(100)
+	sendWarpMessageInput, err := PackSendWarpMessage(sendWarpMessagePayload)
 	require.NoError(t, err)
-	unsignedWarpMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
-	require.NoError(t, err)
-	warpMessage, err := avalancheWarp.NewMessage(unsignedWarpMsg, &avalancheWarp.BitSetSignature{}) // Create message with empty signature for testing
+	sendWarpMessageGas := SendWarpMessageGasCost + uint64(len(sendWarpMessageInput[4:])*int(SendWarpMessageGasCostPerByte)) + allowlist.ReadAllowListGasCost
+
+	expectedOutput := func(caller common.Address) []byte {
+		addressedPayload, err := payload.NewAddressedCall(caller.Bytes(), sendWarpMessagePayload)
+		require.NoError(t, err)
+		unsignedWarpMessage, err := warp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, addressedPayload.Bytes())
+		require.NoError(t, err)
+		return common.Hash(unsignedWarpMessage.ID()).Bytes()
+	}
+
+	requireLogs := func(numLogs int) func(t testing.TB, state contract.StateDB) {
+		return func(t testing.TB, state contract.StateDB) {
+			logsTopics, _ := state.GetLogData()
+			require.Len(t, logsTopics, numLogs)
+		}
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"enabled can send warp message": {
+			Caller:      enabledAddr,
+			Config:      config,
+			InputFn:     func(t testing.TB) []byte { return sendWarpMessageInput },
+			SuppliedGas: sendWarpMessageGas,
+			ReadOnly:    false,
+			ExpectedRes: expectedOutput(enabledAddr),
+			AfterHook:   requireLogs(1),
+		},
+		"no role cannot send warp message": {
+			Caller:      noRoleAddr,
+			Config:      config,
+			InputFn:     func(t testing.TB) []byte { return sendWarpMessageInput },
+			SuppliedGas: sendWarpMessageGas,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSendWarpMessage.Error(),
+			AfterHook:   requireLogs(0),
+		},
+		"insufficient gas for allow list read": {
+			Caller:      enabledAddr,
+			Config:      config,
+			InputFn:     func(t testing.TB) []byte { return sendWarpMessageInput },
+			SuppliedGas: sendWarpMessageGas - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestSendWarpMessageMulti(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+
+	defaultSnowCtx := utils.TestSnowContext()
+	blockchainID := defaultSnowCtx.ChainID
+	sendPayload := agoUtils.RandomBytes(100)
+	destinationChainIDs := []common.Hash{common.Hash(blockchainID), {2}}
+	destinationAddress := common.Hash{3}
+
+	sendMultiInput, err := PackSendWarpMessageMulti(SendWarpMessageMultiInput{
+		DestinationChainIDs: destinationChainIDs,
+		DestinationAddress:  destinationAddress,
+		Payload:             sendPayload,
+	})
 	require.NoError(t, err)
-	warpMessagePredicateBytes := predicate.PackPredicate(warpMessage.Bytes())
-	getVerifiedWarpMsg, err := PackGetVerifiedWarpMessage(0)
+	noDestinationsInput, err := PackSendWarpMessageMulti(SendWarpMessageMultiInput{
+		DestinationChainIDs: []common.Hash{},
+		DestinationAddress:  destinationAddress,
+		Payload:             sendPayload,
+	})
 	require.NoError(t, err)
-	noFailures := set.NewBits().Bytes()
-	require.Len(t, noFailures, 0)
+
+	expectedMessages := make([]*warp.UnsignedMessage, 0, len(destinationChainIDs))
+	expectedIDs := make([]common.Hash, 0, len(destinationChainIDs))
+	for _, destinationChainID := range destinationChainIDs {
+		payloadData, err := PackMultiDestinationPayload(MultiDestinationPayload{
+			DestinationChainID: destinationChainID,
+			DestinationAddress: destinationAddress,
+			Payload:            sendPayload,
+		})
+		require.NoError(t, err)
+		addressedPayload, err := payload.NewAddressedCall(callerAddr.Bytes(), payloadData)
+		require.NoError(t, err)
+		unsignedMessage, err := warp.NewUnsignedMessage(defaultSnowCtx.NetworkID, blockchainID, addressedPayload.Bytes())
+		require.NoError(t, err)
+		expectedMessages = append(expectedMessages, unsignedMessage)
+		expectedIDs = append(expectedIDs, common.Hash(unsignedMessage.ID()))
+	}
+
+	enabledConfig := &Config{
+		Upgrade:                         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		MultiDestinationMessagesEnabled: true,
+	}
+	// The first destination chain is this chain, which is always allowed.
+	allowingConfig := &Config{
+		Upgrade:                         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		MultiDestinationMessagesEnabled: true,
+		DestinationChainIDs:             []common.Hash{destinationChainIDs[1]},
+	}
+	rejectingConfig := &Config{
+		Upgrade:                         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		MultiDestinationMessagesEnabled: true,
+		DestinationChainIDs:             []common.Hash{{9}},
+	}
+	inputGas := SendWarpMessageGasCost + uint64(len(sendMultiInput[4:]))*SendWarpMessageGasCostPerByte
+	sendMultiGas := inputGas + SendWarpMessageGasCost + uint64(len(sendPayload))*SendWarpMessageGasCostPerByte
+	checkGas := uint64(len(destinationChainIDs)) * CheckDestinationChainGasCost
 
 	tests := map[string]testutils.PrecompileTest{
-		"get message success": {
-			Caller:  callerAddr,
-			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
-			BeforeHook: func(t testing.TB, state contract.StateDB) {
-				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
-			},
-			SetupBlockContext: func(mbc *contract.MockBlockContext) {
-				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
-			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
+		"send multi success": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMultiInput },
+			SuppliedGas: sendMultiGas,
 			ReadOnly:    false,
 			ExpectedRes: func() []byte {
-				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
-					Message: WarpMessage{
-						SourceChainID:       common.Hash(sourceChainID),
-						OriginSenderAddress: sourceAddress,
-						Payload:             packagedPayloadBytes,
-					},
-					Valid: true,
-				})
+				res, err := PackSendWarpMessageMultiOutput(expectedIDs)
 				if err != nil {
 					panic(err)
 				}
 				return res
 			}(),
-		},
-		"get message out of bounds non-zero index": {
-			Caller: callerAddr,
-			InputFn: func(t testing.TB) []byte {
-				input, err := PackGetVerifiedWarpMessage(1)
-				require.NoError(t, err)
-				return input
-			},
-			BeforeHook: func(t testing.TB, state contract.StateDB) {
-				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
-			},
-			SetupBlockContext: func(mbc *contract.MockBlockContext) {
-				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				logsTopics, logsData := state.GetLogData()
+				require.Len(t, logsTopics, len(destinationChainIDs))
+				require.Len(t, logsData, len(destinationChainIDs))
+				for i, destinationChainID := range destinationChainIDs {
+					require.Equal(t, []common.Hash{WarpABI.Events["SendWarpMessage"].ID, callerAddr.Hash(), expectedIDs[i]}, logsTopics[i])
+
+					unsignedMessage, err := UnpackSendWarpEventDataToMessage(logsData[i])
+					require.NoError(t, err)
+					require.Equal(t, expectedMessages[i].Bytes(), unsignedMessage.Bytes())
+					addressedPayload, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+					require.NoError(t, err)
+					multiPayload, err := UnpackMultiDestinationPayload(addressedPayload.Payload)
+					require.NoError(t, err)
+					require.Equal(t, MultiDestinationPayload{
+						DestinationChainID: destinationChainID,
+						DestinationAddress: destinationAddress,
+						Payload:            sendPayload,
+					}, multiPayload)
+				}
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+		},
+		"send multi insufficient gas for additional destinations": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMultiInput },
+			SuppliedGas: sendMultiGas - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"send multi readOnly": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMultiInput },
+			SuppliedGas: inputGas,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"send multi no destinations": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return noDestinationsInput },
+			SuppliedGas: SendWarpMessageGasCost + uint64(len(noDestinationsInput[4:]))*SendWarpMessageGasCostPerByte,
+			ReadOnly:    false,
+			ExpectedErr: errNoDestinations.Error(),
+		},
+		"send multi invalid input": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMultiInput[:4] },
+			SuppliedGas: SendWarpMessageGasCost,
+			ReadOnly:    false,
+			ExpectedErr: errInvalidSendMultiInput.Error(),
+		},
+		"send multi allowed destinations": {
+			Caller:      callerAddr,
+			Config:      allowingConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMultiInput },
+			SuppliedGas: sendMultiGas + checkGas,
 			ReadOnly:    false,
 			ExpectedRes: func() []byte {
-				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{Valid: false})
+				res, err := PackSendWarpMessageMultiOutput(expectedIDs)
 				if err != nil {
 					panic(err)
 				}
 				return res
 			}(),
 		},
-		"get message success non-zero index": {
-			Caller: callerAddr,
-			InputFn: func(t testing.TB) []byte {
-				input, err := PackGetVerifiedWarpMessage(1)
-				require.NoError(t, err)
-				return input
-			},
+		"send multi unknown destination": {
+			Caller:      callerAddr,
+			Config:      rejectingConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMultiInput },
+			SuppliedGas: sendMultiGas + checkGas,
+			ReadOnly:    false,
+			ExpectedErr: errUnknownDestination.Error(),
+		},
+		"send multi insufficient gas for destination check": {
+			Caller:      callerAddr,
+			Config:      allowingConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMultiInput },
+			SuppliedGas: sendMultiGas + checkGas - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"send multi not activated": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return sendMultiInput },
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"send multi disabled by upgrade": {
+			Caller:  callerAddr,
+			Config:  NewDefaultConfig(utils.NewUint64(0)),
+			InputFn: func(t testing.TB) []byte { return sendMultiInput },
 			BeforeHook: func(t testing.TB, state contract.StateDB) {
-				state.SetPredicateStorageSlots(ContractAddress, [][]byte{{}, warpMessagePredicateBytes})
-			},
-			SetupBlockContext: func(mbc *contract.MockBlockContext) {
-				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+				multiDestinationMessagesFlag.set(state, true)
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestSendWarpMessages(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+
+	defaultSnowCtx := utils.TestSnowContext()
+	blockchainID := defaultSnowCtx.ChainID
+	messages := []SendWarpMessageInput{
+		{
+			DestinationChainID: common.Hash{1},
+			DestinationAddress: common.Hash{3},
+			Payload:            agoUtils.RandomBytes(100),
+		},
+		{
+			DestinationChainID: common.Hash{2},
+			DestinationAddress: common.Hash{4},
+			Payload:            agoUtils.RandomBytes(50),
+		},
+	}
+
+	sendMessagesInput, err := PackSendWarpMessages(messages)
+	require.NoError(t, err)
+	unpacked, err := UnpackSendWarpMessagesInput(sendMessagesInput[4:])
+	require.NoError(t, err)
+	require.Equal(t, messages, unpacked.Messages)
+	noMessagesInput, err := PackSendWarpMessages([]SendWarpMessageInput{})
+	require.NoError(t, err)
+
+	expectedMessages := make([]*warp.UnsignedMessage, 0, len(messages))
+	expectedIDs := make([]common.Hash, 0, len(messages))
+	for _, message := range messages {
+		payloadData, err := PackMultiDestinationPayload(MultiDestinationPayload(message))
+		require.NoError(t, err)
+		addressedPayload, err := payload.NewAddressedCall(callerAddr.Bytes(), payloadData)
+		require.NoError(t, err)
+		unsignedMessage, err := warp.NewUnsignedMessage(defaultSnowCtx.NetworkID, blockchainID, addressedPayload.Bytes())
+		require.NoError(t, err)
+		expectedMessages = append(expectedMessages, unsignedMessage)
+		expectedIDs = append(expectedIDs, common.Hash(unsignedMessage.ID()))
+	}
+
+	enabledConfig := &Config{
+		Upgrade:          precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		MaxBatchMessages: uint64(len(messages)),
+	}
+	allowingConfig := &Config{
+		Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		MaxBatchMessages:    uint64(len(messages)),
+		DestinationChainIDs: []common.Hash{messages[0].DestinationChainID, messages[1].DestinationChainID},
+	}
+	rejectingConfig := &Config{
+		Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		MaxBatchMessages:    uint64(len(messages)),
+		DestinationChainIDs: []common.Hash{messages[0].DestinationChainID},
+	}
+	inputGas := SendWarpMessageGasCost + uint64(len(sendMessagesInput[4:]))*SendWarpMessageGasCostPerByte
+	sendMessagesGas := inputGas + uint64(len(messages)-1)*SendWarpMessageGasCost
+	checkGas := uint64(len(messages)) * CheckDestinationChainGasCost
+
+	tests := map[string]testutils.PrecompileTest{
+		"send messages success": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMessagesInput },
+			SuppliedGas: sendMessagesGas,
 			ReadOnly:    false,
 			ExpectedRes: func() []byte {
-				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
-					Message: WarpMessage{
-						SourceChainID:       common.Hash(sourceChainID),
-						OriginSenderAddress: sourceAddress,
-						Payload:             packagedPayloadBytes,
-					},
-					Valid: true,
-				})
+				res, err := PackSendWarpMessagesOutput(expectedIDs)
 				if err != nil {
 					panic(err)
 				}
 				return res
 			}(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				logsTopics, logsData := state.GetLogData()
+				require.Len(t, logsTopics, len(messages))
+				require.Len(t, logsData, len(messages))
+				for i, message := range messages {
+					require.Equal(t, []common.Hash{WarpABI.Events["SendWarpMessage"].ID, callerAddr.Hash(), expectedIDs[i]}, logsTopics[i])
+
+					unsignedMessage, err := UnpackSendWarpEventDataToMessage(logsData[i])
+					require.NoError(t, err)
+					require.Equal(t, expectedMessages[i].Bytes(), unsignedMessage.Bytes())
+					addressedPayload, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+					require.NoError(t, err)
+					multiPayload, err := UnpackMultiDestinationPayload(addressedPayload.Payload)
+					require.NoError(t, err)
+					require.Equal(t, MultiDestinationPayload(message), multiPayload)
+				}
+			},
 		},
-		"get message failure non-zero index": {
+		"send messages insufficient gas for additional messages": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMessagesInput },
+			SuppliedGas: sendMessagesGas - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"send messages readOnly": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMessagesInput },
+			SuppliedGas: inputGas,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"send messages no messages": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return noMessagesInput },
+			SuppliedGas: SendWarpMessageGasCost + uint64(len(noMessagesInput[4:]))*SendWarpMessageGasCostPerByte,
+			ReadOnly:    false,
+			ExpectedErr: errNoMessages.Error(),
+		},
+		"send messages too many messages": {
 			Caller: callerAddr,
-			InputFn: func(t testing.TB) []byte {
-				input, err := PackGetVerifiedWarpMessage(1)
-				require.NoError(t, err)
-				return input
-			},
-			BeforeHook: func(t testing.TB, state contract.StateDB) {
-				state.SetPredicateStorageSlots(ContractAddress, [][]byte{{}, warpMessagePredicateBytes})
+			Config: &Config{
+				Upgrade:          precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+				MaxBatchMessages: uint64(len(messages) - 1),
 			},
-			SetupBlockContext: func(mbc *contract.MockBlockContext) {
-				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0, 1).Bytes())
-			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			InputFn:     func(t testing.TB) []byte { return sendMessagesInput },
+			SuppliedGas: inputGas,
+			ReadOnly:    false,
+			ExpectedErr: errTooManyMessages.Error(),
+		},
+		"send messages invalid input": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMessagesInput[:4] },
+			SuppliedGas: SendWarpMessageGasCost,
+			ReadOnly:    false,
+			ExpectedErr: errInvalidSendBatchInput.Error(),
+		},
+		"send messages allowed destinations": {
+			Caller:      callerAddr,
+			Config:      allowingConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMessagesInput },
+			SuppliedGas: sendMessagesGas + checkGas,
 			ReadOnly:    false,
 			ExpectedRes: func() []byte {
-				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{Valid: false})
+				res, err := PackSendWarpMessagesOutput(expectedIDs)
 				if err != nil {
 					panic(err)
 				}
 				return res
 			}(),
 		},
-		"get non-existent message": {
-			Caller:  callerAddr,
-			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
-			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+		"send messages unknown destination": {
+			Caller:      callerAddr,
+			Config:      rejectingConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMessagesInput },
+			SuppliedGas: sendMessagesGas + checkGas,
+			ReadOnly:    false,
+			ExpectedErr: errUnknownDestination.Error(),
+		},
+		"send messages insufficient gas for destination check": {
+			Caller:      callerAddr,
+			Config:      allowingConfig,
+			InputFn:     func(t testing.TB) []byte { return sendMessagesInput },
+			SuppliedGas: sendMessagesGas + checkGas - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"send messages not activated": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return sendMessagesInput },
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"send messages disabled by upgrade": {
+			Caller:  callerAddr,
+			Config:  NewDefaultConfig(utils.NewUint64(0)),
+			InputFn: func(t testing.TB) []byte { return sendMessagesInput },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setMaxBatchMessages(state, uint64(len(messages)))
+			},
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestSendWarpMessageFee(t *testing.T) {
+	var (
+		callerAddr    = common.HexToAddress("0x0123")
+		recipientAddr = common.HexToAddress("0x0456")
+		fee           = big.NewInt(1_000)
+	)
+	defaultSnowCtx := utils.TestSnowContext()
+	sendPayload := agoUtils.RandomBytes(100)
+	sendInput, err := PackSendWarpMessage(sendPayload)
+	require.NoError(t, err)
+	addressedPayload, err := payload.NewAddressedCall(callerAddr.Bytes(), sendPayload)
+	require.NoError(t, err)
+	unsignedMessage, err := warp.NewUnsignedMessage(defaultSnowCtx.NetworkID, defaultSnowCtx.ChainID, addressedPayload.Bytes())
+	require.NoError(t, err)
+	destinationChainIDs := []common.Hash{{1}, {2}}
+	sendMultiInput, err := PackSendWarpMessageMulti(SendWarpMessageMultiInput{
+		DestinationChainIDs: destinationChainIDs,
+		DestinationAddress:  common.Hash{3},
+		Payload:             sendPayload,
+	})
+	require.NoError(t, err)
+	multiMessageIDs := make([]common.Hash, 0, len(destinationChainIDs))
+	for _, destinationChainID := range destinationChainIDs {
+		payloadData, err := PackMultiDestinationPayload(MultiDestinationPayload{
+			DestinationChainID: destinationChainID,
+			DestinationAddress: common.Hash{3},
+			Payload:            sendPayload,
+		})
+		require.NoError(t, err)
+		multiAddressedPayload, err := payload.NewAddressedCall(callerAddr.Bytes(), payloadData)
+		require.NoError(t, err)
+		multiMessage, err := warp.NewUnsignedMessage(defaultSnowCtx.NetworkID, defaultSnowCtx.ChainID, multiAddressedPayload.Bytes())
+		require.NoError(t, err)
+		multiMessageIDs = append(multiMessageIDs, common.Hash(multiMessage.ID()))
+	}
+	sendMultiOutput, err := PackSendWarpMessageMultiOutput(multiMessageIDs)
+	require.NoError(t, err)
+
+	feeConfig := &Config{
+		Upgrade:                         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		MultiDestinationMessagesEnabled: true,
+		MessageFee:                      fee,
+		FeeRecipient:                    recipientAddr,
+	}
+	sendGas := SendWarpMessageGasCost + uint64(len(sendInput[4:]))*SendWarpMessageGasCostPerByte
+	sendMultiGas := SendWarpMessageGasCost + uint64(len(sendMultiInput[4:]))*SendWarpMessageGasCostPerByte +
+		SendWarpMessageGasCost + uint64(len(sendPayload))*SendWarpMessageGasCostPerByte
+
+	tests := map[string]testutils.PrecompileTest{
+		"send with sufficient balance": {
+			Caller: callerAddr,
+			Config: feeConfig,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.AddBalance(callerAddr, big.NewInt(1_500))
+			},
+			InputFn:     func(t testing.TB) []byte { return sendInput },
+			SuppliedGas: sendGas,
+			ReadOnly:    false,
+			ExpectedRes: common.Hash(unsignedMessage.ID()).Bytes(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, big.NewInt(500), state.GetBalance(callerAddr))
+				require.Equal(t, fee, state.GetBalance(recipientAddr))
+				logsTopics, _ := state.GetLogData()
+				require.Len(t, logsTopics, 1)
+			},
+		},
+		"send with insufficient balance": {
+			Caller: callerAddr,
+			Config: feeConfig,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.AddBalance(callerAddr, big.NewInt(999))
+			},
+			InputFn:     func(t testing.TB) []byte { return sendInput },
+			SuppliedGas: sendGas,
+			ReadOnly:    false,
+			ExpectedErr: ErrInsufficientMessageFeeBalance.Error(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, big.NewInt(999), state.GetBalance(callerAddr))
+				require.Zero(t, state.GetBalance(recipientAddr).Sign())
+				logsTopics, _ := state.GetLogData()
+				require.Empty(t, logsTopics)
+			},
+		},
+		"send multi charges fee per destination": {
+			Caller: callerAddr,
+			Config: feeConfig,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.AddBalance(callerAddr, big.NewInt(2_000))
+			},
+			InputFn:     func(t testing.TB) []byte { return sendMultiInput },
+			SuppliedGas: sendMultiGas,
+			ReadOnly:    false,
+			ExpectedRes: sendMultiOutput,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Zero(t, state.GetBalance(callerAddr).Sign())
+				require.Equal(t, big.NewInt(2_000), state.GetBalance(recipientAddr))
+			},
+		},
+		"send multi with insufficient balance for every destination": {
+			Caller: callerAddr,
+			Config: feeConfig,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.AddBalance(callerAddr, big.NewInt(1_999))
+			},
+			InputFn:     func(t testing.TB) []byte { return sendMultiInput },
+			SuppliedGas: sendMultiGas,
+			ReadOnly:    false,
+			ExpectedErr: ErrInsufficientMessageFeeBalance.Error(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, big.NewInt(1_999), state.GetBalance(callerAddr))
+			},
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestComputeWarpMessageID(t *testing.T) {
+	networkID := uint32(54321)
+	sourceAddress := common.HexToAddress("0x456789")
+	sourceChainID := ids.GenerateTestID()
+	packagedPayloadBytes := []byte("mcsorley")
+	addressedPayload, err := payload.NewAddressedCall(
+		sourceAddress.Bytes(),
+		packagedPayloadBytes,
+	)
+	require.NoError(t, err)
+	unsignedWarpMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
+	require.NoError(t, err)
+
+	messageID, err := ComputeWarpMessageID(networkID, &WarpMessage{
+		SourceChainID:       common.Hash(sourceChainID),
+		OriginSenderAddress: sourceAddress,
+		Payload:             packagedPayloadBytes,
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.Hash(unsignedWarpMsg.ID()), messageID)
+}
+
+func TestUnpackGetVerifiedWarpMessageInput(t *testing.T) {
+	packed, err := PackGetVerifiedWarpMessage(math.MaxInt32 + 1)
+	require.NoError(t, err)
+	input := packed[4:] // Omit the selector
+
+	index, err := UnpackGetVerifiedWarpMessageInput(input)
+	require.NoError(t, err)
+	require.Equal(t, uint32(math.MaxInt32+1), index)
+
+	_, err = UnpackGetVerifiedWarpMessageInput(input[:len(input)-1])
+	require.ErrorIs(t, err, errMalformedIndexInput)
+	require.NotErrorIs(t, err, errIndexOutOfRange)
+
+	oversized := new(big.Int).Add(new(big.Int).Lsh(common.Big1, 64), common.Big1)
+	_, err = UnpackGetVerifiedWarpMessageInput(common.BigToHash(oversized).Bytes())
+	require.ErrorIs(t, err, errIndexOutOfRange)
+	require.NotErrorIs(t, err, errMalformedIndexInput)
+
+	_, err = UnpackGetVerifiedWarpMessageInput(common.BigToHash(big.NewInt(math.MaxUint32 + 1)).Bytes())
+	require.ErrorIs(t, err, errIndexOutOfRange)
+}
+
+func TestGetVerifiedWarpMessage(t *testing.T) {
+	networkID := uint32(54321)
+	callerAddr := common.HexToAddress("0x0123")
+	sourceAddress := common.HexToAddress("0x456789")
+	sourceChainID := ids.GenerateTestID()
+	packagedPayloadBytes := []byte("mcsorley")
+	addressedPayload, err := payload.NewAddressedCall(
+		sourceAddress.Bytes(),
+		packagedPayloadBytes,
+	)
+	require.NoError(t, err)
+	unsignedWarpMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
+	require.NoError(t, err)
+	warpMessage, err := avalancheWarp.NewMessage(unsignedWarpMsg, &avalancheWarp.BitSetSignature{}) // Create message with empty signature for testing
+	require.NoError(t, err)
+	warpMessagePredicateBytes := predicate.PackPredicate(warpMessage.Bytes())
+	getVerifiedWarpMsg, err := PackGetVerifiedWarpMessage(0)
+	require.NoError(t, err)
+	noFailures := set.NewBits().Bytes()
+	require.Len(t, noFailures, 0)
+
+	tests := map[string]testutils.PrecompileTest{
+		"get message success": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
+					Message: WarpMessage{
+						SourceChainID:       common.Hash(sourceChainID),
+						OriginSenderAddress: sourceAddress,
+						Payload:             packagedPayloadBytes,
+					},
+					Valid: true,
+				})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get message out of bounds non-zero index": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetVerifiedWarpMessage(1)
+				require.NoError(t, err)
+				return input
+			},
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
 				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
 			},
 			SuppliedGas: GetVerifiedWarpMessageBaseCost,
@@ -312,20 +915,454 @@ func TestGetVerifiedWarpMessage(t *testing.T) {
 				return res
 			}(),
 		},
-		"get message success readOnly": {
+		"get message success non-zero index": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetVerifiedWarpMessage(1)
+				require.NoError(t, err)
+				return input
+			},
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{{}, warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
+					Message: WarpMessage{
+						SourceChainID:       common.Hash(sourceChainID),
+						OriginSenderAddress: sourceAddress,
+						Payload:             packagedPayloadBytes,
+					},
+					Valid: true,
+				})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get message failure non-zero index": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetVerifiedWarpMessage(1)
+				require.NoError(t, err)
+				return input
+			},
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{{}, warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0, 1).Bytes())
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{Valid: false})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get non-existent message": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{Valid: false})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get message success readOnly": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
+					Message: WarpMessage{
+						SourceChainID:       common.Hash(sourceChainID),
+						OriginSenderAddress: sourceAddress,
+						Payload:             packagedPayloadBytes,
+					},
+					Valid: true,
+				})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get non-existent message readOnly": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{Valid: false})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get message out of gas for base cost": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"get message out of gas": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)) - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"get message invalid predicate packing": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessage.Bytes()})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessage.Bytes())),
+			ReadOnly:    false,
+			ExpectedErr: errInvalidPredicateBytes.Error(),
+		},
+		"get message invalid warp message": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{predicate.PackPredicate([]byte{1, 2, 3})})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(32),
+			ReadOnly:    false,
+			ExpectedErr: errInvalidWarpMsg.Error(),
+		},
+		"get message invalid addressed payload": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				unsignedMessage, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, []byte{1, 2, 3}) // Invalid addressed payload
+				require.NoError(t, err)
+				warpMessage, err := avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{})
+				require.NoError(t, err)
+
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{predicate.PackPredicate(warpMessage.Bytes())})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(160),
+			ReadOnly:    false,
+			ExpectedErr: errInvalidAddressedPayload.Error(),
+		},
+		"get message index invalid uint32": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				return append(WarpABI.Methods["getVerifiedWarpMessage"].ID, new(big.Int).SetInt64(math.MaxInt64).Bytes()...)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedErr: errMalformedIndexInput.Error(),
+		},
+		"get message index invalid int32": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				res, err := PackGetVerifiedWarpMessage(math.MaxInt32 + 1)
+				require.NoError(t, err)
+				return res
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedErr: errIndexOutOfRange.Error(),
+		},
+		"get message index larger than uint64": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				index := new(big.Int).Add(new(big.Int).Lsh(common.Big1, 64), common.Big1)
+				return append(WarpABI.Methods["getVerifiedWarpMessage"].ID, common.BigToHash(index).Bytes()...)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedErr: errIndexOutOfRange.Error(),
+		},
+		"get message invalid index input bytes": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				res, err := PackGetVerifiedWarpMessage(1)
+				require.NoError(t, err)
+				return res[:len(res)-2]
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedErr: errMalformedIndexInput.Error(),
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestGetVerifiedWarpMessageRaw(t *testing.T) {
+	networkID := uint32(54321)
+	callerAddr := common.HexToAddress("0x0123")
+	sourceAddress := common.HexToAddress("0x456789")
+	sourceChainID := ids.GenerateTestID()
+	packagedPayloadBytes := []byte("mcsorley")
+	addressedPayload, err := payload.NewAddressedCall(
+		sourceAddress.Bytes(),
+		packagedPayloadBytes,
+	)
+	require.NoError(t, err)
+	unsignedWarpMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
+	require.NoError(t, err)
+	warpMessage, err := avalancheWarp.NewMessage(unsignedWarpMsg, &avalancheWarp.BitSetSignature{}) // Create message with empty signature for testing
+	require.NoError(t, err)
+	warpMessagePredicateBytes := predicate.PackPredicate(warpMessage.Bytes())
+	getVerifiedWarpMsgRaw, err := PackGetVerifiedWarpMessageRaw(0)
+	require.NoError(t, err)
+	noFailures := set.NewBits().Bytes()
+	enabledConfig := &Config{
+		Upgrade:            precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		RawMessagesEnabled: true,
+	}
+	getMessageRawGas := GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)) +
+		GetVerifiedWarpMessageRawGasCostPerByte*uint64(len(unsignedWarpMsg.Bytes()))
+
+	tests := map[string]testutils.PrecompileTest{
+		"get raw message success": {
+			Caller:  callerAddr,
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsgRaw },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: getMessageRawGas,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpMessageRawOutput(GetVerifiedWarpMessageRawOutput{
+					Message: WarpMessage{
+						SourceChainID:       common.Hash(sourceChainID),
+						OriginSenderAddress: sourceAddress,
+						Payload:             packagedPayloadBytes,
+					},
+					UnsignedMessage: unsignedWarpMsg.Bytes(),
+					Valid:           true,
+				})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get raw non-existent message": {
+			Caller:  callerAddr,
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsgRaw },
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedWarpMessageRawOutput(GetVerifiedWarpMessageRawOutput{Valid: false})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get raw message out of gas for unsigned message bytes": {
+			Caller:  callerAddr,
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsgRaw },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: getMessageRawGas - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"get raw message not activated": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsgRaw },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"get raw message disabled by upgrade": {
+			Caller:  callerAddr,
+			Config:  NewDefaultConfig(utils.NewUint64(0)),
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsgRaw },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				rawMessagesFlag.set(state, true)
+			},
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestGetVerifiedSequencedWarpMessage(t *testing.T) {
+	networkID := uint32(54321)
+	callerAddr := common.HexToAddress("0x0123")
+	sourceAddress := common.HexToAddress("0x456789")
+	sourceChainID := ids.GenerateTestID()
+	packagedPayloadBytes := []byte("mcsorley")
+	noFailures := set.NewBits().Bytes()
+	enabledConfig := &Config{
+		Upgrade:                 precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		EnforceSequenceOrdering: true,
+	}
+	getVerifiedSequencedMsg, err := PackGetVerifiedSequencedWarpMessage(0)
+	require.NoError(t, err)
+
+	// newPredicateBytes returns the predicate of a warp message sent by [sourceAddress] with [payloadData].
+	newPredicateBytes := func(payloadData []byte) []byte {
+		addressedPayload, err := payload.NewAddressedCall(sourceAddress.Bytes(), payloadData)
+		require.NoError(t, err)
+		unsignedWarpMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
+		require.NoError(t, err)
+		warpMessage, err := avalancheWarp.NewMessage(unsignedWarpMsg, &avalancheWarp.BitSetSignature{}) // Create message with empty signature for testing
+		require.NoError(t, err)
+		return predicate.PackPredicate(warpMessage.Bytes())
+	}
+	sequencedPayload, err := PackSequencedWarpPayload(2, packagedPayloadBytes)
+	require.NoError(t, err)
+	sequencedPredicateBytes := newPredicateBytes(sequencedPayload)
+	legacyPredicateBytes := newPredicateBytes(packagedPayloadBytes)
+	getSequencedGas := func(predicateBytes []byte) uint64 {
+		return GetVerifiedSequencedWarpMessageGasCost + GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(predicateBytes))
+	}
+	invalidRes, err := PackGetVerifiedSequencedWarpMessageOutput(GetVerifiedSequencedWarpMessageOutput{Valid: false})
+	require.NoError(t, err)
+
+	tests := map[string]testutils.PrecompileTest{
+		"get sequenced message in order": {
+			Caller:  callerAddr,
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return getVerifiedSequencedMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{sequencedPredicateBytes})
+				setLastConsumedSequence(state, common.Hash(sourceChainID), sourceAddress, 1)
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: getSequencedGas(sequencedPredicateBytes),
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedSequencedWarpMessageOutput(GetVerifiedSequencedWarpMessageOutput{
+					Message: SequencedWarpMessage{
+						SourceChainID:       common.Hash(sourceChainID),
+						OriginSenderAddress: sourceAddress,
+						Sequence:            2,
+						Payload:             packagedPayloadBytes,
+					},
+					Valid: true,
+				})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, uint64(2), GetLastConsumedSequence(state, common.Hash(sourceChainID), sourceAddress))
+			},
+		},
+		"get sequenced message out of order": {
+			Caller:  callerAddr,
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return getVerifiedSequencedMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{sequencedPredicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: getSequencedGas(sequencedPredicateBytes),
+			ReadOnly:    false,
+			ExpectedRes: invalidRes,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Zero(t, GetLastConsumedSequence(state, common.Hash(sourceChainID), sourceAddress))
+			},
+		},
+		"get sequenced message already consumed": {
+			Caller:  callerAddr,
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return getVerifiedSequencedMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{sequencedPredicateBytes})
+				setLastConsumedSequence(state, common.Hash(sourceChainID), sourceAddress, 2)
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: getSequencedGas(sequencedPredicateBytes),
+			ReadOnly:    false,
+			ExpectedRes: invalidRes,
+		},
+		"get legacy message without sequence": {
 			Caller:  callerAddr,
-			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return getVerifiedSequencedMsg },
 			BeforeHook: func(t testing.TB, state contract.StateDB) {
-				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{legacyPredicateBytes})
 			},
 			SetupBlockContext: func(mbc *contract.MockBlockContext) {
 				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
-			ReadOnly:    true,
+			SuppliedGas: getSequencedGas(legacyPredicateBytes),
+			ReadOnly:    false,
 			ExpectedRes: func() []byte {
-				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
-					Message: WarpMessage{
+				res, err := PackGetVerifiedSequencedWarpMessageOutput(GetVerifiedSequencedWarpMessageOutput{
+					Message: SequencedWarpMessage{
 						SourceChainID:       common.Hash(sourceChainID),
 						OriginSenderAddress: sourceAddress,
 						Payload:             packagedPayloadBytes,
@@ -337,121 +1374,434 @@ func TestGetVerifiedWarpMessage(t *testing.T) {
 				}
 				return res
 			}(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Zero(t, GetLastConsumedSequence(state, common.Hash(sourceChainID), sourceAddress))
+			},
 		},
-		"get non-existent message readOnly": {
+		"get sequenced message readOnly": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return getVerifiedSequencedMsg },
+			SuppliedGas: GetVerifiedSequencedWarpMessageGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"get sequenced message insufficient gas": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return getVerifiedSequencedMsg },
+			SuppliedGas: GetVerifiedSequencedWarpMessageGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"get sequenced message not activated": {
 			Caller:  callerAddr,
-			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
-			SetupBlockContext: func(mbc *contract.MockBlockContext) {
-				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			InputFn: func(t testing.TB) []byte { return getVerifiedSequencedMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{sequencedPredicateBytes})
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost,
-			ReadOnly:    true,
-			ExpectedRes: func() []byte {
-				res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{Valid: false})
-				if err != nil {
-					panic(err)
-				}
-				return res
-			}(),
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
 		},
-		"get message out of gas for base cost": {
+		"get sequenced message disabled by upgrade": {
 			Caller:  callerAddr,
-			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			Config:  NewDefaultConfig(utils.NewUint64(0)),
+			InputFn: func(t testing.TB) []byte { return getVerifiedSequencedMsg },
 			BeforeHook: func(t testing.TB, state contract.StateDB) {
-				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+				sequenceOrderingFlag.set(state, true)
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestHasWarpPredicates(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+	enabledConfig := &Config{
+		Upgrade:               precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		WarpPredicatesEnabled: true,
+	}
+	hasPredicates, err := PackHasWarpPredicates()
+	require.NoError(t, err)
+	packHasPredicates := func(hasPredicates bool) []byte {
+		res, err := PackHasWarpPredicatesOutput(hasPredicates)
+		if err != nil {
+			panic(err)
+		}
+		return res
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"has warp predicates with warp message": {
+			Caller:  callerAddr,
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return hasPredicates },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{{1}})
+			},
+			SuppliedGas: HasWarpPredicatesGasCost,
+			ReadOnly:    true,
+			ExpectedRes: packHasPredicates(true),
+		},
+		"no warp predicates without warp message": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return hasPredicates },
+			SuppliedGas: HasWarpPredicatesGasCost,
+			ReadOnly:    false,
+			ExpectedRes: packHasPredicates(false),
+		},
+		"has warp predicates insufficient gas": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return hasPredicates },
+			SuppliedGas: HasWarpPredicatesGasCost - 1,
 			ReadOnly:    false,
 			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
 		},
-		"get message out of gas": {
+		"has warp predicates not activated": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return hasPredicates },
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestStoreDestinationChains(t *testing.T) {
+	require := require.New(t)
+	stateDB := state.NewTestStateDB(t)
+
+	storeDestinationChains(stateDB, []common.Hash{{1}, {2}})
+	require.Equal(uint64(2), getDestinationChainsCount(stateDB))
+	require.NotEqual(common.Hash{}, stateDB.GetState(ContractAddress, allowedDestinationChainKey(common.Hash{1})))
+	require.NotEqual(common.Hash{}, stateDB.GetState(ContractAddress, allowedDestinationChainKey(common.Hash{2})))
+
+	// A later upgrade replaces the destination chains rather than adding to them.
+	storeDestinationChains(stateDB, []common.Hash{{3}})
+	require.Equal(uint64(1), getDestinationChainsCount(stateDB))
+	require.Equal(common.Hash{}, stateDB.GetState(ContractAddress, allowedDestinationChainKey(common.Hash{1})))
+	require.Equal(common.Hash{}, stateDB.GetState(ContractAddress, allowedDestinationChainKey(common.Hash{2})))
+	require.Equal(common.Hash{}, stateDB.GetState(ContractAddress, destinationChainKey(1)))
+	require.NotEqual(common.Hash{}, stateDB.GetState(ContractAddress, allowedDestinationChainKey(common.Hash{3})))
+
+	storeDestinationChains(stateDB, nil)
+	require.Zero(getDestinationChainsCount(stateDB))
+	require.Equal(common.Hash{}, stateDB.GetState(ContractAddress, allowedDestinationChainKey(common.Hash{3})))
+}
+
+func TestStateFlag(t *testing.T) {
+	require := require.New(t)
+	stateDB := state.NewTestStateDB(t)
+	flag := stateFlag{key: configKey("testFlag")}
+
+	flag.configure(stateDB, false)
+	require.False(flag.enabled(stateDB))
+	flag.configure(stateDB, true)
+	require.True(flag.enabled(stateDB))
+	// A later upgrade that leaves the flag unset disables it again.
+	flag.configure(stateDB, false)
+	require.False(flag.enabled(stateDB))
+	require.Equal(common.Hash{}, stateDB.GetState(ContractAddress, flag.key))
+}
+
+func TestConfigKeysDoNotCollideWithAllowList(t *testing.T) {
+	require := require.New(t)
+	stateDB := state.NewTestStateDB(t)
+
+	// The role slot of an address is the left-padded address, so an address spelling the name of a
+	// config value must not share its slot.
+	for _, name := range []string{"rawMessagesEnabled", "maxBatchMessages", "sentMessagesEnabled", "messageFee", "destinationChainsCount"} {
+		addr := common.BytesToAddress([]byte(name))
+		allowlist.SetAllowListRole(stateDB, ContractAddress, addr, allowlist.EnabledRole)
+		require.Equal(common.Hash{}, stateDB.GetState(ContractAddress, configKey(name)), name)
+	}
+	require.False(rawMessagesFlag.enabled(stateDB))
+	require.Zero(getMaxBatchMessages(stateDB))
+
+	rawMessagesFlag.set(stateDB, true)
+	require.Equal(allowlist.EnabledRole, allowlist.GetAllowListStatus(stateDB, ContractAddress, common.BytesToAddress([]byte("rawMessagesEnabled"))))
+}
+
+func TestEstimateVerifiedWarpMessageGas(t *testing.T) {
+	networkID := uint32(54321)
+	callerAddr := common.HexToAddress("0x0123")
+	sourceAddress := common.HexToAddress("0x456789")
+	sourceChainID := ids.GenerateTestID()
+	addressedPayload, err := payload.NewAddressedCall(sourceAddress.Bytes(), []byte("mcsorley"))
+	require.NoError(t, err)
+	unsignedWarpMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
+	require.NoError(t, err)
+	warpMessage, err := avalancheWarp.NewMessage(unsignedWarpMsg, &avalancheWarp.BitSetSignature{}) // Create message with empty signature for testing
+	require.NoError(t, err)
+	warpMessagePredicateBytes := predicate.PackPredicate(warpMessage.Bytes())
+	noFailures := set.NewBits().Bytes()
+	enabledConfig := &Config{
+		Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		GasEstimatesEnabled: true,
+	}
+	estimateGas, err := PackEstimateVerifiedWarpMessageGas(0)
+	require.NoError(t, err)
+	packEstimate := func(gas uint64, valid bool) []byte {
+		res, err := PackEstimateVerifiedWarpMessageGasOutput(EstimateVerifiedWarpMessageGasOutput{Gas: gas, Valid: valid})
+		if err != nil {
+			panic(err)
+		}
+		return res
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"estimate message": {
 			Caller:  callerAddr,
-			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return estimateGas },
 			BeforeHook: func(t testing.TB, state contract.StateDB) {
 				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
 			},
 			SetupBlockContext: func(mbc *contract.MockBlockContext) {
 				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)) - 1,
-			ReadOnly:    false,
-			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+			SuppliedGas: EstimateVerifiedWarpMessageGasCost,
+			ReadOnly:    true,
+			ExpectedRes: packEstimate(GetVerifiedWarpMessageBaseCost+GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)), true),
 		},
-		"get message invalid predicate packing": {
-			Caller:  callerAddr,
-			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+		"estimate message with gas cost overrides": {
+			Caller: callerAddr,
+			Config: &Config{
+				Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+				GasEstimatesEnabled: true,
+				GasCosts: &GasCosts{
+					GetVerifiedWarpMessageBase: utils.NewUint64(10),
+					PerWarpMessageByte:         utils.NewUint64(7),
+				},
+			},
+			InputFn: func(t testing.TB) []byte { return estimateGas },
 			BeforeHook: func(t testing.TB, state contract.StateDB) {
-				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessage.Bytes()})
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
 			},
 			SetupBlockContext: func(mbc *contract.MockBlockContext) {
 				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessage.Bytes())),
+			SuppliedGas: EstimateVerifiedWarpMessageGasCost,
 			ReadOnly:    false,
-			ExpectedErr: errInvalidPredicateBytes.Error(),
+			ExpectedRes: packEstimate(10+7*uint64(len(warpMessagePredicateBytes)), true),
 		},
-		"get message invalid warp message": {
+		"estimate non-existent message": {
 			Caller:  callerAddr,
-			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
-			BeforeHook: func(t testing.TB, state contract.StateDB) {
-				state.SetPredicateStorageSlots(ContractAddress, [][]byte{predicate.PackPredicate([]byte{1, 2, 3})})
-			},
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return estimateGas },
 			SetupBlockContext: func(mbc *contract.MockBlockContext) {
 				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(32),
+			SuppliedGas: EstimateVerifiedWarpMessageGasCost,
 			ReadOnly:    false,
-			ExpectedErr: errInvalidWarpMsg.Error(),
+			ExpectedRes: packEstimate(GetVerifiedWarpMessageBaseCost, false),
 		},
-		"get message invalid addressed payload": {
+		"estimate message failed verification": {
 			Caller:  callerAddr,
-			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMsg },
+			Config:  enabledConfig,
+			InputFn: func(t testing.TB) []byte { return estimateGas },
 			BeforeHook: func(t testing.TB, state contract.StateDB) {
-				unsignedMessage, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, []byte{1, 2, 3}) // Invalid addressed payload
-				require.NoError(t, err)
-				warpMessage, err := avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{})
-				require.NoError(t, err)
-
-				state.SetPredicateStorageSlots(ContractAddress, [][]byte{predicate.PackPredicate(warpMessage.Bytes())})
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
 			},
 			SetupBlockContext: func(mbc *contract.MockBlockContext) {
-				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(160),
+			SuppliedGas: EstimateVerifiedWarpMessageGasCost,
 			ReadOnly:    false,
-			ExpectedErr: errInvalidAddressedPayload.Error(),
+			ExpectedRes: packEstimate(GetVerifiedWarpMessageBaseCost, false),
 		},
-		"get message index invalid uint32": {
-			Caller: callerAddr,
-			InputFn: func(t testing.TB) []byte {
-				return append(WarpABI.Methods["getVerifiedWarpMessage"].ID, new(big.Int).SetInt64(math.MaxInt64).Bytes()...)
-			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+		"estimate insufficient gas": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return estimateGas },
+			SuppliedGas: EstimateVerifiedWarpMessageGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"estimate invalid index input": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return estimateGas[:len(estimateGas)-2] },
+			SuppliedGas: EstimateVerifiedWarpMessageGasCost,
 			ReadOnly:    false,
 			ExpectedErr: errInvalidIndexInput.Error(),
 		},
-		"get message index invalid int32": {
-			Caller: callerAddr,
-			InputFn: func(t testing.TB) []byte {
-				res, err := PackGetVerifiedWarpMessage(math.MaxInt32 + 1)
-				require.NoError(t, err)
+		"estimate not activated": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return estimateGas },
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestSentWarpMessages(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+	otherSenderAddr := common.HexToAddress("0x0456")
+	snowCtx := utils.TestSnowContext()
+	enabledConfig := &Config{
+		Upgrade:             precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		SentMessagesEnabled: true,
+	}
+
+	sendWarpMessagePayload := agoUtils.RandomBytes(100)
+	sendWarpMessageInput, err := PackSendWarpMessage(sendWarpMessagePayload)
+	require.NoError(t, err)
+	sendWarpMessageWithIndexInput, err := PackSendWarpMessageWithIndex(sendWarpMessagePayload)
+	require.NoError(t, err)
+	messageID, err := ComputeWarpMessageID(snowCtx.NetworkID, &WarpMessage{
+		SourceChainID:       common.Hash(snowCtx.ChainID),
+		OriginSenderAddress: callerAddr,
+		Payload:             sendWarpMessagePayload,
+	})
+	require.NoError(t, err)
+	// The 100 byte payload is recorded in 4 words after the message ID, sender, and payload length,
+	// along with reading and writing the count of sent messages.
+	recordGas := 9 * SentWarpMessageGasCostPerSlot
+	sendWarpMessageGas := SendWarpMessageGasCost + uint64(len(sendWarpMessageInput[4:]))*SendWarpMessageGasCostPerByte + recordGas
+
+	// A message sent earlier in the transaction by another sender.
+	otherPayload := []byte("mcsorley")
+	otherMessage := WarpMessage{
+		SourceChainID:       common.Hash(snowCtx.ChainID),
+		OriginSenderAddress: otherSenderAddr,
+		Payload:             otherPayload,
+	}
+	otherMessageID, err := ComputeWarpMessageID(snowCtx.NetworkID, &otherMessage)
+	require.NoError(t, err)
+	recordOtherMessage := func(t testing.TB, state contract.StateDB) {
+		require.Equal(t, uint32(0), recordSentWarpMessage(state, otherSenderAddr, otherMessageID, otherPayload))
+	}
+
+	getSentMessage0, err := PackGetSentWarpMessage(0)
+	require.NoError(t, err)
+	getSentMessage1, err := PackGetSentWarpMessage(1)
+	require.NoError(t, err)
+	packGetSent := func(output GetSentWarpMessageOutput) []byte {
+		res, err := PackGetSentWarpMessageOutput(output)
+		if err != nil {
+			panic(err)
+		}
+		return res
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"send records message": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendWarpMessageInput },
+			BeforeHook:  recordOtherMessage,
+			SuppliedGas: sendWarpMessageGas,
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := PackSendWarpMessageOutput(messageID)
+				if err != nil {
+					panic(err)
+				}
 				return res
+			}(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, uint32(2), getSentWarpMessagesCount(state))
+				require.Equal(t, messageID, state.GetTransientState(ContractAddress, sentWarpMessageKey(1, sentMessageIDOffset)))
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost,
-			ReadOnly:    false,
-			ExpectedErr: errInvalidIndexInput.Error(),
 		},
-		"get message invalid index input bytes": {
-			Caller: callerAddr,
-			InputFn: func(t testing.TB) []byte {
-				res, err := PackGetVerifiedWarpMessage(1)
-				require.NoError(t, err)
-				return res[:len(res)-2]
+		"send with index returns index": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendWarpMessageWithIndexInput },
+			BeforeHook:  recordOtherMessage,
+			SuppliedGas: sendWarpMessageGas,
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := PackSendWarpMessageWithIndexOutput(SendWarpMessageWithIndexOutput{MessageID: messageID, Index: 1})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, uint32(2), getSentWarpMessagesCount(state))
+				require.Equal(t, messageID, state.GetTransientState(ContractAddress, sentWarpMessageKey(1, sentMessageIDOffset)))
+				require.Equal(t, common.BytesToHash(callerAddr[:]), state.GetTransientState(ContractAddress, sentWarpMessageKey(1, sentMessageSenderOffset)))
 			},
-			SuppliedGas: GetVerifiedWarpMessageBaseCost,
+		},
+		"send insufficient gas to record message": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return sendWarpMessageInput },
+			SuppliedGas: sendWarpMessageGas - 1,
 			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"get sent message": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return getSentMessage0 },
+			BeforeHook:  recordOtherMessage,
+			SuppliedGas: GetSentWarpMessageBaseGasCost + SentWarpMessageGasCostPerSlot,
+			ReadOnly:    true,
+			ExpectedRes: packGetSent(GetSentWarpMessageOutput{
+				Message:   otherMessage,
+				MessageID: otherMessageID,
+				Valid:     true,
+			}),
+		},
+		"get sent message insufficient gas for payload": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return getSentMessage0 },
+			BeforeHook:  recordOtherMessage,
+			SuppliedGas: GetSentWarpMessageBaseGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"get unsent message": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return getSentMessage1 },
+			BeforeHook:  recordOtherMessage,
+			SuppliedGas: GetSentWarpMessageBaseGasCost,
+			ReadOnly:    true,
+			ExpectedRes: packGetSent(GetSentWarpMessageOutput{Message: WarpMessage{Payload: []byte{}}}),
+		},
+		"get sent message invalid index input": {
+			Caller:      callerAddr,
+			Config:      enabledConfig,
+			InputFn:     func(t testing.TB) []byte { return getSentMessage0[:len(getSentMessage0)-2] },
+			SuppliedGas: GetSentWarpMessageBaseGasCost,
+			ReadOnly:    true,
 			ExpectedErr: errInvalidIndexInput.Error(),
 		},
+		"send with index not activated": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return sendWarpMessageWithIndexInput },
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"get sent message not activated": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return getSentMessage0 },
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"get sent message disabled by upgrade": {
+			Caller:  callerAddr,
+			Config:  NewDefaultConfig(utils.NewUint64(0)),
+			InputFn: func(t testing.TB) []byte { return getSentMessage0 },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				sentMessagesFlag.set(state, true)
+			},
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		},
 	}
 
 	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
@@ -765,3 +2115,106 @@ func TestPackEvents(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, unsignedWarpMessage.Bytes(), unpacked.Bytes())
 }
+
+func TestDecodeSendWarpMessageLog(t *testing.T) {
+	const networkID = uint32(54321)
+	message := &WarpMessage{
+		SourceChainID:       common.Hash(ids.GenerateTestID()),
+		OriginSenderAddress: common.HexToAddress("0x0123"),
+		Payload:             []byte("mcsorley"),
+	}
+	unsignedWarpMessage, err := newUnsignedWarpMessage(networkID, message)
+	require.NoError(t, err)
+	topics, data, err := PackSendWarpMessageEvent(
+		message.OriginSenderAddress,
+		common.Hash(unsignedWarpMessage.ID()),
+		unsignedWarpMessage.Bytes(),
+	)
+	require.NoError(t, err)
+
+	// newLog returns the log emitted by sendWarpMessage for [message], modified by [modify].
+	newLog := func(modify func(*types.Log)) types.Log {
+		log := types.Log{
+			Address: ContractAddress,
+			Topics:  slices.Clone(topics),
+			Data:    data,
+		}
+		modify(&log)
+		return log
+	}
+	tests := map[string]struct {
+		log     types.Log
+		wantErr error
+	}{
+		"valid": {
+			log: newLog(func(*types.Log) {}),
+		},
+		"emitted by another contract": {
+			log:     newLog(func(log *types.Log) { log.Address = common.HexToAddress("0x0123") }),
+			wantErr: ErrInvalidSendWarpMessageLog,
+		},
+		"other event": {
+			log:     newLog(func(log *types.Log) { log.Topics[0] = common.Hash{1} }),
+			wantErr: ErrInvalidSendWarpMessageLog,
+		},
+		"missing topic": {
+			log:     newLog(func(log *types.Log) { log.Topics = log.Topics[:2] }),
+			wantErr: ErrInvalidSendWarpMessageLog,
+		},
+		"mismatched sender": {
+			log:     newLog(func(log *types.Log) { log.Topics[1] = common.BytesToHash(common.HexToAddress("0x0456").Bytes()) }),
+			wantErr: ErrInvalidSendWarpMessageLog,
+		},
+		"mismatched message ID": {
+			log:     newLog(func(log *types.Log) { log.Topics[2] = common.Hash{1} }),
+			wantErr: ErrInvalidSendWarpMessageLog,
+		},
+		"malformed data": {
+			log:     newLog(func(log *types.Log) { log.Data = []byte{1, 2, 3} }),
+			wantErr: ErrInvalidSendWarpMessageLog,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			decoded, err := DecodeSendWarpMessageLog(test.log)
+			require.ErrorIs(t, err, test.wantErr)
+			if test.wantErr != nil {
+				return
+			}
+			require.Equal(t, message, decoded)
+		})
+	}
+}
+
+func TestCheckWarpMethods(t *testing.T) {
+	tests := map[string]struct {
+		methods []warpMethod
+		wantErr string
+	}{
+		"dispatch table": {
+			methods: warpMethods,
+		},
+		"unhandled method": {
+			methods: warpMethods[:len(warpMethods)-1],
+			wantErr: fmt.Sprintf("methods [%s] of the ABI have no handler", warpMethods[len(warpMethods)-1].name),
+		},
+		"method missing from the ABI": {
+			methods: append(slices.Clone(warpMethods), warpMethod{name: "missingMethod"}),
+			wantErr: "given method (missingMethod) does not exist in the ABI",
+		},
+		"duplicated handler": {
+			methods: append(slices.Clone(warpMethods), warpMethods[0]),
+			wantErr: "given method (getBlockchainID) has more than one handler",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkWarpMethods(WarpABI, test.methods)
+			if test.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
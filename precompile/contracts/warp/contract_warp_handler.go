@@ -18,12 +18,16 @@ import (
 
 var (
 	_ messageHandler = addressedPayloadHandler{}
+	_ messageHandler = rawAddressedPayloadHandler{}
+	_ messageHandler = sequencedPayloadHandler{}
 	_ messageHandler = blockHashHandler{}
 )
 
 var (
-	getVerifiedWarpMessageInvalidOutput   []byte
-	getVerifiedWarpBlockHashInvalidOutput []byte
+	getVerifiedWarpMessageInvalidOutput    []byte
+	getVerifiedWarpMessageRawInvalidOutput []byte
+	getVerifiedSequencedInvalidOutput      []byte
+	getVerifiedWarpBlockHashInvalidOutput  []byte
 )
 
 func init() {
@@ -33,6 +37,18 @@ func init() {
 	}
 	getVerifiedWarpMessageInvalidOutput = res
 
+	res, err = PackGetVerifiedWarpMessageRawOutput(GetVerifiedWarpMessageRawOutput{Valid: false})
+	if err != nil {
+		panic(err)
+	}
+	getVerifiedWarpMessageRawInvalidOutput = res
+
+	res, err = PackGetVerifiedSequencedWarpMessageOutput(GetVerifiedSequencedWarpMessageOutput{Valid: false})
+	if err != nil {
+		panic(err)
+	}
+	getVerifiedSequencedInvalidOutput = res
+
 	res, err = PackGetVerifiedWarpBlockHashOutput(GetVerifiedWarpBlockHashOutput{Valid: false})
 	if err != nil {
 		panic(err)
@@ -42,34 +58,31 @@ func init() {
 
 type messageHandler interface {
 	packFailed() []byte
+	// rawOutputSize returns the number of bytes of [msg] returned as is to the caller,
+	// which are charged GetVerifiedWarpMessageRawGasCostPerByte each.
+	rawOutputSize(msg *warp.Message) uint64
 	handleMessage(msg *warp.Message) ([]byte, error)
 }
 
 func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, suppliedGas uint64, handler messageHandler) ([]byte, uint64, error) {
-	remainingGas, err := contract.DeductGas(suppliedGas, GetVerifiedWarpMessageBaseCost)
+	state := accessibleState.GetStateDB()
+	baseGas := getGasCost(state, getVerifiedWarpMessageBaseGasCostKey, GetVerifiedWarpMessageBaseCost)
+	remainingGas, err := contract.DeductGas(suppliedGas, baseGas)
 	if err != nil {
 		return nil, remainingGas, err
 	}
 
-	warpIndexInput, err := UnpackGetVerifiedWarpMessageInput(input)
+	predicateBytes, valid, err := getPredicateBytes(accessibleState, input)
 	if err != nil {
-		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidIndexInput, err)
-	}
-	if warpIndexInput > math.MaxInt32 {
-		return nil, remainingGas, fmt.Errorf("%w: larger than MaxInt32", errInvalidIndexInput)
+		return nil, remainingGas, err
 	}
-	warpIndex := int(warpIndexInput) // This conversion is safe even if int is 32 bits because we checked above.
-	state := accessibleState.GetStateDB()
-	predicateBytes, exists := state.GetPredicateStorageSlots(ContractAddress, warpIndex)
-	predicateResults := accessibleState.GetBlockContext().GetPredicateResults(state.GetTxHash(), ContractAddress)
-	valid := exists && !set.BitsFromBytes(predicateResults).Contains(warpIndex)
 	if !valid {
 		return handler.packFailed(), remainingGas, nil
 	}
 
 	// Note: we charge for the size of the message during both predicate verification and each time the message is read during
 	// EVM execution because each execution incurs an additional read cost.
-	msgBytesGas, overflow := math.SafeMul(GasCostPerWarpMessageBytes, uint64(len(predicateBytes)))
+	msgBytesGas, overflow := warpMessageBytesGas(state, predicateBytes)
 	if overflow {
 		return nil, 0, vmerrs.ErrOutOfGas
 	}
@@ -86,6 +99,13 @@ func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, s
 	if err != nil {
 		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidWarpMsg, err)
 	}
+	rawOutputGas, overflow := math.SafeMul(GetVerifiedWarpMessageRawGasCostPerByte, handler.rawOutputSize(warpMessage))
+	if overflow {
+		return nil, 0, vmerrs.ErrOutOfGas
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, rawOutputGas); err != nil {
+		return nil, 0, err
+	}
 	res, err := handler.handleMessage(warpMessage)
 	if err != nil {
 		return nil, remainingGas, err
@@ -93,33 +113,149 @@ func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, s
 	return res, remainingGas, nil
 }
 
+// getPredicateBytes returns the predicate of the warp message at the index given by [input] and
+// whether it exists and passed verification.
+func getPredicateBytes(accessibleState contract.AccessibleState, input []byte) ([]byte, bool, error) {
+	warpIndexInput, err := UnpackGetVerifiedWarpMessageInput(input)
+	if err != nil {
+		return nil, false, err
+	}
+	if warpIndexInput > math.MaxInt32 {
+		return nil, false, fmt.Errorf("%w: %d larger than MaxInt32", errIndexOutOfRange, warpIndexInput)
+	}
+	warpIndex := int(warpIndexInput) // This conversion is safe even if int is 32 bits because we checked above.
+	state := accessibleState.GetStateDB()
+	predicateBytes, exists := state.GetPredicateStorageSlots(ContractAddress, warpIndex)
+	predicateResults := accessibleState.GetBlockContext().GetPredicateResults(state.GetTxHash(), ContractAddress)
+	valid := exists && !set.BitsFromBytes(predicateResults).Contains(warpIndex)
+	return predicateBytes, valid, nil
+}
+
+// warpMessageBytesGas returns the gas charged to read [predicateBytes] during EVM execution, and
+// whether computing it overflowed.
+func warpMessageBytesGas(state contract.StateDB, predicateBytes []byte) (uint64, bool) {
+	perByteGas := getGasCost(state, perWarpMessageByteGasCostKey, GasCostPerWarpMessageBytes)
+	return math.SafeMul(perByteGas, uint64(len(predicateBytes)))
+}
+
 type addressedPayloadHandler struct{}
 
 func (addressedPayloadHandler) packFailed() []byte {
 	return getVerifiedWarpMessageInvalidOutput
 }
 
+func (addressedPayloadHandler) rawOutputSize(*warp.Message) uint64 {
+	return 0
+}
+
 func (addressedPayloadHandler) handleMessage(warpMessage *warp.Message) ([]byte, error) {
-	addressedPayload, err := payload.ParseAddressedCall(warpMessage.UnsignedMessage.Payload)
+	message, err := parseWarpMessage(warpMessage)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", errInvalidAddressedPayload, err)
+		return nil, err
 	}
 	return PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
-		Message: WarpMessage{
-			SourceChainID:       common.Hash(warpMessage.SourceChainID),
-			OriginSenderAddress: common.BytesToAddress(addressedPayload.SourceAddress),
-			Payload:             addressedPayload.Payload,
+		Message: message,
+		Valid:   true,
+	})
+}
+
+type rawAddressedPayloadHandler struct{}
+
+func (rawAddressedPayloadHandler) packFailed() []byte {
+	return getVerifiedWarpMessageRawInvalidOutput
+}
+
+func (rawAddressedPayloadHandler) rawOutputSize(warpMessage *warp.Message) uint64 {
+	return uint64(len(warpMessage.UnsignedMessage.Bytes()))
+}
+
+func (rawAddressedPayloadHandler) handleMessage(warpMessage *warp.Message) ([]byte, error) {
+	message, err := parseWarpMessage(warpMessage)
+	if err != nil {
+		return nil, err
+	}
+	return PackGetVerifiedWarpMessageRawOutput(GetVerifiedWarpMessageRawOutput{
+		Message:         message,
+		UnsignedMessage: warpMessage.UnsignedMessage.Bytes(),
+		Valid:           true,
+	})
+}
+
+// sequencedPayloadHandler enforces that the sequenced messages of each origin sender are consumed in
+// order, recording the last consumed sequence of each origin sender in [stateDB].
+type sequencedPayloadHandler struct {
+	stateDB contract.StateDB
+}
+
+func (sequencedPayloadHandler) packFailed() []byte {
+	return getVerifiedSequencedInvalidOutput
+}
+
+func (sequencedPayloadHandler) rawOutputSize(*warp.Message) uint64 {
+	return 0
+}
+
+func (h sequencedPayloadHandler) handleMessage(warpMessage *warp.Message) ([]byte, error) {
+	message, err := parseWarpMessage(warpMessage)
+	if err != nil {
+		return nil, err
+	}
+	sequence, payloadData, err := UnpackSequencedWarpPayload(message.Payload)
+	if err != nil {
+		// Messages sent without a sequence are returned as is and are not ordered.
+		return PackGetVerifiedSequencedWarpMessageOutput(GetVerifiedSequencedWarpMessageOutput{
+			Message: SequencedWarpMessage{
+				SourceChainID:       message.SourceChainID,
+				OriginSenderAddress: message.OriginSenderAddress,
+				Payload:             message.Payload,
+			},
+			Valid: true,
+		})
+	}
+	lastSequence := GetLastConsumedSequence(h.stateDB, message.SourceChainID, message.OriginSenderAddress)
+	if lastSequence == math.MaxUint64 || sequence != lastSequence+1 {
+		return h.packFailed(), nil
+	}
+	setLastConsumedSequence(h.stateDB, message.SourceChainID, message.OriginSenderAddress, sequence)
+	return PackGetVerifiedSequencedWarpMessageOutput(GetVerifiedSequencedWarpMessageOutput{
+		Message: SequencedWarpMessage{
+			SourceChainID:       message.SourceChainID,
+			OriginSenderAddress: message.OriginSenderAddress,
+			Sequence:            sequence,
+			Payload:             payloadData,
 		},
 		Valid: true,
 	})
 }
 
+// parseWarpMessage parses the AddressedCall payload of [warpMessage] as a WarpMessage.
+func parseWarpMessage(warpMessage *warp.Message) (WarpMessage, error) {
+	return parseUnsignedWarpMessage(&warpMessage.UnsignedMessage)
+}
+
+// parseUnsignedWarpMessage parses the AddressedCall payload of [unsignedMessage] as a WarpMessage.
+func parseUnsignedWarpMessage(unsignedMessage *warp.UnsignedMessage) (WarpMessage, error) {
+	addressedPayload, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+	if err != nil {
+		return WarpMessage{}, fmt.Errorf("%w: %s", errInvalidAddressedPayload, err)
+	}
+	return WarpMessage{
+		SourceChainID:       common.Hash(unsignedMessage.SourceChainID),
+		OriginSenderAddress: common.BytesToAddress(addressedPayload.SourceAddress),
+		Payload:             addressedPayload.Payload,
+	}, nil
+}
+
 type blockHashHandler struct{}
 
 func (blockHashHandler) packFailed() []byte {
 	return getVerifiedWarpBlockHashInvalidOutput
 }
 
+func (blockHashHandler) rawOutputSize(*warp.Message) uint64 {
+	return 0
+}
+
 func (blockHashHandler) handleMessage(warpMessage *warp.Message) ([]byte, error) {
 	blockHashPayload, err := payload.ParseHash(warpMessage.UnsignedMessage.Payload)
 	if err != nil {
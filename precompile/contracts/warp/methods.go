@@ -0,0 +1,204 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// MethodInfo describes a method of the warp precompile and the gas it charges, for tooling such as
+// wallets and explorers to estimate the fees of calling it.
+type MethodInfo struct {
+	// Name is the name of the method in the WarpMessenger ABI.
+	Name string `json:"name"`
+	// Selector is the 4 byte selector of the method.
+	Selector hexutil.Bytes `json:"selector"`
+	// BaseGasCost is the gas charged by every call of the method.
+	BaseGasCost uint64 `json:"baseGasCost"`
+	// PerByteGasCost is the gas charged for each byte of the payload sent by sendWarpMessage,
+	// sendWarpMessageMulti, and sendWarpMessages, or of the predicate read by the getVerifiedWarp* methods.
+	// sendWarpMessageMulti charges BaseGasCost and PerByteGasCost once per destination chain,
+	// sendWarpMessages charges BaseGasCost once per message, and
+	// getVerifiedWarpMessageRaw additionally charges GetVerifiedWarpMessageRawGasCostPerByte for each
+	// byte of the unsigned message it returns. Once sent messages are enabled, sendWarpMessage additionally
+	// charges SentWarpMessageGasCostPerSlot for each slot recording the message, and getSentWarpMessage
+	// charges it for each 32 byte word of the payload it returns.
+	PerByteGasCost uint64 `json:"perByteGasCost"`
+	// RequiresActivation is true if the method must be enabled in the config of the warp precompile.
+	RequiresActivation bool `json:"requiresActivation"`
+}
+
+// WarpMessengerMethods returns the MethodInfo of every method of the warp precompile with the
+// default gas costs.
+func WarpMessengerMethods() []MethodInfo {
+	return warpMessengerMethods(nil)
+}
+
+// Methods returns the MethodInfo of every method of the warp precompile with the gas costs
+// charged once [c] is configured.
+func (c *Config) Methods() []MethodInfo {
+	return warpMessengerMethods(c.GasCosts)
+}
+
+// warpMessengerMethods returns the MethodInfo of every method in the dispatch table of the warp
+// precompile with the gas costs overridden by [gasCosts], which may be nil.
+func warpMessengerMethods(gasCosts *GasCosts) []MethodInfo {
+	methods := make([]MethodInfo, 0, len(warpMethods))
+	for _, method := range warpMethods {
+		baseGas, perByteGas := method.gasCosts(gasCosts)
+		methods = append(methods, MethodInfo{
+			Name:               method.name,
+			Selector:           WarpABI.Methods[method.name].ID,
+			BaseGasCost:        baseGas,
+			PerByteGasCost:     perByteGas,
+			RequiresActivation: method.activator != nil,
+		})
+	}
+	return methods
+}
+
+// DeliveryCost is the cost of sending a warp message with sendWarpMessage on its source chain and reading
+// it with getVerifiedWarpMessage on its destination chain.
+type DeliveryCost struct {
+	// SendGas is the gas charged by sendWarpMessage on the source chain.
+	SendGas uint64 `json:"sendGas"`
+	// MessageFee is the native-token fee in wei charged by sendWarpMessage on the source chain, or nil if
+	// the source chain charges no fee.
+	MessageFee *big.Int `json:"messageFee,omitempty"`
+	// PredicateGas is the intrinsic gas charged on the destination chain for including the signed message
+	// in the access list of a transaction, which covers verifying its signature.
+	PredicateGas uint64 `json:"predicateGas"`
+	// ReceiveGas is the gas charged by getVerifiedWarpMessage on the destination chain.
+	ReceiveGas uint64 `json:"receiveGas"`
+	// TotalGas is the sum of SendGas, PredicateGas and ReceiveGas.
+	TotalGas uint64 `json:"totalGas"`
+}
+
+// EstimateDeliveryCost returns the cost of sending a warp message with a payload of [payloadSize] bytes
+// on a source chain with the warp config [source], and reading it on a destination chain with the warp
+// config [destination], where a nil config uses the default gas costs. The message is assumed to be
+// signed by every one of the [numValidators] validators of the source subnet, so that the estimate is
+// an upper bound on the cost of the signers.
+//
+// The costs are computed from the same gas costs the precompile charges, with the size of the signed
+// message computed by encoding a message of the same size. Returns an error if the destination chain
+// would reject the message, such as if it has more than its max signers.
+func EstimateDeliveryCost(source *Config, destination *Config, payloadSize uint64, numValidators int) (DeliveryCost, error) {
+	if source == nil {
+		source = &Config{}
+	}
+	if destination == nil {
+		destination = &Config{}
+	}
+	var cost DeliveryCost
+
+	// sendWarpMessage charges for the size of its ABI encoded input rather than of the payload.
+	sendInput, err := PackSendWarpMessage(make([]byte, payloadSize))
+	if err != nil {
+		return DeliveryCost{}, err
+	}
+	sendBaseGas, sendPerByteGas := sendWarpMessageGasCosts(source.GasCosts)
+	sendInputGas, overflow := math.SafeMul(sendPerByteGas, uint64(len(sendInput)-4)) // Excludes the selector
+	if overflow {
+		return DeliveryCost{}, fmt.Errorf("overflow calculating send gas of payload of size %d", payloadSize)
+	}
+	cost.SendGas = sendBaseGas + sendInputGas
+	if source.SenderAllowList != nil {
+		cost.SendGas += allowlist.ReadAllowListGasCost
+	}
+	if source.SentMessagesEnabled {
+		cost.SendGas += SentWarpMessageGasCostPerSlot * (sentWarpMessageSlots(int(payloadSize)) + 2)
+	}
+	if fee := source.messageFee(); fee != nil {
+		cost.MessageFee = new(big.Int).Set(fee)
+	}
+
+	predicateBytes, err := estimatedPredicate(payloadSize, numValidators)
+	if err != nil {
+		return DeliveryCost{}, err
+	}
+	if cost.PredicateGas, err = destination.PredicateGas(predicateBytes); err != nil {
+		return DeliveryCost{}, err
+	}
+	receiveBaseGas, receivePerByteGas := verifiedWarpMessageGasCosts(destination.GasCosts)
+	cost.ReceiveGas = receiveBaseGas + receivePerByteGas*uint64(len(predicateBytes))
+
+	cost.TotalGas = cost.SendGas + cost.PredicateGas + cost.ReceiveGas
+	return cost, nil
+}
+
+// estimatedPredicate returns the predicate of a warp message sent by sendWarpMessage with a payload of
+// [payloadSize] bytes and signed by [numSigners] validators, which has the same size as any such message.
+func estimatedPredicate(payloadSize uint64, numSigners int) ([]byte, error) {
+	addressedCall, err := payload.NewAddressedCall(make([]byte, common.AddressLength), make([]byte, payloadSize))
+	if err != nil {
+		return nil, err
+	}
+	unsignedMessage, err := warp.NewUnsignedMessage(0, ids.Empty, addressedCall.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	signers := set.NewBits()
+	for i := 0; i < numSigners; i++ {
+		signers.Add(i)
+	}
+	signedMessage, err := warp.NewMessage(unsignedMessage, &warp.BitSetSignature{Signers: signers.Bytes()})
+	if err != nil {
+		return nil, err
+	}
+	return predicate.PackPredicate(signedMessage.Bytes()), nil
+}
+
+func getBlockchainIDGasCosts(g *GasCosts) (uint64, uint64) {
+	if g == nil {
+		g = &GasCosts{}
+	}
+	return gasCost(g.GetBlockchainID, GetBlockchainIDGasCost), 0
+}
+
+func verifiedWarpMessageGasCosts(g *GasCosts) (uint64, uint64) {
+	if g == nil {
+		g = &GasCosts{}
+	}
+	return gasCost(g.GetVerifiedWarpMessageBase, GetVerifiedWarpMessageBaseCost), gasCost(g.PerWarpMessageByte, GasCostPerWarpMessageBytes)
+}
+
+func verifiedSequencedWarpMessageGasCosts(g *GasCosts) (uint64, uint64) {
+	baseGas, perByteGas := verifiedWarpMessageGasCosts(g)
+	return baseGas + GetVerifiedSequencedWarpMessageGasCost, perByteGas
+}
+
+func estimateVerifiedWarpMessageGasCosts(*GasCosts) (uint64, uint64) {
+	return EstimateVerifiedWarpMessageGasCost, 0
+}
+
+func getWarpFormatVersionGasCosts(*GasCosts) (uint64, uint64) {
+	return GetWarpFormatVersionGasCost, 0
+}
+
+func hasWarpPredicatesGasCosts(*GasCosts) (uint64, uint64) {
+	return HasWarpPredicatesGasCost, 0
+}
+
+func getSentWarpMessageGasCosts(*GasCosts) (uint64, uint64) {
+	return GetSentWarpMessageBaseGasCost, 0
+}
+
+func sendWarpMessageGasCosts(g *GasCosts) (uint64, uint64) {
+	if g == nil {
+		g = &GasCosts{}
+	}
+	return gasCost(g.SendWarpMessageBase, SendWarpMessageGasCost), gasCost(g.SendWarpMessagePerByte, SendWarpMessageGasCostPerByte)
+}
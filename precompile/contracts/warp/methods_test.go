@@ -0,0 +1,168 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"math/big"
+	"testing"
+
+	agoUtils "github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarpMessengerMethods(t *testing.T) {
+	require := require.New(t)
+
+	methods := WarpMessengerMethods()
+	// Every method of the ABI is dispatched, so it is listed exactly once.
+	require.Len(methods, len(WarpABI.Methods))
+	byName := make(map[string]MethodInfo)
+	for _, method := range methods {
+		abiMethod, ok := WarpABI.Methods[method.Name]
+		require.True(ok, method.Name)
+		require.Equal(abiMethod.ID, []byte(method.Selector))
+		byName[method.Name] = method
+	}
+
+	require.Equal(MethodInfo{
+		Name:           "sendWarpMessage",
+		Selector:       WarpABI.Methods["sendWarpMessage"].ID,
+		BaseGasCost:    SendWarpMessageGasCost,
+		PerByteGasCost: SendWarpMessageGasCostPerByte,
+	}, byName["sendWarpMessage"])
+	require.True(byName["getVerifiedWarpMessageRaw"].RequiresActivation)
+	require.False(byName["getVerifiedWarpMessage"].RequiresActivation)
+	require.True(byName["getVerifiedSequencedWarpMessage"].RequiresActivation)
+	require.True(byName["estimateVerifiedWarpMessageGas"].RequiresActivation)
+	require.Equal(EstimateVerifiedWarpMessageGasCost, byName["estimateVerifiedWarpMessageGas"].BaseGasCost)
+	require.True(byName["getSentWarpMessage"].RequiresActivation)
+	require.True(byName["sendWarpMessageWithIndex"].RequiresActivation)
+	require.True(byName["getWarpFormatVersion"].RequiresActivation)
+	require.Equal(GetWarpFormatVersionGasCost, byName["getWarpFormatVersion"].BaseGasCost)
+	require.True(byName["hasWarpPredicates"].RequiresActivation)
+	require.Equal(HasWarpPredicatesGasCost, byName["hasWarpPredicates"].BaseGasCost)
+	require.Equal(GetSentWarpMessageBaseGasCost, byName["getSentWarpMessage"].BaseGasCost)
+	require.Equal(GetVerifiedWarpMessageBaseCost+GetVerifiedSequencedWarpMessageGasCost, byName["getVerifiedSequencedWarpMessage"].BaseGasCost)
+
+	config := NewConfig(utils.NewUint64(0), 0)
+	config.GasCosts = &GasCosts{
+		GetBlockchainID:    utils.NewUint64(5),
+		PerWarpMessageByte: utils.NewUint64(7),
+	}
+	for _, method := range config.Methods() {
+		switch method.Name {
+		case "getBlockchainID":
+			require.Equal(uint64(5), method.BaseGasCost)
+		case "getVerifiedWarpMessage", "getVerifiedWarpBlockHash", "getVerifiedWarpMessageRaw":
+			require.Equal(GetVerifiedWarpMessageBaseCost, method.BaseGasCost)
+			require.Equal(uint64(7), method.PerByteGasCost)
+		case "sendWarpMessage", "sendWarpMessageWithIndex", "sendWarpMessageMulti", "sendWarpMessages":
+			require.Equal(SendWarpMessageGasCost, method.BaseGasCost)
+		}
+	}
+}
+
+func TestEstimateDeliveryCost(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+	snowCtx := utils.TestSnowContext()
+	numValidators := 5
+	sendPayload := agoUtils.RandomBytes(100)
+	sendInput, err := PackSendWarpMessage(sendPayload)
+	require.NoError(t, err)
+
+	// The message delivered to the destination chain, signed by every validator.
+	addressedCall, err := payload.NewAddressedCall(callerAddr.Bytes(), sendPayload)
+	require.NoError(t, err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, addressedCall.Bytes())
+	require.NoError(t, err)
+	signers := set.NewBits()
+	for i := 0; i < numValidators; i++ {
+		signers.Add(i)
+	}
+	signedMessage, err := avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{Signers: signers.Bytes()})
+	require.NoError(t, err)
+	predicateBytes := predicate.PackPredicate(signedMessage.Bytes())
+	getVerifiedWarpMessage, err := PackGetVerifiedWarpMessage(0)
+	require.NoError(t, err)
+	receiveOutput, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
+		Message: WarpMessage{
+			SourceChainID:       common.Hash(snowCtx.ChainID),
+			OriginSenderAddress: callerAddr,
+			Payload:             sendPayload,
+		},
+		Valid: true,
+	})
+	require.NoError(t, err)
+
+	overriddenConfig := &Config{
+		Upgrade:      precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		MessageFee:   big.NewInt(1000),
+		FeeRecipient: common.Address{1},
+		GasCosts: &GasCosts{
+			SendWarpMessagePerByte: utils.NewUint64(3),
+			SignatureVerification:  utils.NewUint64(100_000),
+			PerWarpSigner:          utils.NewUint64(50),
+			PerWarpMessageByte:     utils.NewUint64(7),
+		},
+	}
+
+	defaultCost, err := EstimateDeliveryCost(nil, nil, uint64(len(sendPayload)), numValidators)
+	require.NoError(t, err)
+	require.Equal(t, GasCostPerSignatureVerification+uint64(len(predicateBytes))*GasCostPerWarpMessageBytes+uint64(numValidators)*GasCostPerWarpSigner, defaultCost.PredicateGas)
+	require.Nil(t, defaultCost.MessageFee)
+	require.Equal(t, defaultCost.SendGas+defaultCost.PredicateGas+defaultCost.ReceiveGas, defaultCost.TotalGas)
+
+	overriddenCost, err := EstimateDeliveryCost(overriddenConfig, overriddenConfig, uint64(len(sendPayload)), numValidators)
+	require.NoError(t, err)
+	require.Equal(t, 100_000+uint64(len(predicateBytes))*7+uint64(numValidators)*50, overriddenCost.PredicateGas)
+	require.Equal(t, big.NewInt(1000), overriddenCost.MessageFee)
+
+	// The estimated send and receive gas must be exactly the gas charged by the precompile, which the
+	// test harness checks by requiring that no supplied gas remains.
+	tests := map[string]testutils.PrecompileTest{
+		"send with default gas costs": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return sendInput },
+			SuppliedGas: defaultCost.SendGas,
+			ExpectedRes: common.Hash(unsignedMessage.ID()).Bytes(),
+		},
+		"receive with default gas costs": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMessage },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{predicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits().Bytes())
+			},
+			SuppliedGas: defaultCost.ReceiveGas,
+			ExpectedRes: receiveOutput,
+		},
+		"receive with overridden gas costs": {
+			Caller:  callerAddr,
+			Config:  overriddenConfig,
+			InputFn: func(t testing.TB) []byte { return getVerifiedWarpMessage },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{predicateBytes})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits().Bytes())
+			},
+			SuppliedGas: overriddenCost.ReceiveGas,
+			ExpectedRes: receiveOutput,
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
@@ -46,10 +46,42 @@ func (*configurator) MakeConfig() precompileconfig.Config {
 	return new(Config)
 }
 
-// Configure is a no-op for warp since it does not need to store any information in the state
-func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg precompileconfig.Config, state contract.StateDB, _ contract.ConfigurationBlockContext) error {
-	if _, ok := cfg.(*Config); !ok {
+// Configure records the gas cost overrides, the flags enabling the optional methods of the precompile, the maximum
+// number of messages of sendWarpMessages, the destination chains messages may be sent to, the message fee and its
+// recipient and whether the sender allow list is enabled and, if so, initializes the roles of its addresses in the
+// state of the warp precompile.
+func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg precompileconfig.Config, state contract.StateDB, blockContext contract.ConfigurationBlockContext) error {
+	config, ok := cfg.(*Config)
+	if !ok {
 		return fmt.Errorf("expected config type %T, got %T: %v", &Config{}, cfg, cfg)
 	}
-	return nil
+	storeGasCosts(state, config.GasCosts)
+	flags := []struct {
+		flag    stateFlag
+		enabled bool
+	}{
+		{rawMessagesFlag, config.RawMessagesEnabled},
+		{multiDestinationMessagesFlag, config.MultiDestinationMessagesEnabled},
+		{sequenceOrderingFlag, config.EnforceSequenceOrdering},
+		{gasEstimatesFlag, config.GasEstimatesEnabled},
+		{sentMessagesFlag, config.SentMessagesEnabled},
+		{formatVersionFlag, config.FormatVersionEnabled},
+		{warpPredicatesFlag, config.WarpPredicatesEnabled},
+		{senderAllowListFlag, config.SenderAllowList != nil},
+	}
+	for _, f := range flags {
+		f.flag.configure(state, f.enabled)
+	}
+	// Avoid touching the state unless sendWarpMessages is or was enabled.
+	if config.MaxBatchMessages > 0 || getMaxBatchMessages(state) > 0 {
+		setMaxBatchMessages(state, config.MaxBatchMessages)
+	}
+	storeDestinationChains(state, config.DestinationChainIDs)
+	if err := storeMessageFee(state, config.messageFee(), config.FeeRecipient); err != nil {
+		return err
+	}
+	if config.SenderAllowList == nil {
+		return nil
+	}
+	return config.SenderAllowList.Configure(chainConfig, ContractAddress, state, blockContext)
 }
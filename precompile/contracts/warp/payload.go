@@ -0,0 +1,82 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+)
+
+// WarpPayloadTypeTagLen is the length of the type tag prefixing a typed warp payload.
+const WarpPayloadTypeTagLen = 4
+
+var (
+	ErrWarpPayloadTooShort       = errors.New("warp payload shorter than type tag")
+	ErrUnexpectedWarpPayloadType = errors.New("unexpected warp payload type tag")
+)
+
+// PackWarpPayload packs [values] into a typed warp payload of [abiType].
+//
+// A typed warp payload is the 4 byte selector of [abiType] followed by the ABI encoding of
+// [values] as the inputs of [abiType]. This is the encoding produced in Solidity by
+// abi.encodeWithSignature, so that a payload type can be defined as a function signature,
+// such as "transfer(address,uint256)", shared by the sending and receiving chains.
+func PackWarpPayload(abiType abi.Method, values ...interface{}) ([]byte, error) {
+	body, err := abiType.Inputs.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s warp payload: %w", abiType.Sig, err)
+	}
+	return append(bytes.Clone(abiType.ID), body...), nil
+}
+
+// UnpackWarpPayload unpacks the values of a typed warp payload of [abiType] packed by PackWarpPayload.
+// Returns ErrUnexpectedWarpPayloadType if [payload] is tagged with a different type.
+func UnpackWarpPayload(abiType abi.Method, payload []byte) ([]interface{}, error) {
+	if len(payload) < WarpPayloadTypeTagLen {
+		return nil, fmt.Errorf("%w: %d bytes", ErrWarpPayloadTooShort, len(payload))
+	}
+	typeTag, body := payload[:WarpPayloadTypeTagLen], payload[WarpPayloadTypeTagLen:]
+	if !bytes.Equal(typeTag, abiType.ID) {
+		return nil, fmt.Errorf("%w: expected %x (%s), got %x", ErrUnexpectedWarpPayloadType, abiType.ID, abiType.Sig, typeTag)
+	}
+	values, err := abiType.Inputs.Unpack(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s warp payload: %w", abiType.Sig, err)
+	}
+	return values, nil
+}
+
+// sequencedWarpPayloadType is the typed warp payload of a sequenced warp message, as read by
+// getVerifiedSequencedWarpMessage.
+var sequencedWarpPayloadType = func() abi.Method {
+	uint64Type, err := abi.NewType("uint64", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	bytesType, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	inputs := abi.Arguments{{Name: "sequence", Type: uint64Type}, {Name: "payload", Type: bytesType}}
+	return abi.NewMethod("sequencedWarpMessage", "sequencedWarpMessage", abi.Function, "", false, false, inputs, nil)
+}()
+
+// PackSequencedWarpPayload packs [payload] with [sequence] as the payload of a sequenced warp message,
+// equivalent to abi.encodeWithSignature("sequencedWarpMessage(uint64,bytes)", sequence, payload).
+func PackSequencedWarpPayload(sequence uint64, payload []byte) ([]byte, error) {
+	return PackWarpPayload(sequencedWarpPayloadType, sequence, payload)
+}
+
+// UnpackSequencedWarpPayload unpacks the sequence and payload of a sequenced warp message packed by
+// PackSequencedWarpPayload. Returns ErrUnexpectedWarpPayloadType if [data] is not sequenced.
+func UnpackSequencedWarpPayload(data []byte) (uint64, []byte, error) {
+	values, err := UnpackWarpPayload(sequencedWarpPayloadType, data)
+	if err != nil {
+		return 0, nil, err
+	}
+	return *abi.ConvertType(values[0], new(uint64)).(*uint64), *abi.ConvertType(values[1], new([]byte)).(*[]byte), nil
+}
@@ -0,0 +1,102 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPayloadType(t *testing.T, name string, types ...string) abi.Method {
+	inputs := make(abi.Arguments, 0, len(types))
+	for _, typ := range types {
+		abiType, err := abi.NewType(typ, "", nil)
+		require.NoError(t, err)
+		inputs = append(inputs, abi.Argument{Type: abiType})
+	}
+	return abi.NewMethod(name, name, abi.Function, "", false, false, inputs, nil)
+}
+
+func TestPackUnpackWarpPayload(t *testing.T) {
+	require := require.New(t)
+
+	transferType := newTestPayloadType(t, "transfer", "address", "uint256")
+	to := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	amount := big.NewInt(1_000_000)
+
+	payload, err := PackWarpPayload(transferType, to, amount)
+	require.NoError(err)
+	// The type tag matches the Solidity function selector of the payload type.
+	require.Equal(crypto.Keccak256([]byte("transfer(address,uint256)"))[:WarpPayloadTypeTagLen], payload[:WarpPayloadTypeTagLen])
+
+	values, err := UnpackWarpPayload(transferType, payload)
+	require.NoError(err)
+	require.Equal([]interface{}{to, amount}, values)
+}
+
+func TestUnpackWarpPayloadErrors(t *testing.T) {
+	transferType := newTestPayloadType(t, "transfer", "address", "uint256")
+	payload, err := PackWarpPayload(transferType, common.Address{1}, big.NewInt(1))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		abiType     abi.Method
+		payload     []byte
+		expectedErr error
+	}{
+		"empty payload": {
+			abiType:     transferType,
+			payload:     nil,
+			expectedErr: ErrWarpPayloadTooShort,
+		},
+		"payload shorter than type tag": {
+			abiType:     transferType,
+			payload:     payload[:WarpPayloadTypeTagLen-1],
+			expectedErr: ErrWarpPayloadTooShort,
+		},
+		"different payload type": {
+			abiType:     newTestPayloadType(t, "burn", "address", "uint256"),
+			payload:     payload,
+			expectedErr: ErrUnexpectedWarpPayloadType,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := UnpackWarpPayload(test.abiType, test.payload)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+
+	t.Run("truncated body", func(t *testing.T) {
+		_, err := UnpackWarpPayload(transferType, payload[:len(payload)-1])
+		require.Error(t, err)
+	})
+}
+
+func TestPackWarpPayloadInvalidValues(t *testing.T) {
+	transferType := newTestPayloadType(t, "transfer", "address", "uint256")
+	_, err := PackWarpPayload(transferType, common.Address{1})
+	require.Error(t, err)
+}
+
+func TestPackUnpackSequencedWarpPayload(t *testing.T) {
+	require := require.New(t)
+
+	payload, err := PackSequencedWarpPayload(7, []byte("payload"))
+	require.NoError(err)
+	require.Equal(crypto.Keccak256([]byte("sequencedWarpMessage(uint64,bytes)"))[:WarpPayloadTypeTagLen], payload[:WarpPayloadTypeTagLen])
+
+	sequence, unpackedPayload, err := UnpackSequencedWarpPayload(payload)
+	require.NoError(err)
+	require.Equal(uint64(7), sequence)
+	require.Equal([]byte("payload"), unpackedPayload)
+
+	_, _, err = UnpackSequencedWarpPayload([]byte("not sequenced"))
+	require.ErrorIs(err, ErrUnexpectedWarpPayloadType)
+}
@@ -4,9 +4,11 @@
 package warp
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -19,10 +21,14 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/precompile/testutils"
 	"github.com/ava-labs/subnet-evm/predicate"
 	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -398,6 +404,253 @@ func TestInvalidAddressedPayload(t *testing.T) {
 	test.Run(t)
 }
 
+func TestWarpAllowedOriginSenders(t *testing.T) {
+	numKeys := 1
+	snowCtx := createSnowCtx([]validatorRange{
+		{
+			start:     0,
+			end:       numKeys,
+			weight:    20,
+			publicKey: true,
+		},
+	})
+	predicateBytes := createPredicate(numKeys)
+	originSender := common.BytesToAddress(addressedPayload.SourceAddress)
+
+	// Sign a block hash message, which has no origin sender.
+	blockHashPayload, err := payload.NewHash(ids.GenerateTestID())
+	require.NoError(t, err)
+	blockHashMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, blockHashPayload.Bytes())
+	require.NoError(t, err)
+	bitSet := set.NewBits(0)
+	blockHashSignature := &avalancheWarp.BitSetSignature{Signers: bitSet.Bytes()}
+	copy(blockHashSignature.Signature[:], bls.SignatureToBytes(bls.Sign(testVdrs[0].sk, blockHashMsg.Bytes())))
+	signedBlockHashMsg, err := avalancheWarp.NewMessage(blockHashMsg, blockHashSignature)
+	require.NoError(t, err)
+	blockHashPredicateBytes := predicate.PackPredicate(signedBlockHashMsg.Bytes())
+
+	tests := map[string]struct {
+		allowedOriginSenders []common.Address
+		predicateBytes       []byte
+		expectedErr          error
+	}{
+		"no allowed origin senders": {
+			predicateBytes: predicateBytes,
+		},
+		"allowed origin sender": {
+			allowedOriginSenders: []common.Address{{1}, originSender},
+			predicateBytes:       predicateBytes,
+		},
+		"disallowed origin sender": {
+			allowedOriginSenders: []common.Address{{1}},
+			predicateBytes:       predicateBytes,
+			expectedErr:          errOriginSenderNotAllowed,
+		},
+		"block hash without allowed origin senders": {
+			predicateBytes: blockHashPredicateBytes,
+		},
+		"block hash with allowed origin senders": {
+			allowedOriginSenders: []common.Address{originSender},
+			predicateBytes:       blockHashPredicateBytes,
+			expectedErr:          errOriginSenderNotAllowed,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			predicateTest := createValidPredicateTest(snowCtx, uint64(numKeys), test.predicateBytes)
+			predicateTest.Config = &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+				AllowedOriginSenders: test.allowedOriginSenders,
+			}
+			predicateTest.ExpectedErr = test.expectedErr
+			predicateTest.Run(t)
+		})
+	}
+}
+
+func TestWarpMaxSigners(t *testing.T) {
+	numKeys := 3
+	snowCtx := createSnowCtx([]validatorRange{
+		{
+			start:     0,
+			end:       numKeys,
+			weight:    20,
+			publicKey: true,
+		},
+	})
+	predicateBytes := createPredicate(numKeys)
+
+	tests := map[string]struct {
+		maxSigners *uint64
+		gasErr     error
+	}{
+		"no max signers": {},
+		"signers at max signers": {
+			maxSigners: utils.NewUint64(uint64(numKeys)),
+		},
+		"signers over max signers": {
+			maxSigners: utils.NewUint64(uint64(numKeys - 1)),
+			gasErr:     errTooManySigners,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			predicateTest := createValidPredicateTest(snowCtx, uint64(numKeys), predicateBytes)
+			predicateTest.Config = &Config{
+				Upgrade:    precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+				MaxSigners: test.maxSigners,
+			}
+			predicateTest.GasErr = test.gasErr
+			predicateTest.Run(t)
+		})
+	}
+}
+
+func TestWarpMaxStorageSlotsBytes(t *testing.T) {
+	numKeys := 3
+	snowCtx := createSnowCtx([]validatorRange{
+		{
+			start:     0,
+			end:       numKeys,
+			weight:    20,
+			publicKey: true,
+		},
+	})
+	predicateBytes := createPredicate(numKeys)
+
+	tests := map[string]struct {
+		maxStorageSlotsBytes *uint64
+		gasErr               error
+	}{
+		"no max storage slots bytes": {},
+		"predicate at max storage slots bytes": {
+			maxStorageSlotsBytes: utils.NewUint64(uint64(len(predicateBytes))),
+		},
+		"predicate over max storage slots bytes": {
+			maxStorageSlotsBytes: utils.NewUint64(uint64(len(predicateBytes) - 1)),
+			gasErr:               errPredicateTooLarge,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			predicateTest := createValidPredicateTest(snowCtx, uint64(numKeys), predicateBytes)
+			predicateTest.Config = &Config{
+				Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+				MaxStorageSlotsBytes: test.maxStorageSlotsBytes,
+			}
+			predicateTest.GasErr = test.gasErr
+			predicateTest.Run(t)
+		})
+	}
+}
+
+func TestWarpOversizedPredicateRejectedBeforeDecoding(t *testing.T) {
+	require := require.New(t)
+
+	// A large blob claiming to be a warp message, which is rejected by its size alone rather than
+	// failing to decode.
+	oversizedPredicate := predicate.PackPredicate(bytes.Repeat([]byte{0x01}, 1<<20))
+	config := &Config{
+		Upgrade:              precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+		MaxStorageSlotsBytes: utils.NewUint64(1 << 10),
+	}
+	_, err := config.PredicateGas(oversizedPredicate)
+	require.ErrorIs(err, errPredicateTooLarge)
+
+	// Without a limit, the same blob is only rejected once decoding it fails.
+	_, err = NewDefaultConfig(utils.NewUint64(0)).PredicateGas(oversizedPredicate)
+	require.ErrorIs(err, ErrWarpParseFailed)
+}
+
+func TestWarpSignersGasOverflow(t *testing.T) {
+	const numKeys = 3
+	predicateBytes := createPredicate(numKeys)
+	// The gas charged regardless of the number of signers.
+	baseGas := GasCostPerSignatureVerification + GasCostPerWarpMessageBytes*uint64(len(predicateBytes))
+
+	tests := map[string]struct {
+		perWarpSigner uint64
+		wantGas       uint64
+		wantErr       error
+	}{
+		"largest per signer gas": {
+			perWarpSigner: (math.MaxUint64 - baseGas) / numKeys,
+			wantGas:       baseGas + numKeys*((math.MaxUint64-baseGas)/numKeys),
+		},
+		"signer gas overflows when added": {
+			perWarpSigner: math.MaxUint64 / numKeys,
+			wantErr:       errOverflowSignersGasCost,
+		},
+		"signer gas overflows when multiplied": {
+			perWarpSigner: math.MaxUint64/numKeys + 1,
+			wantErr:       errOverflowSignersGasCost,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			config := &Config{
+				Upgrade:  precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+				GasCosts: &GasCosts{PerWarpSigner: utils.NewUint64(test.perWarpSigner)},
+			}
+			gas, err := config.PredicateGas(predicateBytes)
+			require.ErrorIs(err, test.wantErr)
+			require.Equal(test.wantGas, gas)
+		})
+	}
+}
+
+func TestWarpMessageWrongNetwork(t *testing.T) {
+	numKeys := 1
+	snowCtx := createSnowCtx([]validatorRange{
+		{
+			start:     0,
+			end:       numKeys,
+			weight:    20,
+			publicKey: true,
+		},
+	})
+	snowCtx.NetworkID = networkID + 1
+
+	test := createValidPredicateTest(snowCtx, uint64(numKeys), createPredicate(numKeys))
+	test.ExpectedErr = ErrWarpWrongChain
+	test.Run(t)
+}
+
+func TestVerifyWarpMessage(t *testing.T) {
+	numKeys := 5
+	snowCtx := createSnowCtx([]validatorRange{
+		{
+			start:     0,
+			end:       numKeys,
+			weight:    20,
+			publicKey: true,
+		},
+	})
+	warpMsg := createWarpMessage(numKeys)
+
+	require.NoError(t, VerifyWarpMessage(context.Background(), warpMsg, networkID, snowCtx.ValidatorState, 1, 0))
+	err := VerifyWarpMessage(context.Background(), warpMsg, networkID+1, snowCtx.ValidatorState, 1, 0)
+	require.ErrorIs(t, err, ErrWarpWrongChain)
+	// A subset of the validators with 4/5 of the weight cannot meet a quorum of 100%.
+	warpMsg = createWarpMessage(numKeys - 1)
+	err = VerifyWarpMessage(context.Background(), warpMsg, networkID, snowCtx.ValidatorState, 1, WarpQuorumDenominator)
+	require.ErrorIs(t, err, ErrWarpSignatureInvalid)
+}
+
+func TestWarpParseErrors(t *testing.T) {
+	for _, err := range []error{
+		errInvalidPredicateBytes,
+		errInvalidWarpMsg,
+		errCannotParseWarpMsg,
+		errInvalidWarpMsgPayload,
+		errInvalidAddressedPayload,
+		errInvalidBlockHashPayload,
+	} {
+		require.ErrorIs(t, err, ErrWarpParseFailed)
+	}
+}
+
 func TestInvalidBitSet(t *testing.T) {
 	addressedCall, err := payload.NewAddressedCall(agoUtils.RandomBytes(20), agoUtils.RandomBytes(100))
 	require.NoError(t, err)
@@ -469,7 +722,7 @@ func TestWarpSignatureWeightsDefaultQuorumNumerator(t *testing.T) {
 		if numSigners >= int(WarpDefaultQuorumNumerator) && numSigners <= int(WarpQuorumDenominator) {
 			expectedErr = nil
 		} else {
-			expectedErr = errFailedVerification
+			expectedErr = ErrWarpSignatureInvalid
 		}
 
 		tests[fmt.Sprintf("default quorum %d signature(s)", numSigners)] = testutils.PredicateTest{
@@ -527,7 +780,7 @@ func TestWarpMultiplePredicates(t *testing.T) {
 			} else {
 				expectedGas = GasCostPerSignatureVerification + uint64(len(invalidPredicateBytes))*GasCostPerWarpMessageBytes + uint64(1)*GasCostPerWarpSigner
 				predicate = invalidPredicateBytes
-				expectedErr = errFailedVerification
+				expectedErr = ErrWarpSignatureInvalid
 			}
 
 			tests[fmt.Sprintf("multiple predicates %v", validMessageIndices)] = testutils.PredicateTest{
@@ -570,7 +823,7 @@ func TestWarpSignatureWeightsNonDefaultQuorumNumerator(t *testing.T) {
 		if numSigners >= nonDefaultQuorumNumerator && numSigners <= int(WarpQuorumDenominator) {
 			expectedErr = nil
 		} else {
-			expectedErr = errFailedVerification
+			expectedErr = ErrWarpSignatureInvalid
 		}
 
 		name := fmt.Sprintf("non-default quorum %d signature(s)", numSigners)
@@ -687,3 +940,27 @@ func TestWarpPredicate(t *testing.T) {
 func BenchmarkWarpPredicate(b *testing.B) {
 	testutils.RunPredicateBenchmarks(b, predicateTests)
 }
+
+func TestCheckPredicatesDefaultMaxMessages(t *testing.T) {
+	require := require.New(t)
+
+	// MaxMessagesPerPredicate is not set, so the default limit applies.
+	rules := params.TestChainConfig.Rules(common.Big0, 0)
+	rules.Predicaters[ContractAddress] = NewDefaultConfig(utils.NewUint64(0))
+	predicateContext := &precompileconfig.PredicateContext{
+		ProposerVMBlockCtx: &block.Context{PChainHeight: 1},
+	}
+	numMessages := WarpDefaultMaxMessagesPerPredicate + 1
+	accessList := make(types.AccessList, 0, numMessages)
+	for i := uint64(0); i < numMessages; i++ {
+		accessList = append(accessList, types.AccessTuple{
+			Address:     ContractAddress,
+			StorageKeys: utils.BytesToHashSlice(createPredicate(1)),
+		})
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{AccessList: accessList, Gas: math.MaxUint64})
+
+	// The transaction is rejected before any of its messages is verified.
+	_, err := core.CheckPredicates(rules, predicateContext, tx)
+	require.ErrorIs(err, core.ErrTooManyPredicates)
+}
@@ -54,6 +54,14 @@ type Predicater interface {
 	VerifyPredicate(predicateContext *PredicateContext, predicateBytes []byte) error
 }
 
+// PredicateLimiter is an optional interface for Predicaters to implement.
+// If implemented and MaxPredicates() returns true, a transaction that includes more than
+// the returned number of predicates for the precompile is rejected before any of its
+// predicates are verified.
+type PredicateLimiter interface {
+	MaxPredicates() (int, bool)
+}
+
 // SharedMemoryWriter defines an interface to allow a precompile's Accepter to write operations
 // into shared memory to be committed atomically on block accept.
 type SharedMemoryWriter interface {
@@ -62,6 +70,9 @@ type SharedMemoryWriter interface {
 
 type WarpMessageWriter interface {
 	AddMessage(unsignedMessage *warp.UnsignedMessage) error
+	// IndexMessage records [messageID] as the warp message sent by the log at [logIndex] in the accepted
+	// block at [blockNumber].
+	IndexMessage(blockNumber uint64, logIndex uint32, messageID ids.ID) error
 }
 
 // AcceptContext defines the context passed in to a precompileconfig's Accepter
@@ -72,7 +83,8 @@ type AcceptContext struct {
 }
 
 // Accepter is an optional interface for StatefulPrecompiledContracts to implement.
-// If implemented, Accept will be called for every log with the address of the precompile when the block is accepted.
+// If implemented, Accept will be called for every log with the address of the precompile when the block is accepted,
+// with the index of the log among all logs of the block.
 // WARNING: If you are implementing a custom precompile, beware that subnet-evm
 // will not maintain backwards compatibility of this interface and your code should not
 // rely on this. Designed for use only by precompiles that ship with subnet-evm.
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// BootstrapPollIntervalEnvVar is the environment variable that overrides the interval between checks
+	// of whether a new blockchain has bootstrapped, as a duration such as "5s".
+	BootstrapPollIntervalEnvVar = "BOOTSTRAP_POLL_INTERVAL"
+	// BootstrapTimeoutEnvVar is the environment variable that overrides the maximum time to wait for a new
+	// blockchain to bootstrap, as a duration such as "5m".
+	BootstrapTimeoutEnvVar = "BOOTSTRAP_TIMEOUT"
+
+	// Default interval between checks of whether a new blockchain has bootstrapped
+	DefaultBootstrapPollInterval = 2 * time.Second
+	// Default maximum time to wait for a new blockchain to bootstrap
+	DefaultBootstrapTimeout = 3 * time.Minute
+	// Interval between logs of a blockchain that is still bootstrapping
+	bootstrapProgressInterval = 15 * time.Second
+)
+
+var errNotBootstrapped = errors.New("blockchain did not bootstrap")
+
+// durationFromEnv returns the duration set in the environment variable [envVar], or [defaultValue] if
+// it is unset. It returns an error if the variable is not a positive duration.
+func durationFromEnv(envVar string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(envVar)
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", envVar, value, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be positive", envVar, value)
+	}
+	return duration, nil
+}
+
+// awaitBootstrapped confirms the blockchain [blockchainID] is ready by polling the info API of
+// [DefaultLocalNodeURI] every BOOTSTRAP_POLL_INTERVAL until it reports the blockchain as bootstrapped,
+// logging its progress periodically. It fails once BOOTSTRAP_TIMEOUT elapses or [ctx] is done, with
+// the last status reported by the node, so that a slow bootstrap can be told apart from an unreachable node.
+func awaitBootstrapped(ctx context.Context, blockchainID ids.ID) error {
+	pollInterval, err := durationFromEnv(BootstrapPollIntervalEnvVar, DefaultBootstrapPollInterval)
+	if err != nil {
+		return err
+	}
+	timeout, err := durationFromEnv(BootstrapTimeoutEnvVar, DefaultBootstrapTimeout)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	infoClient := info.NewClient(DefaultLocalNodeURI)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	var (
+		start        = time.Now()
+		lastProgress = start
+		polls        int
+		// The last response of the node, which is an error if the node could not be reached.
+		bootstrapped bool
+		lastErr      error
+	)
+	for {
+		bootstrapped, lastErr = infoClient.IsBootstrapped(ctx, blockchainID.String())
+		polls++
+		if lastErr == nil && bootstrapped {
+			log.Info("Blockchain bootstrapped", "blockchainID", blockchainID, "elapsed", time.Since(start))
+			return nil
+		}
+		if time.Since(lastProgress) >= bootstrapProgressInterval {
+			lastProgress = time.Now()
+			log.Info("Waiting for blockchain to bootstrap", "blockchainID", blockchainID, "elapsed", time.Since(start),
+				"timeout", timeout, "polls", polls, "lastErr", lastErr)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			status := "not bootstrapped"
+			if lastErr != nil {
+				status = fmt.Sprintf("status request failed: %s", lastErr)
+			}
+			return fmt.Errorf("%w: %s after %s (timeout %s, %d polls every %s, last status: %s): %w",
+				errNotBootstrapped, blockchainID, time.Since(start).Round(time.Millisecond), timeout, polls, pollInterval, status, ctx.Err())
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/subnet-evm/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/go-cmd/cmd"
 	"github.com/onsi/ginkgo/v2"
@@ -98,6 +99,8 @@ func RunHardhatTests(ctx context.Context, blockchainID string, execPath string,
 	RunHardhatTestsCustomURI(ctx, chainURI, execPath, testPath)
 }
 
+// RunHardhatTestsCustomURI runs the hardhat tests in the given [testPath] on the blockchain served at [chainURI].
+// If GasReportDirEnvVar is set, the GasReport of the txs issued by the tests is written to its directory.
 func RunHardhatTestsCustomURI(ctx context.Context, chainURI string, execPath string, testPath string) {
 	log.Info(
 		"Executing HardHat tests on blockchain",
@@ -113,7 +116,29 @@ func RunHardhatTestsCustomURI(ctx context.Context, chainURI string, execPath str
 	gomega.Expect(err).Should(gomega.BeNil())
 	log.Info("Running test command", "cmd", cmd.String())
 
+	reportPath := gasReportPath(testPath)
+	var (
+		client      ethclient.Client
+		startHeight uint64
+	)
+	if reportPath != "" {
+		client, err = ethclient.Dial(chainURI)
+		gomega.Expect(err).Should(gomega.BeNil())
+		defer client.Close()
+		startHeight, err = client.BlockNumber(ctx)
+		gomega.Expect(err).Should(gomega.BeNil())
+	}
+
 	out, err := cmd.CombinedOutput()
 	fmt.Printf("\nCombined output:\n\n%s\n", string(out))
 	gomega.Expect(err).Should(gomega.BeNil())
+
+	if reportPath != "" {
+		endHeight, err := client.BlockNumber(ctx)
+		gomega.Expect(err).Should(gomega.BeNil())
+		report, err := CollectGasReport(ctx, client, startHeight, endHeight)
+		gomega.Expect(err).Should(gomega.BeNil())
+		gomega.Expect(report.Write(reportPath)).Should(gomega.Succeed())
+		log.Info("Wrote gas report", "testPath", testPath, "reportPath", reportPath)
+	}
 }
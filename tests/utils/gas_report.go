@@ -0,0 +1,116 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/precompile/modules"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// GasReportDirEnvVar is the environment variable that enables gas reports of the hardhat tests.
+// If set, the gas report of each hardhat test is written to <GAS_REPORT_DIR>/<test>.json.
+const GasReportDirEnvVar = "GAS_REPORT_DIR"
+
+// MethodGasUsage is the gas used by the txs calling a single precompile method.
+type MethodGasUsage struct {
+	Calls      uint64 `json:"calls"`
+	TotalGas   uint64 `json:"totalGas"`
+	MinGas     uint64 `json:"minGas"`
+	MaxGas     uint64 `json:"maxGas"`
+	AverageGas uint64 `json:"averageGas"`
+}
+
+func (u *MethodGasUsage) add(gasUsed uint64) {
+	if u.Calls == 0 || gasUsed < u.MinGas {
+		u.MinGas = gasUsed
+	}
+	if gasUsed > u.MaxGas {
+		u.MaxGas = gasUsed
+	}
+	u.Calls++
+	u.TotalGas += gasUsed
+	u.AverageGas = u.TotalGas / u.Calls
+}
+
+// GasReport maps the config key of each precompile to the gas used by the txs calling each of its
+// methods, keyed by the hex encoded 4 byte selector of the method.
+type GasReport map[string]map[string]*MethodGasUsage
+
+// CollectGasReport returns the GasReport of the txs in blocks (fromBlock, toBlock] served by [client].
+// Only txs sent directly to a precompile are included, and each is charged the gas used by the whole tx
+// as reported by its receipt, including the intrinsic gas.
+func CollectGasReport(ctx context.Context, client ethclient.Client, fromBlock, toBlock uint64) (GasReport, error) {
+	precompiles := make(map[common.Address]string)
+	for _, module := range modules.RegisteredModules() {
+		precompiles[module.Address] = module.ConfigKey
+	}
+
+	report := make(GasReport)
+	for height := fromBlock + 1; height <= toBlock; height++ {
+		block, err := client.BlockByNumber(ctx, new(big.Int).SetUint64(height))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block %d: %w", height, err)
+		}
+		for _, tx := range block.Transactions() {
+			if tx.To() == nil {
+				continue
+			}
+			precompile, ok := precompiles[*tx.To()]
+			if !ok {
+				continue
+			}
+			receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch receipt of tx %s: %w", tx.Hash(), err)
+			}
+			// Calls without a full selector are reported under the empty selector.
+			selector := "0x"
+			if data := tx.Data(); len(data) >= 4 {
+				selector = hexutil.Encode(data[:4])
+			}
+			methods, ok := report[precompile]
+			if !ok {
+				methods = make(map[string]*MethodGasUsage)
+				report[precompile] = methods
+			}
+			usage, ok := methods[selector]
+			if !ok {
+				usage = &MethodGasUsage{}
+				methods[selector] = usage
+			}
+			usage.add(receipt.GasUsed)
+		}
+	}
+	return report, nil
+}
+
+// Write writes [r] to [path] as indented json.
+func (r GasReport) Write(path string) error {
+	reportBytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, reportBytes, 0o644)
+}
+
+// gasReportPath returns the path to write the gas report of the hardhat test at [testPath] to, or the
+// empty string if gas reports are not enabled.
+func gasReportPath(testPath string) string {
+	dir := os.Getenv(GasReportDirEnvVar)
+	if dir == "" {
+		return ""
+	}
+	testName := strings.TrimSuffix(filepath.Base(testPath), filepath.Ext(testPath))
+	return filepath.Join(dir, testName+".json")
+}
@@ -7,17 +7,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/ava-labs/avalanchego/api/health"
-	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
 	wallet "github.com/ava-labs/avalanchego/wallet/subnet/primary"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/plugin/evm"
@@ -25,6 +25,7 @@ import (
 	"github.com/go-cmd/cmd"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
+	"golang.org/x/sync/errgroup"
 )
 
 type SubnetSuite struct {
@@ -81,10 +82,7 @@ func CreateSubnetsSuite(genesisFiles map[string]string) *SubnetSuite {
 		log.Info("AvalancheGo node is healthy")
 
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		blockchainIDs := make(map[string]string)
-		for alias, file := range genesisFiles {
-			blockchainIDs[alias] = CreateNewSubnet(ctx, file)
-		}
+		blockchainIDs := CreateNewSubnets(ctx, genesisFiles)
 
 		blockchainIDsBytes, err := json.Marshal(blockchainIDs)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
@@ -108,9 +106,118 @@ func CreateSubnetsSuite(genesisFiles map[string]string) *SubnetSuite {
 	return &globalSuite
 }
 
+// FeeConfigOverride overrides fields of the fee config of a genesis. Nil fields keep the value of the genesis.
+type FeeConfigOverride struct {
+	// GasLimit overrides the block gas limit, in both the fee config and the genesis header.
+	GasLimit *big.Int
+	// MinBaseFee overrides the minimum base fee.
+	MinBaseFee *big.Int
+	// TargetBlockRate overrides the target rate of block production in seconds.
+	TargetBlockRate *uint64
+}
+
+// GenesisWithFeeConfig returns the bytes of the genesis in [genesisFilePath] with its fee config
+// overridden by [override], so that the same genesis can be benchmarked with different fee parameters.
+func GenesisWithFeeConfig(genesisFilePath string, override FeeConfigOverride) ([]byte, error) {
+	genesisBytes, err := os.ReadFile(genesisFilePath)
+	if err != nil {
+		return nil, err
+	}
+	genesis := &core.Genesis{}
+	if err := json.Unmarshal(genesisBytes, genesis); err != nil {
+		return nil, fmt.Errorf("failed to parse genesis file %s: %w", genesisFilePath, err)
+	}
+	if genesis.Config == nil {
+		return nil, fmt.Errorf("genesis file %s has no chain config", genesisFilePath)
+	}
+
+	feeConfig := &genesis.Config.FeeConfig
+	if override.GasLimit != nil {
+		if !override.GasLimit.IsUint64() {
+			return nil, fmt.Errorf("invalid gas limit %d", override.GasLimit)
+		}
+		feeConfig.GasLimit = new(big.Int).Set(override.GasLimit)
+		// The gas limit of the genesis header must match the gas limit of the fee config.
+		genesis.GasLimit = override.GasLimit.Uint64()
+	}
+	if override.MinBaseFee != nil {
+		feeConfig.MinBaseFee = new(big.Int).Set(override.MinBaseFee)
+	}
+	if override.TargetBlockRate != nil {
+		feeConfig.TargetBlockRate = *override.TargetBlockRate
+	}
+	if err := feeConfig.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid fee config override: %w", err)
+	}
+	return json.Marshal(genesis)
+}
+
 // CreateNewSubnet creates a new subnet and Subnet-EVM blockchain with the given genesis file.
 // returns the ID of the new created blockchain.
 func CreateNewSubnet(ctx context.Context, genesisFilePath string) string {
+	wd, err := os.Getwd()
+	gomega.Expect(err).Should(gomega.BeNil())
+	log.Info("Reading genesis file", "filePath", genesisFilePath, "wd", wd)
+	genesisBytes, err := os.ReadFile(genesisFilePath)
+	gomega.Expect(err).Should(gomega.BeNil())
+
+	return CreateNewSubnetWithGenesis(ctx, genesisBytes)
+}
+
+// CreateNewSubnetWithFeeConfig creates a new subnet and Subnet-EVM blockchain with the given genesis file,
+// with its fee config overridden by [override].
+// returns the ID of the new created blockchain.
+func CreateNewSubnetWithFeeConfig(ctx context.Context, genesisFilePath string, override FeeConfigOverride) string {
+	genesisBytes, err := GenesisWithFeeConfig(genesisFilePath, override)
+	gomega.Expect(err).Should(gomega.BeNil())
+
+	return CreateNewSubnetWithGenesis(ctx, genesisBytes)
+}
+
+// CreateNewSubnetWithGenesis creates a new subnet and Subnet-EVM blockchain with the given genesis bytes.
+// returns the ID of the new created blockchain.
+func CreateNewSubnetWithGenesis(ctx context.Context, genesisBytes []byte) string {
+	pWallet := newPWallet(ctx)
+	blockchainID := issueCreateSubnetAndChain(pWallet, genesisBytes)
+	gomega.Expect(awaitBootstrapped(ctx, blockchainID)).Should(gomega.BeNil())
+
+	// Return the blockchainID of the newly created blockchain
+	return blockchainID.String()
+}
+
+// CreateNewSubnets creates a new subnet and Subnet-EVM blockchain for each of the given [genesisFiles],
+// which maps test aliases to genesis file paths, and waits for the blockchains to bootstrap concurrently.
+// returns a map of the test aliases to the IDs of the new created blockchains.
+func CreateNewSubnets(ctx context.Context, genesisFiles map[string]string) map[string]string {
+	// The txs are issued serially, since the wallet tracks its UTXOs locally and is not safe for
+	// concurrent use. Issuance is quick compared to bootstrapping, which is awaited concurrently.
+	pWallet := newPWallet(ctx)
+	blockchainIDs := make(map[string]ids.ID, len(genesisFiles))
+	for alias, genesisFilePath := range genesisFiles {
+		log.Info("Reading genesis file", "alias", alias, "filePath", genesisFilePath)
+		genesisBytes, err := os.ReadFile(genesisFilePath)
+		gomega.Expect(err).Should(gomega.BeNil())
+		blockchainIDs[alias] = issueCreateSubnetAndChain(pWallet, genesisBytes)
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, blockchainID := range blockchainIDs {
+		blockchainID := blockchainID
+		eg.Go(func() error {
+			return awaitBootstrapped(egCtx, blockchainID)
+		})
+	}
+	gomega.Expect(eg.Wait()).Should(gomega.BeNil())
+
+	blockchainIDStrs := make(map[string]string, len(blockchainIDs))
+	for alias, blockchainID := range blockchainIDs {
+		blockchainIDStrs[alias] = blockchainID.String()
+	}
+	return blockchainIDStrs
+}
+
+// newPWallet returns a P-Chain wallet funded by the EWOQ key on the network that [DefaultLocalNodeURI] is hosting.
+func newPWallet(ctx context.Context) p.Wallet {
 	kc := secp256k1fx.NewKeychain(genesis.EWOQKey)
 
 	// MakeWallet fetches the available UTXOs owned by [kc] on the network
@@ -122,8 +229,12 @@ func CreateNewSubnet(ctx context.Context, genesisFilePath string) string {
 	})
 	gomega.Expect(err).Should(gomega.BeNil())
 
-	pWallet := wallet.P()
+	return wallet.P()
+}
 
+// issueCreateSubnetAndChain issues the txs to create a new subnet and a Subnet-EVM blockchain with
+// [genesisBytes] on it, and returns the ID of the new blockchain.
+func issueCreateSubnetAndChain(pWallet p.Wallet, genesisBytes []byte) ids.ID {
 	owner := &secp256k1fx.OutputOwners{
 		Threshold: 1,
 		Addrs: []ids.ShortID{
@@ -131,12 +242,6 @@ func CreateNewSubnet(ctx context.Context, genesisFilePath string) string {
 		},
 	}
 
-	wd, err := os.Getwd()
-	gomega.Expect(err).Should(gomega.BeNil())
-	log.Info("Reading genesis file", "filePath", genesisFilePath, "wd", wd)
-	genesisBytes, err := os.ReadFile(genesisFilePath)
-	gomega.Expect(err).Should(gomega.BeNil())
-
 	log.Info("Creating new subnet")
 	createSubnetTx, err := pWallet.IssueCreateSubnetTx(owner)
 	gomega.Expect(err).Should(gomega.BeNil())
@@ -154,16 +259,7 @@ func CreateNewSubnet(ctx context.Context, genesisFilePath string) string {
 		"testChain",
 	)
 	gomega.Expect(err).Should(gomega.BeNil())
-	createChainTxID := createChainTx.ID()
-
-	// Confirm the new blockchain is ready by waiting for the readiness endpoint
-	infoClient := info.NewClient(DefaultLocalNodeURI)
-	bootstrapped, err := info.AwaitBootstrapped(ctx, infoClient, createChainTxID.String(), 2*time.Second)
-	gomega.Expect(err).Should(gomega.BeNil())
-	gomega.Expect(bootstrapped).Should(gomega.BeTrue())
-
-	// Return the blockchainID of the newly created blockchain
-	return createChainTxID.String()
+	return createChainTx.ID()
 }
 
 // GetDefaultChainURI returns the default chain URI for a given blockchainID
@@ -8,6 +8,8 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
+	"errors"
+	"flag"
 	"fmt"
 	"math/big"
 	"os"
@@ -55,6 +57,9 @@ import (
 const (
 	subnetAName = "warp-subnet-a"
 	subnetBName = "warp-subnet-b"
+
+	// confirmReachedTipTimeout bounds the time for every node to sync to the tip after a loader completes
+	confirmReachedTipTimeout = 2 * time.Minute
 )
 
 var (
@@ -67,11 +72,19 @@ var (
 	subnetA, subnetB, cChainSubnetDetails *Subnet
 
 	testPayload = []byte{1, 2, 3}
+
+	// Contract on the receiving chain to deliver warp messages to in the warp load test and its method
+	// called with the index of the warp message in the predicate.
+	warpReceiverAddress string
+	warpReceiverMethod  string
 )
 
 func init() {
 	// Configures flags used to configure tmpnet (via SynchronizedBeforeSuite)
 	flagVars = e2e.RegisterFlags()
+
+	flag.StringVar(&warpReceiverAddress, "warp-receiver-address", "", "address of the contract on the receiving chain to deliver warp messages to in the warp load test (empty calls getVerifiedWarpMessage on the warp precompile)")
+	flag.StringVar(&warpReceiverMethod, "warp-receiver-method", "receiveWarpMessage(uint32)", "signature of the method of the warp receiver contract called with the index of the warp message, which must take a single uint32")
 }
 
 // Subnet provides the basic details of a created subnet
@@ -127,7 +140,8 @@ var _ = ginkgo.SynchronizedBeforeSuite(func() []byte {
 	}
 
 	tmpnetSubnetA := network.GetSubnet(subnetAName)
-	require.NotNil(tmpnetSubnetA)
+	require.NotNil(tmpnetSubnetA, "network has no subnet %s", subnetAName)
+	require.NotEmpty(tmpnetSubnetA.Chains, "subnet %s has no chains", subnetAName)
 	subnetA = &Subnet{
 		SubnetID:      tmpnetSubnetA.SubnetID,
 		BlockchainID:  tmpnetSubnetA.Chains[0].ChainID,
@@ -136,7 +150,8 @@ var _ = ginkgo.SynchronizedBeforeSuite(func() []byte {
 	}
 
 	tmpnetSubnetB := network.GetSubnet(subnetBName)
-	require.NotNil(tmpnetSubnetB)
+	require.NotNil(tmpnetSubnetB, "network has no subnet %s", subnetBName)
+	require.NotEmpty(tmpnetSubnetB.Chains, "subnet %s has no chains", subnetBName)
 	subnetB = &Subnet{
 		SubnetID:      tmpnetSubnetB.SubnetID,
 		BlockchainID:  tmpnetSubnetB.Chains[0].ChainID,
@@ -609,13 +624,14 @@ func (w *warpTest) warpLoad() {
 	chainBKeys, chainBPrivateKeys := generateKeys(w.receivingSubnetFundedKey, numWorkers)
 
 	loadMetrics := metrics.NewDefaultMetrics()
+	fundingOpts := load.FundingOptions{RetryPolicy: load.DefaultFundingRetryPolicy}
 
 	log.Info("Distributing funds on sending subnet", "numKeys", len(chainAKeys))
-	chainAKeys, err := load.DistributeFunds(ctx, sendingClient, chainAKeys, len(chainAKeys), new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether)), loadMetrics)
+	chainAKeys, err := load.DistributeFunds(ctx, sendingClient, chainAKeys, len(chainAKeys), new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether)), loadMetrics, fundingOpts)
 	require.NoError(err)
 
 	log.Info("Distributing funds on receiving subnet", "numKeys", len(chainBKeys))
-	_, err = load.DistributeFunds(ctx, w.receivingSubnetClients[0], chainBKeys, len(chainBKeys), new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether)), loadMetrics)
+	_, err = load.DistributeFunds(ctx, w.receivingSubnetClients[0], chainBKeys, len(chainBKeys), new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether)), loadMetrics, fundingOpts)
 	require.NoError(err)
 
 	log.Info("Creating workers for each subnet...")
@@ -628,6 +644,9 @@ func (w *warpTest) warpLoad() {
 		chainBWorkers = append(chainBWorkers, load.NewTxReceiptWorker(ctx, w.receivingSubnetClients[i]))
 	}
 
+	receiver, receiverInput, err := w.warpLoadReceiver(ctx)
+	require.NoError(err)
+
 	log.Info("Subscribing to warp send events on sending subnet")
 	logs := make(chan types.Log, numWorkers*int(txsPerWorker))
 	sub, err := sendingClient.SubscribeFilterLogs(ctx, interfaces.FilterQuery{
@@ -641,81 +660,157 @@ func (w *warpTest) warpLoad() {
 	}()
 
 	log.Info("Generating tx sequence to send warp messages...")
-	warpSendSequences, err := txs.GenerateTxSequences(ctx, func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
-		data, err := warp.PackSendWarpMessage([]byte(fmt.Sprintf("Jets %d-%d Dolphins", key.X.Int64(), nonce)))
-		if err != nil {
-			return nil, err
-		}
-		tx := types.NewTx(&types.DynamicFeeTx{
-			ChainID:   w.sendingSubnetChainID,
-			Nonce:     nonce,
-			To:        &warp.Module.Address,
-			Gas:       200_000,
-			GasFeeCap: big.NewInt(225 * params.GWei),
-			GasTipCap: big.NewInt(params.GWei),
-			Value:     common.Big0,
-			Data:      data,
-		})
-		return types.SignTx(tx, w.sendingSubnetSigner, key)
+	warpSendSequences, err := txs.GenerateTxSequencesFrom(ctx, &warpSendTxGenerator{
+		chainID: w.sendingSubnetChainID,
+		signer:  w.sendingSubnetSigner,
 	}, w.sendingSubnetClients[0], chainAPrivateKeys, txsPerWorker, false)
 	require.NoError(err)
 	log.Info("Executing warp send loader...")
-	warpSendLoader := load.New(chainAWorkers, warpSendSequences, batchSize, loadMetrics)
+	warpSendLoader := load.New(chainAWorkers, warpSendSequences, batchSize, loadMetrics, load.LoaderOptions{})
 	// TODO: execute send and receive loaders concurrently.
 	require.NoError(warpSendLoader.Execute(ctx))
-	require.NoError(warpSendLoader.ConfirmReachedTip(ctx))
+	require.NoError(warpSendLoader.ConfirmReachedTip(ctx, confirmReachedTipTimeout, load.DefaultTipPollMaxInterval))
 
-	warpClient, err := warpBackend.NewClient(w.sendingSubnetURIs[0], w.sendingSubnet.BlockchainID.String())
-	require.NoError(err)
 	subnetIDStr := ""
 	if w.sendingSubnet.SubnetID == constants.PrimaryNetworkID {
 		subnetIDStr = w.receivingSubnet.SubnetID.String()
 	}
 
 	log.Info("Executing warp delivery sequences...")
-	warpDeliverSequences, err := txs.GenerateTxSequences(ctx, func(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
-		// Wait for the next warp send log
-		warpLog := <-logs
-
-		unsignedMessage, err := warp.UnpackSendWarpEventDataToMessage(warpLog.Data)
-		if err != nil {
-			return nil, err
-		}
-		log.Info("Fetching addressed call aggregate signature via p2p API")
-
-		signedWarpMessageBytes, err := warpClient.GetMessageAggregateSignature(ctx, unsignedMessage.ID(), warp.WarpDefaultQuorumNumerator, subnetIDStr)
-		if err != nil {
-			return nil, err
-		}
-
-		packedInput, err := warp.PackGetVerifiedWarpMessage(0)
-		if err != nil {
-			return nil, err
-		}
-		tx := predicate.NewPredicateTx(
-			w.receivingSubnetChainID,
-			nonce,
-			&warp.Module.Address,
-			5_000_000,
-			big.NewInt(225*params.GWei),
-			big.NewInt(params.GWei),
-			common.Big0,
-			packedInput,
-			types.AccessList{},
-			warp.ContractAddress,
-			signedWarpMessageBytes,
-		)
-		return types.SignTx(tx, w.receivingSubnetSigner, key)
+	warpDeliverSequences, err := txs.GenerateTxSequencesFrom(ctx, &warpDeliverTxGenerator{
+		ctx:                ctx,
+		logs:               logs,
+		sourceURI:          w.sendingSubnetURIs[0],
+		sourceBlockchainID: w.sendingSubnet.BlockchainID.String(),
+		signingSubnetID:    subnetIDStr,
+		receiver:           receiver,
+		receiverInput:      receiverInput,
+		chainID:            w.receivingSubnetChainID,
+		signer:             w.receivingSubnetSigner,
 	}, w.receivingSubnetClients[0], chainBPrivateKeys, txsPerWorker, true)
 	require.NoError(err)
 
 	log.Info("Executing warp delivery...")
-	warpDeliverLoader := load.New(chainBWorkers, warpDeliverSequences, batchSize, loadMetrics)
+	warpDeliverLoader := load.New(chainBWorkers, warpDeliverSequences, batchSize, loadMetrics, load.LoaderOptions{})
 	require.NoError(warpDeliverLoader.Execute(ctx))
-	require.NoError(warpSendLoader.ConfirmReachedTip(ctx))
+	require.NoError(warpSendLoader.ConfirmReachedTip(ctx, confirmReachedTipTimeout, load.DefaultTipPollMaxInterval))
 	log.Info("Completed warp delivery successfully.")
 }
 
+// warpLoadReceiver returns the address called by the txs delivering warp messages in the warp load
+// test and their input. Unless --warp-receiver-address is set, getVerifiedWarpMessage is called on
+// the warp precompile directly.
+func (w *warpTest) warpLoadReceiver(ctx context.Context) (common.Address, []byte, error) {
+	if warpReceiverAddress == "" {
+		input, err := warp.PackGetVerifiedWarpMessage(0)
+		return warp.Module.Address, input, err
+	}
+	if !common.IsHexAddress(warpReceiverAddress) {
+		return common.Address{}, nil, fmt.Errorf("invalid warp receiver address %q", warpReceiverAddress)
+	}
+	receiver := common.HexToAddress(warpReceiverAddress)
+	code, err := w.receivingSubnetClients[0].CodeAt(ctx, receiver, nil)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("failed to fetch code of warp receiver %s: %w", receiver, err)
+	}
+	if len(code) == 0 {
+		return common.Address{}, nil, fmt.Errorf("warp receiver %s is not a contract on the receiving chain", receiver)
+	}
+	if !strings.HasSuffix(warpReceiverMethod, "(uint32)") {
+		return common.Address{}, nil, errors.New("warp receiver method must take a single uint32")
+	}
+	// Each delivery tx includes a single warp message, so the index of the message is always 0.
+	input := append(crypto.Keccak256([]byte(warpReceiverMethod))[:4], make([]byte, common.HashLength)...)
+	log.Info("Delivering warp messages to receiver contract", "receiver", receiver, "method", warpReceiverMethod)
+	return receiver, input, nil
+}
+
+// warpSendTxGenerator generates txs that send a warp message with a payload unique to the key and nonce.
+type warpSendTxGenerator struct {
+	chainID *big.Int
+	signer  types.Signer
+}
+
+func (*warpSendTxGenerator) Setup(context.Context) error {
+	return nil
+}
+
+func (g *warpSendTxGenerator) GenerateTx(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+	data, err := warp.PackSendWarpMessage([]byte(fmt.Sprintf("Jets %d-%d Dolphins", key.X.Int64(), nonce)))
+	if err != nil {
+		return nil, err
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   g.chainID,
+		Nonce:     nonce,
+		To:        &warp.Module.Address,
+		Gas:       200_000,
+		GasFeeCap: big.NewInt(225 * params.GWei),
+		GasTipCap: big.NewInt(params.GWei),
+		Value:     common.Big0,
+		Data:      data,
+	})
+	return types.SignTx(tx, g.signer, key)
+}
+
+// warpDeliverTxGenerator generates txs that deliver the next warp message sent in [logs], with
+// an aggregate signature fetched from the source node, by calling [receiver] with [receiverInput].
+type warpDeliverTxGenerator struct {
+	ctx                context.Context
+	logs               <-chan types.Log
+	sourceURI          string
+	sourceBlockchainID string
+	signingSubnetID    string
+	receiver           common.Address
+	receiverInput      []byte
+	chainID            *big.Int
+	signer             types.Signer
+
+	// Set in Setup
+	warpClient warpBackend.Client
+}
+
+// Setup creates the client to fetch aggregate signatures from the source node.
+func (g *warpDeliverTxGenerator) Setup(context.Context) error {
+	warpClient, err := warpBackend.NewClient(g.sourceURI, g.sourceBlockchainID)
+	if err != nil {
+		return err
+	}
+	g.warpClient = warpClient
+	return nil
+}
+
+func (g *warpDeliverTxGenerator) GenerateTx(key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+	// Wait for the next warp send log
+	warpLog := <-g.logs
+
+	unsignedMessage, err := warp.UnpackSendWarpEventDataToMessage(warpLog.Data)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Fetching addressed call aggregate signature via p2p API")
+
+	signedWarpMessageBytes, err := g.warpClient.GetMessageAggregateSignature(g.ctx, unsignedMessage.ID(), warp.WarpDefaultQuorumNumerator, g.signingSubnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := predicate.NewPredicateTx(
+		g.chainID,
+		nonce,
+		&g.receiver,
+		5_000_000,
+		big.NewInt(225*params.GWei),
+		big.NewInt(params.GWei),
+		common.Big0,
+		g.receiverInput,
+		types.AccessList{},
+		warp.ContractAddress,
+		signedWarpMessageBytes,
+	)
+	return types.SignTx(tx, g.signer, key)
+}
+
 func generateKeys(preFundedKey *ecdsa.PrivateKey, numWorkers int) ([]*key.Key, []*ecdsa.PrivateKey) {
 	keys := []*key.Key{
 		key.CreateKey(preFundedKey),
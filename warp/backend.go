@@ -5,6 +5,7 @@ package warp
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -27,6 +29,11 @@ var (
 
 const batchSize = ethdb.IdealBatchSize
 
+// messageIndexPrefix prefixes the keys mapping (block height, index) to the ID of a warp
+// message sent in an accepted block. These keys never collide with message ID keys since
+// they have a different length.
+var messageIndexPrefix = []byte("index")
+
 type BlockClient interface {
 	GetBlock(ctx context.Context, blockID ids.ID) (snowman.Block, error)
 }
@@ -46,6 +53,13 @@ type Backend interface {
 	// GetMessage retrieves the [unsignedMessage] from the warp backend database if available
 	GetMessage(messageHash ids.ID) (*avalancheWarp.UnsignedMessage, error)
 
+	// IndexMessage records [messageID] as the warp message sent by the log at [logIndex] in the accepted
+	// block at [blockNumber].
+	IndexMessage(blockNumber uint64, logIndex uint32, messageID ids.ID) error
+
+	// GetMessageAtIndex retrieves the warp message sent by the log at [index] in the accepted block at [blockNumber]
+	GetMessageAtIndex(blockNumber uint64, index uint32) (*avalancheWarp.UnsignedMessage, error)
+
 	// Clear clears the entire db
 	Clear() error
 }
@@ -217,3 +231,32 @@ func (b *backend) GetMessage(messageID ids.ID) (*avalancheWarp.UnsignedMessage,
 
 	return unsignedMessage, nil
 }
+
+func (b *backend) IndexMessage(blockNumber uint64, logIndex uint32, messageID ids.ID) error {
+	if err := b.db.Put(messageIndexKey(blockNumber, logIndex), messageID[:]); err != nil {
+		return fmt.Errorf("failed to put warp message index in db: %w", err)
+	}
+	log.Debug("Indexing warp message", "messageID", messageID, "blockNumber", blockNumber, "logIndex", logIndex)
+	return nil
+}
+
+func (b *backend) GetMessageAtIndex(blockNumber uint64, index uint32) (*avalancheWarp.UnsignedMessage, error) {
+	messageIDBytes, err := b.db.Get(messageIndexKey(blockNumber, index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warp message index (%d, %d) from db: %w", blockNumber, index, err)
+	}
+	messageID, err := ids.ToID(messageIDBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse warp message ID at index (%d, %d): %w", blockNumber, index, err)
+	}
+	return b.GetMessage(messageID)
+}
+
+// messageIndexKey returns the database key for the warp message sent by the log at [index] in the block at [blockNumber].
+func messageIndexKey(blockNumber uint64, index uint32) []byte {
+	key := make([]byte, len(messageIndexPrefix)+wrappers.LongLen+wrappers.IntLen)
+	copy(key, messageIndexPrefix)
+	binary.BigEndian.PutUint64(key[len(messageIndexPrefix):], blockNumber)
+	binary.BigEndian.PutUint32(key[len(messageIndexPrefix)+wrappers.LongLen:], index)
+	return key
+}
@@ -124,6 +124,50 @@ func TestAddAndGetUnknownMessage(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestIndexMessages(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend, err := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500, nil)
+	require.NoError(err)
+
+	// Index the messages of two logs at height 1 and of one log at height 2
+	positions := []struct {
+		height   uint64
+		logIndex uint32
+	}{
+		{height: 1, logIndex: 0},
+		{height: 1, logIndex: 3},
+		{height: 2, logIndex: 1},
+	}
+	msgs := make([]*avalancheWarp.UnsignedMessage, 0, len(positions))
+	for i, position := range positions {
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, []byte{byte(i)})
+		require.NoError(err)
+		require.NoError(backend.AddMessage(unsignedMsg))
+		require.NoError(backend.IndexMessage(position.height, position.logIndex, unsignedMsg.ID()))
+		msgs = append(msgs, unsignedMsg)
+	}
+	// Indexing the messages of an accepted block again, such as on a replay, leaves the index unchanged.
+	for i, position := range positions {
+		require.NoError(backend.IndexMessage(position.height, position.logIndex, msgs[i].ID()))
+	}
+
+	for i, position := range positions {
+		msg, err := backend.GetMessageAtIndex(position.height, position.logIndex)
+		require.NoError(err)
+		require.Equal(msgs[i].Bytes(), msg.Bytes())
+	}
+
+	_, err = backend.GetMessageAtIndex(1, 1)
+	require.ErrorContains(err, "failed to get warp message index")
+	_, err = backend.GetMessageAtIndex(3, 0)
+	require.ErrorContains(err, "failed to get warp message index")
+}
+
 func TestGetBlockSignature(t *testing.T) {
 	require := require.New(t)
 
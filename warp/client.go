@@ -16,6 +16,7 @@ var _ Client = (*client)(nil)
 
 type Client interface {
 	GetMessage(ctx context.Context, messageID ids.ID) ([]byte, error)
+	GetMessageAtIndex(ctx context.Context, blockNumber uint64, index uint32) ([]byte, error)
 	GetMessageSignature(ctx context.Context, messageID ids.ID) ([]byte, error)
 	GetMessageAggregateSignature(ctx context.Context, messageID ids.ID, quorumNum uint64, subnetIDStr string) ([]byte, error)
 	GetBlockSignature(ctx context.Context, blockID ids.ID) ([]byte, error)
@@ -46,6 +47,14 @@ func (c *client) GetMessage(ctx context.Context, messageID ids.ID) ([]byte, erro
 	return res, nil
 }
 
+func (c *client) GetMessageAtIndex(ctx context.Context, blockNumber uint64, index uint32) ([]byte, error) {
+	var res hexutil.Bytes
+	if err := c.client.CallContext(ctx, &res, "warp_getMessageAtIndex", hexutil.Uint64(blockNumber), hexutil.Uint(index)); err != nil {
+		return nil, fmt.Errorf("call to warp_getMessageAtIndex failed. err: %w", err)
+	}
+	return res, nil
+}
+
 func (c *client) GetMessageSignature(ctx context.Context, messageID ids.ID) ([]byte, error) {
 	var res hexutil.Bytes
 	if err := c.client.CallContext(ctx, &res, "warp_getMessageSignature", messageID); err != nil {
@@ -49,6 +49,15 @@ func (a *API) GetMessage(ctx context.Context, messageID ids.ID) (hexutil.Bytes,
 	return hexutil.Bytes(message.Bytes()), nil
 }
 
+// GetMessageAtIndex returns the Warp message sent by the log at [index] in the accepted block at [blockNumber].
+func (a *API) GetMessageAtIndex(ctx context.Context, blockNumber hexutil.Uint64, index hexutil.Uint) (hexutil.Bytes, error) {
+	message, err := a.backend.GetMessageAtIndex(uint64(blockNumber), uint32(index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message at index (%d, %d) with error %w", blockNumber, index, err)
+	}
+	return hexutil.Bytes(message.Bytes()), nil
+}
+
 // GetMessageSignature returns the BLS signature associated with a messageID.
 func (a *API) GetMessageSignature(ctx context.Context, messageID ids.ID) (hexutil.Bytes, error) {
 	signature, err := a.backend.GetMessageSignature(messageID)